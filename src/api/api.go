@@ -7,6 +7,7 @@ import (
 	fiber "github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/gofiber/swagger"
 	"go.uber.org/zap"
 
@@ -15,6 +16,9 @@ import (
 	"github.com/sudoblockio/icon-go-api/api/ws"
 	"github.com/sudoblockio/icon-go-api/config"
 	"github.com/sudoblockio/icon-go-api/global"
+	"github.com/sudoblockio/icon-go-api/metrics"
+	"github.com/sudoblockio/icon-go-api/tracing"
+	"github.com/sudoblockio/icon-go-api/webhooks"
 )
 
 // @title Icon Go API
@@ -22,7 +26,19 @@ import (
 // @description The icon tracker API
 func Start() *fiber.App {
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		// Client ip for rate limiting behind a proxy
+		ProxyHeader: config.Config.RestProxyHeader,
+
+		// Structured error responses
+		ErrorHandler: rest.ErrorHandler,
+	})
+
+	// Request ID Middleware
+	// Set first so every error response carries it
+	app.Use(requestid.New(requestid.Config{
+		ContextKey: rest.RequestIDLocalsKey,
+	}))
 
 	// Logging middleware
 	app.Use(func(c *fiber.Ctx) error {
@@ -32,6 +48,12 @@ func Start() *fiber.App {
 		return c.Next()
 	})
 
+	// Tracing Middleware
+	app.Use(tracing.NewFiberMiddleware())
+
+	// Metrics Middleware
+	app.Use(metrics.NewFiberMiddleware())
+
 	// CORS Middleware
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:  config.Config.CORSAllowOrigins,
@@ -49,6 +71,14 @@ func Start() *fiber.App {
 		},
 	}))
 
+	// Rate Limit Middleware
+	if config.Config.RateLimitEnabled {
+		app.Use(rest.RateLimit())
+	}
+
+	// Indexed Block Middleware
+	app.Use(rest.IndexedBlock())
+
 	// Enforces strict typing for query parameters
 	fiber.SetParserDecoder(fiber.ParserConfig{
 		SetAliasTag:       "query",
@@ -62,6 +92,11 @@ func Start() *fiber.App {
 	app.Get("/version", handlerVersion)
 	app.Get("/metadata", handlerMetadata)
 
+	// Response cache
+	if config.Config.RestCacheEnabled {
+		rest.StartResponseCache()
+	}
+
 	// Add handlers
 	rest.BlocksAddHandlers(app)
 	rest.TransactionsAddHandlers(app)
@@ -69,7 +104,16 @@ func Start() *fiber.App {
 	rest.AddressesAddHandlers(app)
 	rest.StatsAddHandlers(app)
 	rest.SuppliesAddHandlers(app)
+	rest.StatusAddHandlers(app)
+	rest.SearchAddHandlers(app)
+	rest.TokensAddHandlers(app)
 	ws.WebsocketsAddHandlers(app)
+	if webhooks.Active() {
+		rest.WebhooksAddHandlers(app)
+	}
+	if config.Config.RateLimitEnabled && config.Config.AdminAPIKey != "" {
+		rest.ApiKeysAddHandlers(app)
+	}
 
 	go app.Listen(":" + config.Config.APIPort)
 
@@ -91,8 +91,19 @@ const docTemplate = `{
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
@@ -110,7 +121,7 @@ const docTemplate = `{
                 "tags": [
                     "Addresses"
                 ],
-                "summary": "Get Contracts",
+                "summary": "Get Contract Addresses",
                 "parameters": [
                     {
                         "type": "string",
@@ -174,8 +185,19 @@ const docTemplate = `{
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
@@ -210,11 +232,28 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Address"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
@@ -255,16 +294,27 @@ const docTemplate = `{
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/blocks": {
+        "/api/v1/addresses/{address}/activity": {
             "get": {
-                "description": "get historical blocks",
+                "description": "get transactions, internal transactions and token transfers of an address in one feed, latest first",
                 "consumes": [
                     "*/*"
                 ],
@@ -272,56 +322,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Blocks"
+                    "Addresses"
                 ],
-                "summary": "Get Blocks",
+                "summary": "Get Address Activity",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "amount of records",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "skip to a record",
-                        "name": "skip",
-                        "in": "query"
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "find by block number",
-                        "name": "number",
+                        "description": "amount of records",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "range by start block number",
-                        "name": "start_number",
+                        "type": "string",
+                        "description": "cursor from the X-NEXT-CURSOR header of the previous page",
+                        "name": "cursor",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "range by end block number",
-                        "name": "end_number",
+                        "type": "string",
+                        "description": "transaction, internal_transaction, or token_transfer",
+                        "name": "type",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "find by block hash",
-                        "name": "hash",
+                        "description": "in or out",
+                        "name": "direction",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "find by block creator",
-                        "name": "created_by",
+                        "type": "integer",
+                        "description": "find by block timestamp range, microsecond epoch",
+                        "name": "start_timestamp",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "desc or asc",
-                        "name": "sort",
+                        "type": "integer",
+                        "description": "find by block timestamp range, microsecond epoch",
+                        "name": "end_timestamp",
                         "in": "query"
                     }
                 ],
@@ -331,23 +376,34 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.BlockList"
+                                "$ref": "#/definitions/rest.Activity"
                             }
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/blocks/timestamp/{timestamp}": {
+        "/api/v1/addresses/{address}/balance-history": {
             "get": {
-                "description": "get details of a block based on timestamp in millisecond epoch time",
+                "description": "get the ICX balance of an address after each block or UTC day that changed it, latest first\nrebuilt from the current balance and the ICX moved by transactions, internal transactions and fees\nwith block_number or timestamp, the balance at that block or timestamp",
                 "consumes": [
                     "*/*"
                 ],
@@ -355,38 +411,88 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Blocks"
+                    "Addresses"
                 ],
-                "summary": "Get Block Details By Nearest Timestamp",
+                "summary": "Get Address Balance History",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "timestamp",
-                        "name": "timestamp",
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor from the X-NEXT-CURSOR header of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "day or block, defaults to day",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "balance at a block",
+                        "name": "block_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "balance at a block timestamp, microsecond epoch",
+                        "name": "timestamp",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Block"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rest.BalancePoint"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/blocks/{number}": {
+        "/api/v1/addresses/{address}/portfolio": {
             "get": {
-                "description": "get details of a block",
+                "description": "get the ICX and IRC2, IRC3 and IRC31 token holdings of an address, with USD values where a price source has the price\ntokens are sorted by USD value, then contract address, and paged",
                 "consumes": [
                     "*/*"
                 ],
@@ -394,38 +500,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Blocks"
+                    "Addresses"
                 ],
-                "summary": "Get Block Details",
+                "summary": "Get Address Portfolio",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "block number",
-                        "name": "number",
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "amount of tokens",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a token",
+                        "name": "skip",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Block"
+                            "$ref": "#/definitions/rest.Portfolio"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/logs": {
+        "/api/v1/addresses/{address}/token-balances": {
             "get": {
-                "description": "get historical logs",
+                "description": "get the IRC2 token balances of an address at a block, from its current token balances less the token transfers after the block",
                 "consumes": [
                     "*/*"
                 ],
@@ -433,56 +568,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Logs"
+                    "Addresses"
                 ],
-                "summary": "Get Logs",
+                "summary": "Get Address Token Balances",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "amount of records",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "skip to a record",
-                        "name": "skip",
-                        "in": "query"
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "skip to a block",
+                        "description": "block to get the balances at, the latest block if left out",
                         "name": "block_number",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "For block range queries, a start block. Invalid with block_number",
-                        "name": "block_start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "For block range queries, an end block. Invalid with block_number",
-                        "name": "block_end",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "find by transaction hash",
-                        "name": "transaction_hash",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "find by address",
-                        "name": "address",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "find by method",
-                        "name": "method",
+                        "type": "boolean",
+                        "description": "check each balance exactly against balanceOf on a node, needs a node that keeps the state of the block",
+                        "name": "verify",
                         "in": "query"
                     }
                 ],
@@ -492,252 +598,226 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Log"
+                                "$ref": "#/definitions/rest.TokenBalance"
                             }
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/stats": {
-            "get": {
-                "description": "get json with a summary of stats",
-                "consumes": [
-                    "*/*"
-                ],
-                "tags": [
-                    "Stats"
-                ],
-                "summary": "Get Stats",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "504": {
+                        "description": "Gateway Timeout",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/stats/circulating-supply": {
+        "/api/v1/admin/api-keys": {
             "get": {
-                "description": "get circulating supply (total supply - burn wallet balance)",
+                "description": "get list of api keys created through the admin endpoints. Requires the X-ADMIN-KEY header.",
                 "consumes": [
                     "*/*"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Stats"
+                    "Admin"
                 ],
-                "summary": "Get Circulating Supply",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "number"
-                        }
+                "summary": "Get Api Keys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "admin key",
+                        "name": "X-ADMIN-KEY",
+                        "in": "header",
+                        "required": true
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
                     }
-                }
-            }
-        },
-        "/api/v1/stats/market-cap": {
-            "get": {
-                "description": "get mkt cap (Coin Gecko Price * circulating supply)",
-                "consumes": [
-                    "*/*"
-                ],
-                "tags": [
-                    "Stats"
                 ],
-                "summary": "Get Market Cap",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "number"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ApiKey"
+                            }
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/stats/total-supply": {
-            "get": {
-                "description": "get total supply",
-                "consumes": [
-                    "*/*"
-                ],
-                "tags": [
-                    "Stats"
-                ],
-                "summary": "Get Total Supply",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "number"
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "504": {
+                        "description": "Gateway Timeout",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/supplies": {
-            "get": {
-                "description": "get json with a summary of stats",
+            },
+            "post": {
+                "description": "create an api key with its own rate limit, the key is only returned here. Requires the X-ADMIN-KEY header.",
                 "consumes": [
-                    "*/*"
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "Supplies"
+                    "Admin"
                 ],
-                "summary": "Get Supplies",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                "summary": "Create Api Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "admin key",
+                        "name": "X-ADMIN-KEY",
+                        "in": "header",
+                        "required": true
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    {
+                        "description": "name, rate in requests per second, burst, and daily_quota, 0 for unlimited",
+                        "name": "api_key",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.ApiKeyBody"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/supplies/circulating-supply": {
-            "get": {
-                "description": "get circulating supply (total supply - burn wallet balance)",
-                "consumes": [
-                    "*/*"
                 ],
-                "tags": [
-                    "Supplies"
-                ],
-                "summary": "Get Circulating Supply",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "number"
+                            "$ref": "#/definitions/rest.ApiKeyCreated"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/supplies/market-cap": {
-            "get": {
-                "description": "get mkt cap (Coin Gecko Price * circulating supply)",
-                "consumes": [
-                    "*/*"
-                ],
-                "tags": [
-                    "Supplies"
-                ],
-                "summary": "Get Market Cap",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "number"
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "504": {
+                        "description": "Gateway Timeout",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/supplies/total-supply": {
-            "get": {
-                "description": "get total supply",
+        "/api/v1/admin/api-keys/{id}": {
+            "delete": {
+                "description": "delete an api key created through the admin endpoints. Requires the X-ADMIN-KEY header.",
                 "consumes": [
                     "*/*"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Supplies"
+                    "Admin"
+                ],
+                "summary": "Delete Api Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "admin key",
+                        "name": "X-ADMIN-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "api key id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get Total Supply",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "number"
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/transactions": {
+        "/api/v1/blocks": {
             "get": {
-                "description": "get historical transactions",
+                "description": "get historical blocks",
                 "consumes": [
-                    "application/json",
-                    "text/csv"
+                    "*/*"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Blocks"
                 ],
-                "summary": "Get Transactions",
+                "summary": "Get Blocks",
                 "parameters": [
                     {
                         "type": "integer",
@@ -752,45 +832,33 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "find by from address",
-                        "name": "from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "find by to address",
-                        "name": "to",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "find by type",
-                        "name": "type",
+                        "type": "integer",
+                        "description": "find by block number",
+                        "name": "number",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "find by block number",
-                        "name": "block_number",
+                        "description": "range by start block number",
+                        "name": "start_number",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "find by block number range",
-                        "name": "start_block_number",
+                        "description": "range by end block number",
+                        "name": "end_number",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "find by block number range",
-                        "name": "end_block_number",
+                        "type": "string",
+                        "description": "find by block hash",
+                        "name": "hash",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "find by method",
-                        "name": "method",
+                        "description": "find by block creator",
+                        "name": "created_by",
                         "in": "query"
                     },
                     {
@@ -802,24 +870,38 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "CSV Response",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BlockList"
+                            }
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/transactions/address/{address}": {
+        "/api/v1/blocks/timestamp/{timestamp}": {
             "get": {
-                "description": "get transactions by address",
+                "description": "get details of a block based on timestamp in millisecond epoch time",
                 "consumes": [
                     "*/*"
                 ],
@@ -827,26 +909,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Blocks"
                 ],
-                "summary": "Get Transactions by address",
+                "summary": "Get Block Details By Nearest Timestamp",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "amount of records",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "skip to a record",
-                        "name": "skip",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "address",
-                        "name": "address",
+                        "description": "timestamp",
+                        "name": "timestamp",
                         "in": "path",
                         "required": true
                     }
@@ -855,73 +925,39 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TransactionList"
+                            "$ref": "#/definitions/models.Block"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/transactions/block-number/{block_number}": {
-            "get": {
-                "description": "get transactions by block_number",
-                "consumes": [
-                    "*/*"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Transactions"
-                ],
-                "summary": "Get Transactions by block_number",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "amount of records",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "skip to a record",
-                        "name": "skip",
-                        "in": "query"
                     },
-                    {
-                        "type": "string",
-                        "description": "block_number",
-                        "name": "block_number",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.TransactionList"
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "504": {
+                        "description": "Gateway Timeout",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/transactions/details/{hash}": {
+        "/api/v1/blocks/{number}": {
             "get": {
-                "description": "get details of a transaction",
+                "description": "get details of a block",
                 "consumes": [
                     "*/*"
                 ],
@@ -929,14 +965,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Blocks"
                 ],
-                "summary": "Get Transaction",
+                "summary": "Get Block Details",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "transaction hash",
-                        "name": "hash",
+                        "type": "integer",
+                        "description": "block number",
+                        "name": "number",
                         "in": "path",
                         "required": true
                     }
@@ -945,22 +981,39 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Transaction"
+                            "$ref": "#/definitions/models.Block"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/transactions/icx/{address}": {
+        "/api/v1/logs": {
             "get": {
-                "description": "get ICX transactions to or from an address",
+                "description": "get historical logs",
                 "consumes": [
                     "*/*"
                 ],
@@ -968,9 +1021,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Logs"
                 ],
-                "summary": "Get ICX Transactions by Address",
+                "summary": "Get Logs",
                 "parameters": [
                     {
                         "type": "integer",
@@ -986,10 +1039,45 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "address",
+                        "description": "cursor from the X-NEXT-CURSOR header of the previous page, invalid with skip",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a block",
+                        "name": "block_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "For block range queries, a start block. Invalid with block_number",
+                        "name": "block_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "For block range queries, an end block. Invalid with block_number",
+                        "name": "block_end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by transaction hash",
+                        "name": "transaction_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by address",
                         "name": "address",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by method",
+                        "name": "method",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -998,23 +1086,40 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Transaction"
+                                "$ref": "#/definitions/models.Log"
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/transactions/internal/address/{address}": {
+        "/api/v1/search": {
             "get": {
-                "description": "Get internal transactions by address",
+                "description": "find blocks by number or hash, transactions by hash, addresses, and contracts or tokens by name or symbol\nnames and symbols match by prefix, and by word similarity from 3 characters by default, shorter than 2 characters by default they are not searched",
                 "consumes": [
                     "*/*"
                 ],
@@ -1022,28 +1127,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Search"
                 ],
-                "summary": "Get Internal Transactions By Address",
+                "summary": "Search",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "amount of records",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "block number, block or transaction hash, address, or token name or symbol",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "skip to a record",
-                        "name": "skip",
+                        "description": "amount of records, for typeahead",
+                        "name": "limit",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "find by address",
-                        "name": "address",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -1052,77 +1151,98 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.TransactionInternalList"
+                                "$ref": "#/definitions/rest.SearchResult"
                             }
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/transactions/internal/block-number/{block_number}": {
+        "/api/v1/stats": {
             "get": {
-                "description": "Get internal transactions by block number",
+                "description": "get json with a summary of stats, refreshed in the background\nvalues are from the last successful refresh, with its timestamp and the error of the last refresh if it failed",
                 "consumes": [
                     "*/*"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Transactions"
+                    "Stats"
                 ],
-                "summary": "Get Internal Transactions By Block Number",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "amount of records",
-                        "name": "limit",
-                        "in": "query"
+                "summary": "Get Stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stats.Stats"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "skip to a record",
-                        "name": "skip",
-                        "in": "query"
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "block_number",
-                        "name": "block_number",
-                        "in": "path",
-                        "required": true
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
                     }
+                }
+            }
+        },
+        "/api/v1/stats/circulating-supply": {
+            "get": {
+                "description": "get circulating supply (total supply - burned and locked wallet balances)",
+                "consumes": [
+                    "*/*"
+                ],
+                "tags": [
+                    "Stats"
                 ],
+                "summary": "Get Circulating Supply",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.TransactionInternalList"
-                            }
+                            "type": "number"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/transactions/internal/{hash}": {
+        "/api/v1/stats/daily": {
             "get": {
-                "description": "Get internal transactions by hash",
+                "description": "get a network metric per UTC day, rolled up in the background from the chain tables by the stats writer\ndays are empty until an instance runs with STATS_WRITER_ENABLED\nmetrics are transaction_count, active_addresses, new_addresses, new_contracts, total_fees, icx_volume, token_transfer_count\nthere is no token transfer volume, amounts of different tokens can't be summed, token_transfer_count counts the transfers of every token instead\nresponds with CSV when the Accept header is text/csv",
                 "consumes": [
                     "*/*"
                 ],
@@ -1130,28 +1250,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Stats"
                 ],
-                "summary": "Get Internal Transactions By Hash",
+                "summary": "Get Daily Stats",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "amount of records",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "metric",
+                        "name": "metric",
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "skip to a record",
-                        "name": "skip",
+                        "type": "string",
+                        "description": "first day, YYYY-MM-DD, defaults to 30 days up to end",
+                        "name": "start",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "find by hash",
-                        "name": "hash",
-                        "in": "path",
-                        "required": true
+                        "description": "last day, YYYY-MM-DD, defaults to today",
+                        "name": "end",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1160,77 +1280,98 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.TransactionInternalList"
+                                "$ref": "#/definitions/models.DailyStat"
                             }
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/transactions/token-holders/token-contract/{token_contract_address}": {
+        "/api/v1/stats/market-cap": {
             "get": {
-                "description": "get token holders",
+                "description": "get mkt cap (ICX price * circulating supply)",
                 "consumes": [
                     "*/*"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Transactions"
+                    "Stats"
                 ],
-                "summary": "Get Token Holders By Token Contract",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "amount of records",
-                        "name": "limit",
-                        "in": "query"
+                "summary": "Get Market Cap",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "number"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "skip to a record",
-                        "name": "skip",
-                        "in": "query"
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "find by token contract address",
-                        "name": "token_contract_address",
-                        "in": "path",
-                        "required": true
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
                     }
+                }
+            }
+        },
+        "/api/v1/stats/total-supply": {
+            "get": {
+                "description": "get total supply",
+                "consumes": [
+                    "*/*"
+                ],
+                "tags": [
+                    "Stats"
                 ],
+                "summary": "Get Total Supply",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.TokenAddress"
-                            }
+                            "type": "number"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/transactions/token-transfers": {
+        "/api/v1/status": {
             "get": {
-                "description": "get historical token transfers",
+                "description": "get data freshness, how many blocks the indexed data is behind the icon node. Status is ok, degraded when the lag is past the threshold, or unknown.",
                 "consumes": [
                     "*/*"
                 ],
@@ -1238,88 +1379,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Status"
                 ],
-                "summary": "Get Token Transfers",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "amount of records",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "skip to a record",
-                        "name": "skip",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "find by from address",
-                        "name": "from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "find by to address",
-                        "name": "to",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "find by block number",
-                        "name": "block_number",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "find by block number range",
-                        "name": "start_block_number",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "find by block number range",
-                        "name": "end_block_number",
-                        "in": "query"
+                "summary": "Get Status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/freshness.Status"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "find by token contract",
-                        "name": "token_contract_address",
-                        "in": "query"
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "find by transaction hash",
-                        "name": "transaction_hash",
-                        "in": "query"
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
                     }
+                }
+            }
+        },
+        "/api/v1/supplies": {
+            "get": {
+                "description": "get json with a summary of stats, refreshed in the background\nvalues are from the last successful refresh, with its timestamp and the error of the last refresh if it failed",
+                "consumes": [
+                    "*/*"
+                ],
+                "tags": [
+                    "Supplies"
                 ],
+                "summary": "Get Supplies",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.TokenTransfer"
-                            }
+                            "$ref": "#/definitions/stats.Stats"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/transactions/token-transfers/address/{address}": {
+        "/api/v1/supplies/breakdown": {
             "get": {
-                "description": "get historical token transfers by address",
+                "description": "get the ICX supply broken down into staked, bonded, delegated, burned, locked and treasury ICX\nstaking is from the chain SCORE's network info, burned, locked and treasury are the balances of the configured wallets",
                 "consumes": [
                     "*/*"
                 ],
@@ -1327,53 +1446,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
-                ],
-                "summary": "Get Token Transfer By Address",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "amount of records",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "skip to a record",
-                        "name": "skip",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "find by address",
-                        "name": "address",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Supplies"
                 ],
+                "summary": "Get Supply Breakdown",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.TokenTransfer"
-                            }
+                            "$ref": "#/definitions/models.SupplySnapshot"
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/transactions/token-transfers/token-contract/{token_contract_address}": {
+        "/api/v1/supplies/breakdown/history": {
             "get": {
-                "description": "get historical token transfers by token contract",
+                "description": "get snapshots of the supply breakdown, one per history interval, latest first\nsnapshots are only recorded by an instance running with STATS_WRITER_ENABLED",
                 "consumes": [
                     "*/*"
                 ],
@@ -1381,9 +1481,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Supplies"
                 ],
-                "summary": "Get Token Transfers By Token Contract",
+                "summary": "Get Supply Breakdown History",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1398,11 +1498,16 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "find by token contract address",
-                        "name": "token_contract_address",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "snapshots at or after, unix seconds",
+                        "name": "start_timestamp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "snapshots at or before, unix seconds",
+                        "name": "end_timestamp",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1411,142 +1516,1877 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.TokenTransfer"
+                                "$ref": "#/definitions/models.SupplySnapshot"
                             }
                         }
                     },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/metadata": {
+        "/api/v1/supplies/circulating-supply": {
             "get": {
-                "description": "get the metadata of server.",
+                "description": "get circulating supply (total supply - burned and locked wallet balances)",
                 "consumes": [
                     "*/*"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Version"
+                    "Supplies"
                 ],
-                "summary": "Show the metadata of server.",
+                "summary": "Get Circulating Supply",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "number"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
         },
-        "/version": {
+        "/api/v1/supplies/market-cap": {
             "get": {
-                "description": "get the version of server.",
+                "description": "get mkt cap (ICX price * circulating supply)",
                 "consumes": [
                     "*/*"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Version"
+                    "Supplies"
                 ],
-                "summary": "Show the version of server.",
+                "summary": "Get Market Cap",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "number"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Address": {
-            "type": "object",
-            "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "audit_tx_hash": {
-                    "type": "string"
-                },
-                "balance": {
-                    "type": "number"
-                },
-                "code_hash": {
-                    "type": "string"
-                },
+        },
+        "/api/v1/supplies/total-supply": {
+            "get": {
+                "description": "get total supply",
+                "consumes": [
+                    "*/*"
+                ],
+                "tags": [
+                    "Supplies"
+                ],
+                "summary": "Get Total Supply",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tokens/{contract}/holder-stats": {
+            "get": {
+                "description": "get the holder count, top 10 and top 100 holder shares, Gini coefficient and balance histogram of a token\nholder count changes are against the daily holder snapshots of the day before and a week before\nthey are left out without snapshots, which are only taken by an instance running with STATS_WRITER_ENABLED",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Get Token Holder Stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token contract address",
+                        "name": "contract",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.TokenHolderStats"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions": {
+            "get": {
+                "description": "get historical transactions",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor from the X-NEXT-CURSOR header of the previous page with the same sort, invalid with skip",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by from address",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by to address",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "find by block number",
+                        "name": "block_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "find by block number range",
+                        "name": "start_block_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "find by block number range",
+                        "name": "end_block_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by method",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "desc or asc",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV Response",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions/address/{address}": {
+            "get": {
+                "description": "get transactions by address",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Transactions by address",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TransactionList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions/block-number/{block_number}": {
+            "get": {
+                "description": "get transactions by block_number",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Transactions by block_number",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "block_number",
+                        "name": "block_number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TransactionList"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions/details/{hash}": {
+            "get": {
+                "description": "get details of a transaction",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "transaction hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions/icx/{address}": {
+            "get": {
+                "description": "get ICX transactions to or from an address",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get ICX Transactions by Address",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Transaction"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions/internal/address/{address}": {
+            "get": {
+                "description": "Get internal transactions by address",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Internal Transactions By Address",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TransactionInternalList"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions/internal/block-number/{block_number}": {
+            "get": {
+                "description": "Get internal transactions by block number",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Internal Transactions By Block Number",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "block_number",
+                        "name": "block_number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TransactionInternalList"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions/internal/{hash}": {
+            "get": {
+                "description": "Get internal transactions by hash",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Internal Transactions By Hash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TransactionInternalList"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions/token-holders/token-contract/{token_contract_address}": {
+            "get": {
+                "description": "get token holders",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Token Holders By Token Contract",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by token contract address",
+                        "name": "token_contract_address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TokenAddress"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions/token-transfers": {
+            "get": {
+                "description": "get historical token transfers",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Token Transfers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor from the X-NEXT-CURSOR header of the previous page, invalid with skip",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by from address",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by to address",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "find by block number",
+                        "name": "block_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "find by block number range",
+                        "name": "start_block_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "find by block number range",
+                        "name": "end_block_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by token contract",
+                        "name": "token_contract_address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by transaction hash",
+                        "name": "transaction_hash",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TokenTransfer"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions/token-transfers/address/{address}": {
+            "get": {
+                "description": "get historical token transfers by address",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Token Transfer By Address",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TokenTransfer"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transactions/token-transfers/token-contract/{token_contract_address}": {
+            "get": {
+                "description": "get historical token transfers by token contract",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Token Transfers By Token Contract",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by token contract address",
+                        "name": "token_contract_address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TokenTransfer"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks": {
+            "get": {
+                "description": "get list of webhooks. Requires the X-ADMIN-KEY header.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Webhooks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "admin key",
+                        "name": "X-ADMIN-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "find by event type",
+                        "name": "event_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Webhook"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "register a url to receive events as signed POST requests. Requires the X-ADMIN-KEY header. Urls resolving to loopback, private or link-local addresses are rejected. The secret is only returned here. Each delivery has an X-Webhook-Signature header of \"sha256=\" + hex HMAC-SHA256 of \"\u003cX-Webhook-Timestamp\u003e.\u003cbody\u003e\" keyed with the secret.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "admin key",
+                        "name": "X-ADMIN-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "url, event_type (blocks, transactions, logs, token_transfers) and optional address, contract, and method filters",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.WebhookBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{id}": {
+            "get": {
+                "description": "get details of a webhook. Requires the X-ADMIN-KEY header.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "admin key",
+                        "name": "X-ADMIN-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete a webhook and its deliveries. Requires the X-ADMIN-KEY header.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "admin key",
+                        "name": "X-ADMIN-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{id}/dead-letters": {
+            "get": {
+                "description": "get deliveries of a webhook that failed every attempt, latest first. Requires the X-ADMIN-KEY header.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Webhook Dead Letters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "admin key",
+                        "name": "X-ADMIN-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "get the delivery log of a webhook, latest first. Requires the X-ADMIN-KEY header.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Webhook Deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "admin key",
+                        "name": "X-ADMIN-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "amount of records",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "skip to a record",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "one of pending, delivered, dead",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/metadata": {
+            "get": {
+                "description": "get the metadata of server.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Version"
+                ],
+                "summary": "Show the metadata of server.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "get the version of server.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Version"
+                ],
+                "summary": "Show the version of server.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "freshness.Status": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "indexed_block_number": {
+                    "type": "integer"
+                },
+                "lag": {
+                    "type": "integer"
+                },
+                "lag_threshold": {
+                    "type": "integer"
+                },
+                "node_block_number": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_timestamp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Address": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "audit_tx_hash": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "code_hash": {
+                    "type": "string"
+                },
                 "contract_type": {
                     "type": "string"
                 },
                 "contract_updated_block": {
                     "type": "integer"
                 },
-                "created_timestamp": {
+                "created_timestamp": {
+                    "type": "integer"
+                },
+                "deploy_tx_hash": {
+                    "type": "string"
+                },
+                "is_contract": {
+                    "type": "boolean"
+                },
+                "is_nft": {
+                    "type": "boolean"
+                },
+                "is_prep": {
+                    "type": "boolean"
+                },
+                "is_token": {
+                    "type": "boolean"
+                },
+                "log_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "token_standard": {
+                    "type": "string"
+                },
+                "token_transfer_count": {
+                    "type": "integer"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                },
+                "transaction_internal_count": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AddressList": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "is_contract": {
+                    "type": "boolean"
+                },
+                "is_nft": {
+                    "type": "boolean"
+                },
+                "is_token": {
+                    "type": "boolean"
+                },
+                "token_standard": {
+                    "type": "string"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ApiKey": {
+            "type": "object",
+            "properties": {
+                "burst": {
+                    "type": "integer"
+                },
+                "created_timestamp": {
+                    "type": "integer"
+                },
+                "daily_quota": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key_hash": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Block": {
+            "type": "object",
+            "properties": {
+                "block_time": {
+                    "type": "integer"
+                },
+                "failed_transaction_count": {
+                    "type": "integer"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "internal_transaction_amount": {
+                    "type": "string"
+                },
+                "internal_transaction_count": {
+                    "type": "integer"
+                },
+                "item_id": {
+                    "type": "string"
+                },
+                "item_timestamp": {
+                    "type": "string"
+                },
+                "merkle_root_hash": {
+                    "type": "string"
+                },
+                "next_leader": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "parent_hash": {
+                    "type": "string"
+                },
+                "peer_id": {
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "Base",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "transaction_amount": {
+                    "type": "string"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                },
+                "transaction_fees": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BlockList": {
+            "type": "object",
+            "properties": {
+                "hash": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "peer_id": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "transaction_amount": {
+                    "type": "string"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                },
+                "transaction_fees": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ContractList": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "created_timestamp": {
+                    "type": "integer"
+                },
+                "is_token": {
+                    "type": "boolean"
+                },
+                "log_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "token_standard": {
+                    "type": "string"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DailyStat": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "deploy_tx_hash": {
+                "metric": {
                     "type": "string"
                 },
-                "is_contract": {
-                    "type": "boolean"
-                },
-                "is_nft": {
-                    "type": "boolean"
+                "updated_timestamp": {
+                    "type": "integer"
                 },
-                "is_prep": {
-                    "type": "boolean"
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Log": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
                 },
-                "is_token": {
-                    "type": "boolean"
+                "block_number": {
+                    "type": "integer"
                 },
-                "log_count": {
+                "block_timestamp": {
                     "type": "integer"
                 },
-                "name": {
+                "data": {
                     "type": "string"
                 },
-                "owner": {
+                "indexed": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
+                "log_index": {
+                    "type": "integer"
                 },
-                "symbol": {
+                "method": {
                     "type": "string"
                 },
-                "token_standard": {
+                "transaction_hash": {
                     "type": "string"
+                }
+            }
+        },
+        "models.SupplySnapshot": {
+            "type": "object",
+            "properties": {
+                "burned": {
+                    "type": "number"
                 },
-                "token_transfer_count": {
-                    "type": "integer"
+                "circulating_supply": {
+                    "type": "number"
                 },
-                "transaction_count": {
+                "id": {
                     "type": "integer"
                 },
-                "transaction_internal_count": {
+                "locked": {
+                    "type": "number"
+                },
+                "staking_ratio": {
+                    "type": "number"
+                },
+                "timestamp": {
                     "type": "integer"
                 },
-                "type": {
-                    "type": "string"
+                "total_bonded": {
+                    "type": "number"
+                },
+                "total_delegated": {
+                    "type": "number"
+                },
+                "total_staked": {
+                    "type": "number"
+                },
+                "total_supply": {
+                    "type": "number"
+                },
+                "treasury": {
+                    "type": "number"
                 }
             }
         },
-        "models.AddressList": {
+        "models.TokenAddress": {
             "type": "object",
             "properties": {
                 "address": {
@@ -1555,197 +3395,335 @@ const docTemplate = `{
                 "balance": {
                     "type": "number"
                 },
-                "is_contract": {
-                    "type": "boolean"
+                "token_contract_address": {
+                    "type": "string"
                 },
-                "is_nft": {
-                    "type": "boolean"
+                "token_standard": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TokenTransfer": {
+            "type": "object",
+            "properties": {
+                "block_number": {
+                    "type": "integer"
                 },
-                "is_token": {
-                    "type": "boolean"
+                "block_timestamp": {
+                    "type": "integer"
                 },
-                "token_standard": {
+                "from_address": {
                     "type": "string"
                 },
-                "transaction_count": {
+                "log_index": {
                     "type": "integer"
                 },
-                "type": {
+                "nft_id": {
+                    "type": "integer"
+                },
+                "to_address": {
                     "type": "string"
+                },
+                "token_contract_address": {
+                    "type": "string"
+                },
+                "token_contract_name": {
+                    "type": "string"
+                },
+                "token_contract_symbol": {
+                    "type": "string"
+                },
+                "transaction_fee": {
+                    "type": "string"
+                },
+                "transaction_hash": {
+                    "type": "string"
+                },
+                "transaction_index": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                },
+                "value_decimal": {
+                    "type": "number"
                 }
             }
         },
-        "models.Block": {
+        "models.Transaction": {
             "type": "object",
             "properties": {
-                "block_time": {
+                "block_hash": {
+                    "type": "string"
+                },
+                "block_number": {
                     "type": "integer"
                 },
-                "failed_transaction_count": {
+                "block_timestamp": {
                     "type": "integer"
                 },
-                "hash": {
+                "cumulative_step_used": {
                     "type": "string"
                 },
-                "internal_transaction_amount": {
+                "data": {
                     "type": "string"
                 },
-                "internal_transaction_count": {
-                    "type": "integer"
+                "data_type": {
+                    "type": "string"
                 },
-                "item_id": {
+                "from_address": {
                     "type": "string"
                 },
-                "item_timestamp": {
+                "hash": {
                     "type": "string"
                 },
-                "merkle_root_hash": {
+                "log_count": {
+                    "type": "integer"
+                },
+                "log_index": {
+                    "type": "integer"
+                },
+                "logs_bloom": {
                     "type": "string"
                 },
-                "next_leader": {
+                "method": {
                     "type": "string"
                 },
-                "number": {
-                    "type": "integer"
+                "nid": {
+                    "type": "string"
                 },
-                "parent_hash": {
+                "nonce": {
                     "type": "string"
                 },
-                "peer_id": {
+                "score_address": {
                     "type": "string"
                 },
                 "signature": {
-                    "description": "Base",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "step_limit": {
+                    "type": "string"
+                },
+                "step_price": {
+                    "type": "string"
+                },
+                "step_used": {
                     "type": "string"
                 },
                 "timestamp": {
                     "type": "integer"
                 },
-                "transaction_amount": {
+                "to_address": {
                     "type": "string"
                 },
-                "transaction_count": {
+                "transaction_fee": {
+                    "type": "string"
+                },
+                "transaction_index": {
                     "type": "integer"
                 },
-                "transaction_fees": {
+                "type": {
                     "type": "string"
                 },
-                "type": {
+                "value": {
                     "type": "string"
                 },
+                "value_decimal": {
+                    "type": "number"
+                },
                 "version": {
                     "type": "string"
                 }
             }
         },
-        "models.BlockList": {
+        "models.TransactionInternalList": {
             "type": "object",
             "properties": {
+                "block_hash": {
+                    "type": "string"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "block_timestamp": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "from_address": {
+                    "type": "string"
+                },
                 "hash": {
                     "type": "string"
                 },
-                "number": {
+                "method": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_address": {
+                    "type": "string"
+                },
+                "transaction_index": {
                     "type": "integer"
                 },
-                "peer_id": {
+                "type": {
                     "type": "string"
                 },
-                "timestamp": {
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TransactionList": {
+            "type": "object",
+            "properties": {
+                "block_number": {
                     "type": "integer"
                 },
-                "transaction_amount": {
+                "block_timestamp": {
+                    "type": "integer"
+                },
+                "data": {
                     "type": "string"
                 },
-                "transaction_count": {
+                "from_address": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "log_index": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_address": {
+                    "type": "string"
+                },
+                "transaction_fee": {
+                    "type": "string"
+                },
+                "transaction_index": {
+                    "type": "integer"
+                },
+                "transaction_type": {
                     "type": "integer"
                 },
-                "transaction_fees": {
+                "type": {
+                    "type": "string"
+                },
+                "value": {
                     "type": "string"
+                },
+                "value_decimal": {
+                    "type": "number"
                 }
             }
         },
-        "models.ContractList": {
+        "models.Webhook": {
             "type": "object",
             "properties": {
                 "address": {
                     "type": "string"
                 },
-                "balance": {
-                    "type": "number"
+                "contract": {
+                    "type": "string"
                 },
                 "created_timestamp": {
                     "type": "integer"
                 },
-                "is_token": {
-                    "type": "boolean"
+                "event_type": {
+                    "type": "string"
                 },
-                "log_count": {
+                "id": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
-                },
-                "status": {
+                "method": {
                     "type": "string"
                 },
-                "symbol": {
+                "secret": {
                     "type": "string"
                 },
-                "token_standard": {
+                "url": {
                     "type": "string"
-                },
-                "transaction_count": {
-                    "type": "integer"
                 }
             }
         },
-        "models.Log": {
+        "models.WebhookDelivery": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "block_number": {
+                "attempts": {
                     "type": "integer"
                 },
-                "block_timestamp": {
+                "created_timestamp": {
                     "type": "integer"
                 },
-                "data": {
+                "event_hash": {
                     "type": "string"
                 },
-                "indexed": {
+                "event_type": {
                     "type": "string"
                 },
-                "log_index": {
+                "id": {
                     "type": "integer"
                 },
-                "method": {
+                "last_error": {
                     "type": "string"
                 },
-                "transaction_hash": {
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "next_attempt_timestamp": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
+                },
+                "updated_timestamp": {
+                    "type": "integer"
+                },
+                "webhook_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.TokenAddress": {
+        "rest.APIError": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "balance": {
-                    "type": "number"
+                "code": {
+                    "type": "string",
+                    "example": "invalid_parameter"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ErrorDetail"
+                    }
                 },
-                "token_contract_address": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "limit must be between 1 and 100"
                 },
-                "token_standard": {
-                    "type": "string"
+                "request_id": {
+                    "type": "string",
+                    "example": "3f1b0e0c-5f8e-4b7e-9d7a-0c2b1a9e4f10"
                 }
             }
         },
-        "models.TokenTransfer": {
+        "rest.Activity": {
             "type": "object",
             "properties": {
                 "block_number": {
@@ -1754,15 +3732,25 @@ const docTemplate = `{
                 "block_timestamp": {
                     "type": "integer"
                 },
+                "direction": {
+                    "type": "string",
+                    "example": "in"
+                },
                 "from_address": {
                     "type": "string"
                 },
                 "log_index": {
                     "type": "integer"
                 },
+                "method": {
+                    "type": "string"
+                },
                 "nft_id": {
                     "type": "integer"
                 },
+                "status": {
+                    "type": "string"
+                },
                 "to_address": {
                     "type": "string"
                 },
@@ -1775,15 +3763,16 @@ const docTemplate = `{
                 "token_contract_symbol": {
                     "type": "string"
                 },
-                "transaction_fee": {
-                    "type": "string"
-                },
                 "transaction_hash": {
                     "type": "string"
                 },
                 "transaction_index": {
                     "type": "integer"
                 },
+                "type": {
+                    "type": "string",
+                    "example": "token_transfer"
+                },
                 "value": {
                     "type": "string"
                 },
@@ -1792,176 +3781,282 @@ const docTemplate = `{
                 }
             }
         },
-        "models.Transaction": {
+        "rest.ApiKeyBody": {
             "type": "object",
             "properties": {
-                "block_hash": {
+                "burst": {
+                    "type": "integer"
+                },
+                "daily_quota": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "block_number": {
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.ApiKeyCreated": {
+            "type": "object",
+            "properties": {
+                "burst": {
                     "type": "integer"
                 },
-                "block_timestamp": {
+                "created_timestamp": {
                     "type": "integer"
                 },
-                "cumulative_step_used": {
-                    "type": "string"
+                "daily_quota": {
+                    "type": "integer"
                 },
-                "data": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "data_type": {
+                "key": {
                     "type": "string"
                 },
-                "from_address": {
+                "key_hash": {
                     "type": "string"
                 },
-                "hash": {
+                "name": {
                     "type": "string"
                 },
-                "log_count": {
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.BalancePoint": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "block_number": {
                     "type": "integer"
                 },
-                "log_index": {
+                "date": {
+                    "type": "string",
+                    "example": "2022-06-30"
+                },
+                "timestamp": {
                     "type": "integer"
+                }
+            }
+        },
+        "rest.ErrorDetail": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "limit"
                 },
-                "logs_bloom": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "limit must be between 1 and 100"
+                }
+            }
+        },
+        "rest.HolderBalanceBucket": {
+            "type": "object",
+            "properties": {
+                "holders": {
+                    "type": "integer"
                 },
-                "method": {
-                    "type": "string"
+                "max": {
+                    "type": "number"
                 },
-                "nid": {
+                "min": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.Portfolio": {
+            "type": "object",
+            "properties": {
+                "address": {
                     "type": "string"
                 },
-                "nonce": {
-                    "type": "string"
+                "icx": {
+                    "$ref": "#/definitions/rest.PortfolioHolding"
                 },
-                "score_address": {
-                    "type": "string"
+                "tokens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PortfolioHolding"
+                    }
                 },
-                "signature": {
-                    "type": "string"
+                "total_value_usd": {
+                    "description": "Sum of the holdings with a price, left out if none have one",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.PortfolioHolding": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
                 },
-                "status": {
-                    "type": "string"
+                "decimals": {
+                    "description": "Left out of IRC2 tokens until read from the token's contract in the background",
+                    "type": "integer"
                 },
-                "step_limit": {
+                "name": {
                     "type": "string"
                 },
-                "step_price": {
+                "price_usd": {
+                    "type": "number"
+                },
+                "symbol": {
                     "type": "string"
                 },
-                "step_used": {
+                "token_contract_address": {
                     "type": "string"
                 },
-                "timestamp": {
-                    "type": "integer"
+                "token_standard": {
+                    "type": "string",
+                    "example": "irc2"
                 },
-                "to_address": {
-                    "type": "string"
+                "value_usd": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.SearchResult": {
+            "type": "object",
+            "properties": {
+                "match": {
+                    "type": "string",
+                    "example": "prefix"
                 },
-                "transaction_fee": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "Balanced Dollar"
                 },
-                "transaction_index": {
-                    "type": "integer"
+                "symbol": {
+                    "type": "string",
+                    "example": "bnUSD"
                 },
                 "type": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "token"
                 },
                 "value": {
-                    "type": "string"
-                },
-                "value_decimal": {
-                    "type": "number"
-                },
-                "version": {
-                    "type": "string"
+                    "description": "Block number, hash, or address",
+                    "type": "string",
+                    "example": "cx88fd7df7ddff82f7cc735c871dc519838cb235bb"
                 }
             }
         },
-        "models.TransactionInternalList": {
+        "rest.TokenBalance": {
             "type": "object",
             "properties": {
-                "block_hash": {
+                "balance": {
+                    "description": "Decimal amount, as precise as the indexer's current balance it's folded back from",
                     "type": "string"
                 },
-                "block_number": {
-                    "type": "integer"
-                },
-                "block_timestamp": {
+                "decimals": {
                     "type": "integer"
                 },
-                "data": {
+                "node_value": {
+                    "description": "Set when verified against a node, which needs the state of the block",
                     "type": "string"
                 },
-                "from_address": {
+                "token_contract_address": {
                     "type": "string"
                 },
-                "hash": {
+                "value": {
+                    "description": "Hex amount in the token's smallest unit, like transfer values\nLeft out with the decimals when the token's decimals aren't known yet",
                     "type": "string"
                 },
-                "method": {
-                    "type": "string"
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "rest.TokenHolderStats": {
+            "type": "object",
+            "properties": {
+                "gini": {
+                    "description": "0 when every holder has the same balance, towards 1 when one holder has it all",
+                    "type": "number"
                 },
-                "status": {
-                    "type": "string"
+                "histogram": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.HolderBalanceBucket"
+                    }
                 },
-                "to_address": {
-                    "type": "string"
+                "holder_count": {
+                    "type": "integer"
                 },
-                "transaction_index": {
+                "holder_count_change_24h": {
+                    "description": "Against the snapshots of the day before and a week before, left out without a snapshot",
                     "type": "integer"
                 },
-                "type": {
-                    "type": "string"
+                "holder_count_change_7d": {
+                    "type": "integer"
                 },
-                "value": {
+                "token_contract_address": {
                     "type": "string"
+                },
+                "top_100_share": {
+                    "type": "number"
+                },
+                "top_10_share": {
+                    "description": "Shares of the total balance held by the largest holders, 0 to 1",
+                    "type": "number"
+                },
+                "total_balance": {
+                    "type": "number"
                 }
             }
         },
-        "models.TransactionList": {
+        "rest.WebhookBody": {
             "type": "object",
             "properties": {
-                "block_number": {
-                    "type": "integer"
-                },
-                "block_timestamp": {
-                    "type": "integer"
-                },
-                "data": {
+                "address": {
                     "type": "string"
                 },
-                "from_address": {
+                "contract": {
                     "type": "string"
                 },
-                "hash": {
+                "event_type": {
                     "type": "string"
                 },
                 "method": {
                     "type": "string"
                 },
-                "status": {
+                "url": {
                     "type": "string"
+                }
+            }
+        },
+        "stats.Stats": {
+            "type": "object",
+            "properties": {
+                "circulating-supply": {
+                    "type": "number"
                 },
-                "to_address": {
-                    "type": "string"
+                "market-cap": {
+                    "type": "number"
                 },
-                "transaction_fee": {
+                "market-cap-error": {
                     "type": "string"
                 },
-                "transaction_type": {
+                "market-cap-updated-timestamp": {
                     "type": "integer"
                 },
-                "type": {
+                "supply-error": {
                     "type": "string"
                 },
-                "value": {
-                    "type": "string"
+                "supply-updated-timestamp": {
+                    "description": "Unix timestamps of the last successful refreshes, 0 before the first",
+                    "type": "integer"
                 },
-                "value_decimal": {
+                "total-supply": {
                     "type": "number"
                 }
             }
@@ -0,0 +1,286 @@
+package rest
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// Activity types
+const (
+	ActivityTypeTransaction         = "transaction"
+	ActivityTypeInternalTransaction = "internal_transaction"
+	ActivityTypeTokenTransfer       = "token_transfer"
+)
+
+// Activity directions, relative to the address
+const (
+	ActivityDirectionIn   = "in"
+	ActivityDirectionOut  = "out"
+	ActivityDirectionSelf = "self"
+)
+
+type ActivityQuery struct {
+	Limit  int    `query:"limit"`
+	Cursor string `query:"cursor"`
+
+	Type           string `query:"type"`
+	Direction      string `query:"direction"`
+	StartTimestamp int64  `query:"start_timestamp"`
+	EndTimestamp   int64  `query:"end_timestamp"`
+}
+
+// Activity - transaction, internal transaction, or token transfer from or to an address
+type Activity struct {
+	Type                 string  `json:"type" example:"token_transfer"`
+	Direction            string  `json:"direction" example:"in"`
+	TransactionHash      string  `json:"transaction_hash"`
+	BlockNumber          int64   `json:"block_number"`
+	BlockTimestamp       int64   `json:"block_timestamp"`
+	TransactionIndex     int64   `json:"transaction_index"`
+	LogIndex             int64   `json:"log_index"`
+	FromAddress          string  `json:"from_address"`
+	ToAddress            string  `json:"to_address"`
+	Value                string  `json:"value"`
+	ValueDecimal         float64 `json:"value_decimal"`
+	Method               string  `json:"method,omitempty"`
+	Status               string  `json:"status,omitempty"`
+	TokenContractAddress string  `json:"token_contract_address,omitempty"`
+	TokenContractName    string  `json:"token_contract_name,omitempty"`
+	TokenContractSymbol  string  `json:"token_contract_symbol,omitempty"`
+	NftId                int64   `json:"nft_id,omitempty"`
+}
+
+// Address Activity
+// @Summary Get Address Activity
+// @Description get transactions, internal transactions and token transfers of an address in one feed, latest first
+// @Tags Addresses
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param address path string true "address"
+// @Param limit query int false "amount of records"
+// @Param cursor query string false "cursor from the X-NEXT-CURSOR header of the previous page"
+// @Param type query string false "transaction, internal_transaction, or token_transfer"
+// @Param direction query string false "in or out"
+// @Param start_timestamp query int false "find by block timestamp range, microsecond epoch"
+// @Param end_timestamp query int false "find by block timestamp range, microsecond epoch"
+// @Router /api/v1/addresses/{address}/activity [get]
+// @Success 200 {object} []Activity
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerGetAddressActivity(c *fiber.Ctx) error {
+	address := c.Params("address")
+
+	params := new(ActivityQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Address Activity Get Handler ERROR: %s", err.Error())
+
+		return errInvalidQuery(err)
+	}
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 25
+	}
+
+	// Check Params
+	if err := checkPaging(params.Limit, 0); err != nil {
+		return err
+	}
+	if params.Type != "" && stringInSlice(params.Type, []string{
+		ActivityTypeTransaction,
+		ActivityTypeInternalTransaction,
+		ActivityTypeTokenTransfer,
+	}) == false {
+		return errInvalidParameter("type", "type must be one of transaction, internal_transaction, token_transfer")
+	}
+	if params.Direction != "" && params.Direction != ActivityDirectionIn && params.Direction != ActivityDirectionOut {
+		return errInvalidParameter("direction", "direction must be in or out")
+	}
+	if params.EndTimestamp != 0 && params.StartTimestamp > params.EndTimestamp {
+		return errInvalidParameter("start_timestamp", "start_timestamp must be before end_timestamp")
+	}
+
+	// Cursor
+	var cursor *crud.Cursor
+	if params.Cursor != "" {
+		var err error
+		cursor, err = crud.DecodeCursor(params.Cursor)
+		if err != nil {
+			return errInvalidParameter("cursor", "invalid cursor")
+		}
+		if cursor.Sort != "desc" {
+			return errInvalidParameter("cursor", "cursor is for a different sort")
+		}
+	}
+
+	// Transaction types in the transactions table
+	transactionTypes := []string{}
+	if params.Type == "" || params.Type == ActivityTypeTransaction {
+		transactionTypes = append(transactionTypes, "transaction")
+	}
+	if params.Type == "" || params.Type == ActivityTypeInternalTransaction {
+		transactionTypes = append(transactionTypes, "log")
+	}
+
+	// Each source returns up to a page, the merged page is the latest of them
+	var wg sync.WaitGroup
+	transactions := &[]models.TransactionList{}
+	tokenTransfers := &[]models.TokenTransfer{}
+	var transactionsErr, tokenTransfersErr error
+
+	if len(transactionTypes) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			transactions, transactionsErr = crud.GetTransactionCrud().SelectManyActivity(
+				c.UserContext(),
+				params.Limit,
+				cursor,
+				address,
+				transactionTypes,
+				params.Direction,
+				params.StartTimestamp,
+				params.EndTimestamp,
+			)
+		}()
+	}
+
+	if params.Type == "" || params.Type == ActivityTypeTokenTransfer {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tokenTransfers, tokenTransfersErr = crud.GetTokenTransferCrud().SelectManyActivity(
+				c.UserContext(),
+				params.Limit,
+				cursor,
+				address,
+				params.Direction,
+				params.StartTimestamp,
+				params.EndTimestamp,
+			)
+		}()
+	}
+
+	// Both use the request, wait for both before responding
+	wg.Wait()
+
+	if transactionsErr != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetAddressActivity",
+			" Error=Could not retrieve transactions: ", transactionsErr.Error(),
+		)
+		return errQuery(transactionsErr, "could not retrieve activity")
+	}
+	if tokenTransfersErr != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetAddressActivity",
+			" Error=Could not retrieve token transfers: ", tokenTransfersErr.Error(),
+		)
+		return errQuery(tokenTransfersErr, "could not retrieve activity")
+	}
+
+	activity := mergeActivity(address, *transactions, *tokenTransfers, params.Limit)
+
+	// Set X-NEXT-CURSOR
+	if len(activity) == params.Limit {
+		lastActivity := activity[len(activity)-1]
+		nextCursor := &crud.Cursor{
+			BlockNumber:      lastActivity.BlockNumber,
+			TransactionIndex: lastActivity.TransactionIndex,
+			LogIndex:         lastActivity.LogIndex,
+			Sort:             "desc",
+		}
+		c.Append("X-NEXT-CURSOR", nextCursor.Encode())
+	}
+
+	body, _ := json.Marshal(&activity)
+	return c.SendString(string(body))
+}
+
+// mergeActivity - latest transactions and token transfers as one feed, at most limit long
+// Ordered by (block_number, transaction_index, log_index) descending, the order of the cursor
+// Regular transactions have log index -1 so they follow their own internal transactions and transfers
+func mergeActivity(
+	address string,
+	transactions []models.TransactionList,
+	tokenTransfers []models.TokenTransfer,
+	limit int,
+) []Activity {
+	activity := make([]Activity, 0, len(transactions)+len(tokenTransfers))
+
+	for _, transaction := range transactions {
+		activityType := ActivityTypeTransaction
+		if transaction.Type == "log" {
+			activityType = ActivityTypeInternalTransaction
+		}
+
+		activity = append(activity, Activity{
+			Type:             activityType,
+			Direction:        activityDirection(address, transaction.FromAddress, transaction.ToAddress),
+			TransactionHash:  transaction.Hash,
+			BlockNumber:      transaction.BlockNumber,
+			BlockTimestamp:   transaction.BlockTimestamp,
+			TransactionIndex: transaction.TransactionIndex,
+			LogIndex:         transaction.LogIndex,
+			FromAddress:      transaction.FromAddress,
+			ToAddress:        transaction.ToAddress,
+			Value:            transaction.Value,
+			ValueDecimal:     transaction.ValueDecimal,
+			Method:           transaction.Method,
+			Status:           transaction.Status,
+		})
+	}
+
+	for _, tokenTransfer := range tokenTransfers {
+		activity = append(activity, Activity{
+			Type:                 ActivityTypeTokenTransfer,
+			Direction:            activityDirection(address, tokenTransfer.FromAddress, tokenTransfer.ToAddress),
+			TransactionHash:      tokenTransfer.TransactionHash,
+			BlockNumber:          tokenTransfer.BlockNumber,
+			BlockTimestamp:       tokenTransfer.BlockTimestamp,
+			TransactionIndex:     tokenTransfer.TransactionIndex,
+			LogIndex:             tokenTransfer.LogIndex,
+			FromAddress:          tokenTransfer.FromAddress,
+			ToAddress:            tokenTransfer.ToAddress,
+			Value:                tokenTransfer.Value,
+			ValueDecimal:         tokenTransfer.ValueDecimal,
+			TokenContractAddress: tokenTransfer.TokenContractAddress,
+			TokenContractName:    tokenTransfer.TokenContractName,
+			TokenContractSymbol:  tokenTransfer.TokenContractSymbol,
+			NftId:                tokenTransfer.NftId,
+		})
+	}
+
+	sort.SliceStable(activity, func(i, j int) bool {
+		a := &crud.Cursor{BlockNumber: activity[i].BlockNumber, TransactionIndex: activity[i].TransactionIndex, LogIndex: activity[i].LogIndex}
+		b := &crud.Cursor{BlockNumber: activity[j].BlockNumber, TransactionIndex: activity[j].TransactionIndex, LogIndex: activity[j].LogIndex}
+		return b.Less(a)
+	})
+
+	if len(activity) > limit {
+		activity = activity[:limit]
+	}
+
+	return activity
+}
+
+// activityDirection - direction of a transfer relative to the address
+func activityDirection(address string, fromAddress string, toAddress string) string {
+	switch {
+	case fromAddress == address && toAddress == address:
+		return ActivityDirectionSelf
+	case fromAddress == address:
+		return ActivityDirectionOut
+	default:
+		return ActivityDirectionIn
+	}
+}
@@ -0,0 +1,43 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func TestMergeActivity(t *testing.T) {
+	assert := assert.New(t)
+
+	address := "hx0000000000000000000000000000000000000001"
+	other := "hx0000000000000000000000000000000000000002"
+
+	transactions := []models.TransactionList{
+		{Hash: "0x3", Type: "transaction", BlockNumber: 30, TransactionIndex: 1, LogIndex: -1, FromAddress: address, ToAddress: other},
+		{Hash: "0x3", Type: "log", BlockNumber: 30, TransactionIndex: 1, LogIndex: 2, FromAddress: other, ToAddress: address},
+		{Hash: "0x1", Type: "transaction", BlockNumber: 10, TransactionIndex: 0, LogIndex: -1, FromAddress: address, ToAddress: address},
+	}
+	tokenTransfers := []models.TokenTransfer{
+		{TransactionHash: "0x3", BlockNumber: 30, TransactionIndex: 1, LogIndex: 0, FromAddress: other, ToAddress: address},
+		{TransactionHash: "0x2", BlockNumber: 20, TransactionIndex: 4, LogIndex: 1, FromAddress: address, ToAddress: other},
+	}
+
+	activity := mergeActivity(address, transactions, tokenTransfers, 4)
+
+	// Latest first, cut to the limit
+	assert.Equal(4, len(activity))
+	assert.Equal(Activity{
+		Type: ActivityTypeInternalTransaction, Direction: ActivityDirectionIn, TransactionHash: "0x3",
+		BlockNumber: 30, TransactionIndex: 1, LogIndex: 2, FromAddress: other, ToAddress: address,
+	}, activity[0])
+	assert.Equal(ActivityTypeTokenTransfer, activity[1].Type)
+	assert.Equal(ActivityDirectionIn, activity[1].Direction)
+	assert.Equal(ActivityTypeTransaction, activity[2].Type)
+	assert.Equal(ActivityDirectionOut, activity[2].Direction)
+	assert.Equal(int64(20), activity[3].BlockNumber)
+
+	activity = mergeActivity(address, transactions[2:], nil, 4)
+	assert.Equal(ActivityDirectionSelf, activity[0].Direction)
+}
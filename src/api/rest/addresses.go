@@ -30,10 +30,16 @@ func AddressesAddHandlers(app *fiber.App) {
 
 	prefix := config.Config.RestPrefix + "/addresses"
 
-	app.Get(prefix+"/", handlerGetAddresses)
-	app.Get(prefix+"/details/:address", handlerGetAddressDetails)
+	app.Use(prefix, QueryTimeout(config.Config.RestQueryTimeoutAddresses))
+
+	app.Get(prefix+"/", ResponseCache(), handlerGetAddresses)
+	app.Get(prefix+"/details/:address", ResponseCache(), handlerGetAddressDetails)
 	app.Get(prefix+"/contracts", handlerGetContracts)
 	app.Get(prefix+"/token-addresses/:address", handlerGetTokenAddresses)
+	app.Get(prefix+"/:address/activity", handlerGetAddressActivity)
+	app.Get(prefix+"/:address/token-balances", handlerGetAddressTokenBalances)
+	app.Get(prefix+"/:address/balance-history", handlerGetAddressBalanceHistory)
+	app.Get(prefix+"/:address/portfolio", handlerGetAddressPortfolio)
 }
 
 // Addresses
@@ -53,14 +59,15 @@ func AddressesAddHandlers(app *fiber.App) {
 // @Param sort query string false "Field to sort by. name, balance, transaction_count, transaction_internal_count, token_transfer_count. Use leading `-` (ie -balance) for sort direction or omit for descending."
 // @Router /api/v1/addresses [get]
 // @Success 200 {object} []models.AddressList
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetAddresses(c *fiber.Ctx) error {
 	params := new(AddressesQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Addresses Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -69,9 +76,8 @@ func handlerGetAddresses(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 
 	if params.Sort != "" {
@@ -85,13 +91,13 @@ func handlerGetAddresses(c *fiber.Ctx) error {
 		}
 
 		if !stringInSlice(sortParam, addressSortParams) {
-			c.Status(422)
-			return c.SendString(`{"error": "invalid sort parameter"}`)
+			return errInvalidParameter("sort", "invalid sort parameter")
 		}
 	}
 
 	// Get Addresses
 	addresses, err := crud.GetAddressCrud().SelectMany(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
 		params.Address,
@@ -103,12 +109,11 @@ func handlerGetAddresses(c *fiber.Ctx) error {
 	)
 	if err != nil {
 		zap.S().Warnf("Addresses CRUD ERROR: %s", err.Error())
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetAddresses",
 			" Error=Could not retrieve addresses: ", err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve addresses"}`)
+		return errQuery(err, "could not retrieve addresses")
 	}
 
 	if len(*addresses) == 0 {
@@ -132,7 +137,7 @@ func handlerGetAddresses(c *fiber.Ctx) error {
 	// Continue with JSON response if not CSV
 	body, err := json.Marshal(addresses)
 	if err != nil {
-		return c.SendString(`{"error": "parsing error"}`)
+		return errInternal("could not encode response")
 	}
 
 	return c.SendString(string(body))
@@ -148,40 +153,39 @@ func handlerGetAddresses(c *fiber.Ctx) error {
 // @Param address path string true "find by address"
 // @Router /api/v1/addresses/details/{address} [get]
 // @Success 200 {object} models.Address
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetAddressDetails(c *fiber.Ctx) error {
 	addressString := c.Params("address")
 	if addressString == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "address required"}`)
+		return errInvalidParameter("address", "address required")
 	}
 
 	params := new(SkipLimitQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Addresses Get Handler ERROR: %s", err.Error())
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Get Addresses
-	address, err := crud.GetAddressCrud().SelectOne(addressString)
+	address, err := crud.GetAddressCrud().SelectOne(c.UserContext(), addressString)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.Status(404)
-			return c.SendString(`{"error": "address not found"}`)
+			return errNotFound("address not found")
 		}
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetAddressDetails",
 			" Error=Could not retrieve addresses: ", err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve addresses"}`)
+		return errQuery(err, "could not retrieve addresses")
 	}
 
 	// Continue with JSON response if not CSV
 	body, err := json.Marshal(address)
 	if err != nil {
-		return c.SendString(`{"error": "parsing error"}`)
+		return errInternal("could not encode response")
 	}
 	return c.SendString(string(body))
 }
@@ -214,14 +218,15 @@ type ContractsQuery struct {
 // @Param sort query string false "Field to sort by. name, balance, transaction_count, transaction_internal_count, token_transfer_count. Use leading `-` (ie -balance) for sort direction or omit for descending."
 // @Router /api/v1/addresses/contracts [get]
 // @Success 200 {object} []models.ContractList
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetContracts(c *fiber.Ctx) error {
 	params := new(ContractsQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Addresses Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	if params.TokenStandard != "" {
@@ -234,13 +239,8 @@ func handlerGetContracts(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 
 	if params.Sort != "" {
@@ -254,13 +254,13 @@ func handlerGetContracts(c *fiber.Ctx) error {
 		}
 
 		if !stringInSlice(sortParam, addressSortParams) {
-			c.Status(422)
-			return c.SendString(`{"error": "invalid sort parameter"}`)
+			return errInvalidParameter("sort", "invalid sort parameter")
 		}
 	}
 
 	// Get contracts
 	contracts, err := crud.GetAddressCrud().SelectManyContracts(
+		c.UserContext(),
 		params.Search,
 		params.TokenStandard,
 		params.IsToken,
@@ -271,12 +271,11 @@ func handlerGetContracts(c *fiber.Ctx) error {
 		params.Sort,
 	)
 	if err != nil {
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetContracts",
 			" Error=Could not retrieve contracts: ", err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve contracts"}`)
+		return errQuery(err, "could not retrieve contracts")
 	}
 
 	if len(*contracts) == 0 {
@@ -285,23 +284,25 @@ func handlerGetContracts(c *fiber.Ctx) error {
 	}
 
 	if params.Search != "" || params.TokenStandard != "" || params.IsToken != nil || params.IsNft != nil {
-		count, err := crud.GetAddressCrud().CountWithParamsSearch(
-			params.Search,
-			params.TokenStandard,
-			params.Status,
-			params.IsToken,
-			params.IsNft,
-			newTrue(),
-		)
+		count, err := GetCachedCount(c, func() (*crud.Count, error) {
+			return crud.GetAddressCrud().CountWithParamsSearch(
+				c.UserContext(),
+				params.Search,
+				params.TokenStandard,
+				params.Status,
+				params.IsToken,
+				params.IsNft,
+				newTrue(),
+			)
+		})
 		if err != nil {
-			c.Status(500)
 			zap.S().Warn(
 				"Endpoint=handlerGetContracts",
 				" Error=Could not retrieve contracts: ", err.Error(),
 			)
-			return c.SendString(`{"error": "could not count contracts"}`)
+			return errQuery(err, "could not count contracts")
 		}
-		c.Append("X-TOTAL-COUNT", strconv.FormatInt(count, 10))
+		setTotalCount(c, count)
 	} else {
 		// Set X-TOTAL-COUNT
 		count, err := redis.GetRedisClient().GetCount(config.Config.RedisKeyPrefix + "address_contract_count")
@@ -319,7 +320,7 @@ func handlerGetContracts(c *fiber.Ctx) error {
 	// Continue with JSON response if not CSV
 	body, err := json.Marshal(contracts)
 	if err != nil {
-		return c.SendString(`{"error": "parsing error"}`)
+		return errInternal("could not encode response")
 	}
 
 	return c.SendString(string(body))
@@ -335,26 +336,26 @@ func handlerGetContracts(c *fiber.Ctx) error {
 // @Param address path string true "address"
 // @Router /api/v1/addresses/token-addresses/{address} [get]
 // @Success 200 {object} []string
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetTokenAddresses(c *fiber.Ctx) error {
 	addressString := c.Params("address")
 
 	params := new(SkipLimitQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Addresses Get Handler ERROR: %s", err.Error())
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Get TokenAddresses
-	tokenAddress, err := crud.GetTokenAddressCrud().SelectManyByAddress(addressString)
+	tokenAddress, err := crud.GetTokenAddressCrud().SelectManyByAddress(c.UserContext(), addressString)
 	if err != nil {
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetContracts",
 			" Error=Could not retrieve token addresses: ", err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve addresses"}`)
+		return errQuery(err, "could not retrieve addresses")
 	}
 
 	if len(*tokenAddress) == 0 {
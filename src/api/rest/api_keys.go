@@ -0,0 +1,217 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/apikeys"
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// Header with the admin api key
+const HeaderAdminKey = "X-ADMIN-KEY"
+
+type ApiKeyBody struct {
+	Name       string  `json:"name"`
+	Rate       float64 `json:"rate"`
+	Burst      int64   `json:"burst"`
+	DailyQuota int64   `json:"daily_quota"`
+}
+
+// ApiKeyCreated - created api key with its key, only returned on create
+type ApiKeyCreated struct {
+	models.ApiKey
+	Key string `json:"key"`
+}
+
+type ApiKeysQuery struct {
+	Limit int `query:"limit"`
+	Skip  int `query:"skip"`
+}
+
+// ApiKeysAddHandlers - add api key admin endpoints to fiber router
+func ApiKeysAddHandlers(app *fiber.App) {
+
+	prefix := config.Config.RestPrefix + "/admin/api-keys"
+
+	app.Use(prefix, handlerAdminAuth)
+	app.Use(prefix, QueryTimeout(config.Config.RestQueryTimeoutAdmin))
+
+	app.Post(prefix+"/", handlerCreateApiKey)
+	app.Get(prefix+"/", handlerGetApiKeys)
+	app.Delete(prefix+"/:id", handlerDeleteApiKey)
+}
+
+func handlerAdminAuth(c *fiber.Ctx) error {
+	// Without an admin key configured nothing is authorized
+	adminKey := c.Get(HeaderAdminKey)
+	if config.Config.AdminAPIKey == "" || subtle.ConstantTimeCompare([]byte(adminKey), []byte(config.Config.AdminAPIKey)) != 1 {
+		return errUnauthorized("invalid admin key")
+	}
+
+	return c.Next()
+}
+
+// Create Api Key
+// @Summary Create Api Key
+// @Description create an api key with its own rate limit, the key is only returned here. Requires the X-ADMIN-KEY header.
+// @Tags Admin
+// @BasePath /api/v1
+// @Accept json
+// @Produce json
+// @Param X-ADMIN-KEY header string true "admin key"
+// @Param api_key body ApiKeyBody true "name, rate in requests per second, burst, and daily_quota, 0 for unlimited"
+// @Router /api/v1/admin/api-keys [post]
+// @Success 201 {object} ApiKeyCreated
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerCreateApiKey(c *fiber.Ctx) error {
+	body := new(ApiKeyBody)
+	if err := c.BodyParser(body); err != nil {
+		zap.S().Warnf("Api Keys Create Handler ERROR: %s", err.Error())
+
+		return errInvalidBody(err)
+	}
+
+	key, err := apikeys.NewKey()
+	if err != nil {
+		return errInternal("could not create key")
+	}
+
+	apiKey := &models.ApiKey{
+		Name:             body.Name,
+		KeyHash:          apikeys.HashKey(key),
+		Rate:             body.Rate,
+		Burst:            body.Burst,
+		DailyQuota:       body.DailyQuota,
+		CreatedTimestamp: time.Now().Unix(),
+	}
+
+	err = apikeys.ValidateApiKey(apiKey)
+	if err != nil {
+		return errInvalidBody(err)
+	}
+
+	err = crud.GetApiKeyCrud().InsertOne(c.UserContext(), apiKey)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerCreateApiKey",
+			" Error=Could not create api key: ", err.Error(),
+		)
+		return errQuery(err, "could not create api key")
+	}
+
+	// Usable on this replica now, others pick it up on refresh
+	apikeys.Refresh()
+
+	c.Status(201)
+	responseBody, _ := json.Marshal(&ApiKeyCreated{
+		ApiKey: *apiKey,
+		Key:    key,
+	})
+	return c.Send(responseBody)
+}
+
+// Api Keys
+// @Summary Get Api Keys
+// @Description get list of api keys created through the admin endpoints. Requires the X-ADMIN-KEY header.
+// @Tags Admin
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param X-ADMIN-KEY header string true "admin key"
+// @Param limit query int false "amount of records"
+// @Param skip query int false "skip to a record"
+// @Router /api/v1/admin/api-keys [get]
+// @Success 200 {object} []models.ApiKey
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerGetApiKeys(c *fiber.Ctx) error {
+	params := new(ApiKeysQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Api Keys Get Handler ERROR: %s", err.Error())
+
+		return errInvalidQuery(err)
+	}
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 25
+	}
+
+	// Check Params
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
+	}
+
+	apiKeys, err := crud.GetApiKeyCrud().SelectMany(
+		c.UserContext(),
+		params.Limit,
+		params.Skip,
+	)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetApiKeys",
+			" Error=Could not retrieve api keys: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve api keys")
+	}
+
+	if len(*apiKeys) == 0 {
+		// No Content
+		c.Status(204)
+	}
+
+	body, _ := json.Marshal(apiKeys)
+	return c.Send(body)
+}
+
+// Delete Api Key
+// @Summary Delete Api Key
+// @Description delete an api key created through the admin endpoints. Requires the X-ADMIN-KEY header.
+// @Tags Admin
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param X-ADMIN-KEY header string true "admin key"
+// @Param id path int true "api key id"
+// @Router /api/v1/admin/api-keys/{id} [delete]
+// @Success 204
+// @Failure 422 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerDeleteApiKey(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return errInvalidParameter("id", "invalid id")
+	}
+
+	err = crud.GetApiKeyCrud().DeleteOne(c.UserContext(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errNotFound("api key not found")
+		}
+		zap.S().Warn(
+			"Endpoint=handlerDeleteApiKey",
+			" Error=Could not delete api key: ", err.Error(),
+		)
+		return errQuery(err, "could not delete api key")
+	}
+
+	// Revoked on this replica now, others pick it up on refresh
+	apikeys.Refresh()
+
+	return c.SendStatus(204)
+}
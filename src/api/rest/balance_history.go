@@ -0,0 +1,322 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/redis"
+)
+
+// Balance history granularities
+const (
+	BalanceHistoryGranularityDay   = "day"
+	BalanceHistoryGranularityBlock = "block"
+)
+
+type BalanceHistoryQuery struct {
+	Limit       int    `query:"limit"`
+	Cursor      string `query:"cursor"`
+	Granularity string `query:"granularity"`
+	BlockNumber int64  `query:"block_number"`
+	Timestamp   int64  `query:"timestamp"`
+}
+
+// BalancePoint - ICX balance of an address after a block
+type BalancePoint struct {
+	BlockNumber int64   `json:"block_number"`
+	Timestamp   int64   `json:"timestamp"`
+	Date        string  `json:"date,omitempty" example:"2022-06-30"`
+	Balance     float64 `json:"balance"`
+}
+
+// Address Balance History
+// @Summary Get Address Balance History
+// @Description get the ICX balance of an address after each block or UTC day that changed it, latest first
+// @Description rebuilt from the current balance and the ICX moved by transactions, internal transactions and fees
+// @Description with block_number or timestamp, the balance at that block or timestamp
+// @Tags Addresses
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param address path string true "address"
+// @Param limit query int false "amount of records"
+// @Param cursor query string false "cursor from the X-NEXT-CURSOR header of the previous page"
+// @Param granularity query string false "day or block, defaults to day"
+// @Param block_number query int false "balance at a block"
+// @Param timestamp query int false "balance at a block timestamp, microsecond epoch"
+// @Router /api/v1/addresses/{address}/balance-history [get]
+// @Success 200 {object} []BalancePoint
+// @Failure 422 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerGetAddressBalanceHistory(c *fiber.Ctx) error {
+	address := c.Params("address")
+
+	params := new(BalanceHistoryQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Address Balance History Get Handler ERROR: %s", err.Error())
+
+		return errInvalidQuery(err)
+	}
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 25
+	}
+	if params.Granularity == "" {
+		params.Granularity = BalanceHistoryGranularityDay
+	}
+
+	// Check Params
+	if err := checkPaging(params.Limit, 0); err != nil {
+		return err
+	}
+	if params.Granularity != BalanceHistoryGranularityDay && params.Granularity != BalanceHistoryGranularityBlock {
+		return errInvalidParameter("granularity", "granularity must be day or block")
+	}
+	if params.BlockNumber < 0 {
+		return errInvalidParameter("block_number", "invalid block number")
+	}
+	if params.Timestamp < 0 {
+		return errInvalidParameter("timestamp", "invalid timestamp")
+	}
+	if params.BlockNumber != 0 && params.Timestamp != 0 {
+		return errInvalidParameter("block_number", "can't supply both block_number and timestamp")
+	}
+
+	// Cursor
+	var cursor *crud.Cursor
+	if params.Cursor != "" {
+		var err error
+		cursor, err = crud.DecodeCursor(params.Cursor)
+		if err != nil {
+			return errInvalidParameter("cursor", "invalid cursor")
+		}
+		if cursor.Sort != "desc" {
+			return errInvalidParameter("cursor", "cursor is for a different sort")
+		}
+	}
+
+	history, err := getCachedBalanceHistory(c, address, params, cursor)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errNotFound("address not found")
+		}
+		zap.S().Warn(
+			"Endpoint=handlerGetAddressBalanceHistory",
+			" Error=Could not retrieve balance history: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve balance history")
+	}
+
+	// Set X-NEXT-CURSOR
+	if history.NextCursor != "" {
+		c.Append("X-NEXT-CURSOR", history.NextCursor)
+	}
+
+	return c.SendString(string(history.Body))
+}
+
+// balanceHistory - response of a balance history query
+type balanceHistory struct {
+	Body       json.RawMessage `json:"body"`
+	NextCursor string          `json:"next_cursor"`
+}
+
+// getCachedBalanceHistory - balance history of an address, cached in redis per address and query
+func getCachedBalanceHistory(
+	c *fiber.Ctx,
+	address string,
+	params *BalanceHistoryQuery,
+	cursor *crud.Cursor,
+) (*balanceHistory, error) {
+	key := config.Config.RedisKeyPrefix + "balance_history_" + address + "_" +
+		params.Granularity + "_" + strconv.Itoa(params.Limit) + "_" + params.Cursor + "_" +
+		strconv.FormatInt(params.BlockNumber, 10) + "_" + strconv.FormatInt(params.Timestamp, 10)
+
+	cached, err := redis.GetRedisClient().GetCache(key)
+	if err != nil {
+		zap.S().Warn("Could not retrieve cached balance history: ", err.Error())
+	} else if cached != nil {
+		history := &balanceHistory{}
+		err = json.Unmarshal(cached, history)
+		if err == nil {
+			return history, nil
+		}
+	}
+
+	addressModel, err := crud.GetAddressCrud().SelectOne(c.UserContext(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Balances are folded back in loop so they don't drift, the current balance is the anchor
+	balance, err := tokenValue(addressModel.Balance, 18)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &balanceHistory{}
+
+	if params.BlockNumber != 0 || params.Timestamp != 0 {
+		// Point lookup
+		point, err := getBalancePoint(c, address, balance, params)
+		if err != nil {
+			return nil, err
+		}
+
+		history.Body, _ = json.Marshal(point)
+	} else {
+		// Page
+		points, nextCursor, err := getBalancePoints(c, address, balance, params, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		history.Body, _ = json.Marshal(&points)
+		history.NextCursor = nextCursor
+	}
+
+	cached, _ = json.Marshal(history)
+	err = redis.GetRedisClient().SetCache(key, cached, config.Config.BalanceHistoryCacheTTL)
+	if err != nil {
+		zap.S().Warn("Could not cache balance history: ", err.Error())
+	}
+
+	return history, nil
+}
+
+// getBalancePoint - balance after a block, or after the last block at a timestamp
+func getBalancePoint(c *fiber.Ctx, address string, balance *big.Int, params *BalanceHistoryQuery) (*BalancePoint, error) {
+	blockNumber := params.BlockNumber
+	if params.Timestamp != 0 {
+		// Last block at or before the timestamp
+		block, err := crud.GetBlockCrud().SelectOneByTimestamp(c.UserContext(), uint64(params.Timestamp+1))
+		if err != nil {
+			return nil, err
+		}
+		blockNumber = block.Number
+	}
+
+	change, err := crud.GetTransactionCrud().SumIcxBalanceChangeAfter(c.UserContext(), address, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err = subtractChange(balance, change)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BalancePoint{
+		BlockNumber: blockNumber,
+		Timestamp:   params.Timestamp,
+		Balance:     icxFloat(balance),
+	}, nil
+}
+
+// getBalancePoints - page of balances after each block or day, latest first, with the cursor of the next page
+func getBalancePoints(
+	c *fiber.Ctx,
+	address string,
+	balance *big.Int,
+	params *BalanceHistoryQuery,
+	cursor *crud.Cursor,
+) ([]BalancePoint, string, error) {
+	daily := params.Granularity == BalanceHistoryGranularityDay
+
+	// Balance after the last page
+	beforeBlockNumber := int64(0)
+	if cursor != nil {
+		beforeBlockNumber = cursor.BlockNumber
+
+		change, err := crud.GetTransactionCrud().SumIcxBalanceChangeAfter(c.UserContext(), address, beforeBlockNumber-1)
+		if err != nil {
+			return nil, "", err
+		}
+
+		balance, err = subtractChange(balance, change)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	icxBalanceChanges, err := crud.GetTransactionCrud().SelectManyIcxBalanceChanges(
+		c.UserContext(),
+		address,
+		daily,
+		beforeBlockNumber,
+		params.Limit,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	points, err := balancePoints(balance, *icxBalanceChanges, daily)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(*icxBalanceChanges) == params.Limit {
+		lastChange := (*icxBalanceChanges)[len(*icxBalanceChanges)-1]
+		nextCursor = (&crud.Cursor{
+			BlockNumber: lastChange.FirstBlockNumber,
+			Sort:        "desc",
+		}).Encode()
+	}
+
+	return points, nextCursor, nil
+}
+
+// balancePoints - walk back from the balance in loop after the latest change through the changes, latest first
+// ICX that isn't moved by a transaction, like issued rewards, isn't seen so it shows up in the oldest balances
+func balancePoints(balance *big.Int, icxBalanceChanges []crud.IcxBalanceChange, daily bool) ([]BalancePoint, error) {
+	points := []BalancePoint{}
+
+	for _, icxBalanceChange := range icxBalanceChanges {
+		point := BalancePoint{
+			BlockNumber: icxBalanceChange.LastBlockNumber,
+			Timestamp:   icxBalanceChange.LastBlockTimestamp,
+			Balance:     icxFloat(balance),
+		}
+		if daily {
+			point.Date = time.UnixMicro(point.Timestamp).UTC().Format("2006-01-02")
+		}
+		points = append(points, point)
+
+		var err error
+		balance, err = subtractChange(balance, icxBalanceChange.Change)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return points, nil
+}
+
+// subtractChange - balance before an exact change, both in loop
+func subtractChange(balance *big.Int, change string) (*big.Int, error) {
+	changeValue, ok := new(big.Int).SetString(change, 10)
+	if ok == false {
+		return nil, errors.New("invalid balance change " + change)
+	}
+
+	return new(big.Int).Sub(balance, changeValue), nil
+}
+
+// icxFloat - ICX of an amount in loop, rounded once
+func icxFloat(value *big.Int) float64 {
+	icx, _ := new(big.Rat).SetFrac(value, big.NewInt(1000000000000000000)).Float64()
+	return icx
+}
@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/crud"
+)
+
+func TestBalancePoints(t *testing.T) {
+	assert := assert.New(t)
+
+	day := int64(86400 * 1000000)
+
+	// 94.7 ICX
+	balance, _ := new(big.Int).SetString("94700000000000000000", 10)
+
+	// Latest first, per block
+	icxBalanceChanges := []crud.IcxBalanceChange{
+		{FirstBlockNumber: 40, LastBlockNumber: 40, LastBlockTimestamp: 2*day + 10, Change: "4900000000000000000"},
+		{FirstBlockNumber: 30, LastBlockNumber: 30, LastBlockTimestamp: day + 20, Change: "-100000000000000000"},
+		{FirstBlockNumber: 20, LastBlockNumber: 20, LastBlockTimestamp: day + 10, Change: "-10100000000000000000"},
+		{FirstBlockNumber: 10, LastBlockNumber: 10, LastBlockTimestamp: 10, Change: "100000000000000000000"},
+	}
+
+	points, err := balancePoints(balance, icxBalanceChanges, false)
+	assert.Equal(nil, err)
+	assert.Equal(4, len(points))
+	// Exact in loop, so no drift from the float sums
+	expected := []float64{94.7, 89.8, 89.9, 100}
+	for i, point := range points {
+		assert.Equal(expected[i], point.Balance)
+		assert.Equal(icxBalanceChanges[i].LastBlockNumber, point.BlockNumber)
+		assert.Equal("", point.Date)
+	}
+
+	// The anchor isn't changed
+	assert.Equal("94700000000000000000", balance.String())
+
+	// Per day, the balance at the end of the day
+	icxBalanceChanges = []crud.IcxBalanceChange{
+		{FirstBlockNumber: 40, LastBlockNumber: 40, LastBlockTimestamp: 2*day + 10, Change: "4900000000000000000"},
+		{FirstBlockNumber: 20, LastBlockNumber: 30, LastBlockTimestamp: day + 20, Change: "-10200000000000000000"},
+		{FirstBlockNumber: 10, LastBlockNumber: 10, LastBlockTimestamp: 10, Change: "100000000000000000000"},
+	}
+
+	points, err = balancePoints(balance, icxBalanceChanges, true)
+	assert.Equal(nil, err)
+	assert.Equal(3, len(points))
+	assert.Equal("1970-01-02", points[1].Date)
+	assert.Equal(int64(30), points[1].BlockNumber)
+	assert.Equal(89.8, points[1].Balance)
+	assert.Equal(100.0, points[2].Balance)
+
+	_, err = balancePoints(balance, []crud.IcxBalanceChange{{Change: "1.5"}}, false)
+	assert.NotEqual(nil, err)
+}
@@ -19,8 +19,10 @@ func BlocksAddHandlers(app *fiber.App) {
 
 	prefix := config.Config.RestPrefix + "/blocks"
 
-	app.Get(prefix+"/", handlerGetBlocks)
-	app.Get(prefix+"/:number", handlerGetBlockDetails)
+	app.Use(prefix, QueryTimeout(config.Config.RestQueryTimeoutBlocks))
+
+	app.Get(prefix+"/", ResponseCache(), handlerGetBlocks)
+	app.Get(prefix+"/:number", ResponseCacheByBlock("number"), handlerGetBlockDetails)
 	app.Get(prefix+"/timestamp/:timestamp", handlerGetBlockTimestampDetails)
 }
 
@@ -53,14 +55,15 @@ type paramsGetBlocks struct {
 // @Param sort query string false "desc or asc"
 // @Router /api/v1/blocks [get]
 // @Success 200 {object} []models.BlockList
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetBlocks(c *fiber.Ctx) error {
 	params := &paramsGetBlocks{}
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Blocks Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default params
@@ -72,23 +75,18 @@ func handlerGetBlocks(c *fiber.Ctx) error {
 	}
 
 	// Check params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid limit"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 	if params.EndNumber < params.StartNumber {
-		c.Status(422)
-		return c.SendString(`{"error": "end_number is less than start_number"}`)
+		return errInvalidParameter("end_number", "end_number is less than start_number")
 	}
 	if params.Sort != "desc" && params.Sort != "asc" {
 		params.Sort = "desc"
 	}
 
 	blocks, err := crud.GetBlockCrud().SelectMany(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
 		params.Number,
@@ -99,12 +97,11 @@ func handlerGetBlocks(c *fiber.Ctx) error {
 		params.Sort,
 	)
 	if err != nil {
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetBlocks",
 			" Error=Could not retrieve blocks: ", err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve blocks"}`)
+		return errQuery(err, "could not retrieve blocks")
 	}
 	if len(*blocks) == 0 {
 		// No Content
@@ -136,30 +133,29 @@ func handlerGetBlocks(c *fiber.Ctx) error {
 // @Param number path int true "block number"
 // @Router /api/v1/blocks/{number} [get]
 // @Success 200 {object} models.Block
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetBlockDetails(c *fiber.Ctx) error {
 	numberRaw := c.Params("number")
 
 	if numberRaw == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "number required"}`)
+		return errInvalidParameter("number", "number required")
 	}
 
 	// Is number?
 	number, err := strconv.ParseUint(numberRaw, 10, 32)
 	if err != nil {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid number"}`)
+		return errInvalidParameter("number", "invalid number")
 	}
 
-	block, err := crud.GetBlockCrud().SelectOne(uint32(number))
+	block, err := crud.GetBlockCrud().SelectOne(c.UserContext(), uint32(number))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.Status(404)
-			return c.SendString(`{"error": "no block found"}`)
+			return errNotFound("no block found")
 		}
-		c.Status(500)
-		return c.SendString(`{"error": "could not retrieve block"}`)
+		return errQuery(err, "could not retrieve block")
 	}
 	body, _ := json.Marshal(&block)
 	return c.SendString(string(body))
@@ -175,30 +171,29 @@ func handlerGetBlockDetails(c *fiber.Ctx) error {
 // @Param timestamp path int true "timestamp"
 // @Router /api/v1/blocks/timestamp/{timestamp} [get]
 // @Success 200 {object} models.Block
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetBlockTimestampDetails(c *fiber.Ctx) error {
 	timestampRaw := c.Params("timestamp")
 
 	if timestampRaw == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "number required"}`)
+		return errInvalidParameter("timestamp", "timestamp required")
 	}
 
 	// Is number?
 	timestamp, err := strconv.ParseUint(timestampRaw, 10, 64)
 	if err != nil {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid number"}`)
+		return errInvalidParameter("timestamp", "invalid timestamp")
 	}
 
-	block, err := crud.GetBlockCrud().SelectOneByTimestamp(uint64(timestamp))
+	block, err := crud.GetBlockCrud().SelectOneByTimestamp(c.UserContext(), uint64(timestamp))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.Status(404)
-			return c.SendString(`{"error": "no block found"}`)
+			return errNotFound("no block found")
 		}
-		c.Status(500)
-		return c.SendString(`{"error": "could not retrieve block"}`)
+		return errQuery(err, "could not retrieve block")
 	}
 
 	body, _ := json.Marshal(&block)
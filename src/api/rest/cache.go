@@ -1,11 +1,24 @@
 package rest
 
 import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	fiber "github.com/gofiber/fiber/v2"
 	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
 	"github.com/sudoblockio/icon-go-api/redis"
 	"go.uber.org/zap"
 )
 
+// Header set with X-TOTAL-COUNT on filtered counts, false when the count is estimated
+const HeaderTotalCountExact = "X-TOTAL-COUNT-EXACT"
+
+// Query parameters that page through results rather than filter them
+var countPageParams = []string{"limit", "skip", "sort", "cursor"}
+
 func GetRedisCount(key string) (int64, error) {
 	var count int64
 	count, err := redis.GetRedisClient().GetCount(config.Config.RedisKeyPrefix + key)
@@ -15,3 +28,53 @@ func GetRedisCount(key string) (int64, error) {
 	}
 	return count, err
 }
+
+// GetCachedCount - count for the request's filters, cached in redis per filter
+func GetCachedCount(c *fiber.Ctx, count func() (*crud.Count, error)) (*crud.Count, error) {
+	key := config.Config.RedisKeyPrefix + countCacheKey(c)
+
+	cached, err := redis.GetRedisClient().GetCache(key)
+	if err != nil {
+		zap.S().Warn("Could not retrieve cached count: ", err.Error())
+	} else if cached != nil {
+		cachedCount := &crud.Count{}
+		err = json.Unmarshal(cached, cachedCount)
+		if err == nil {
+			return cachedCount, nil
+		}
+	}
+
+	result, err := count()
+	if err != nil {
+		return nil, err
+	}
+
+	cached, _ = json.Marshal(result)
+	err = redis.GetRedisClient().SetCache(key, cached, config.Config.CountCacheTTL)
+	if err != nil {
+		zap.S().Warn("Could not cache count: ", err.Error())
+	}
+
+	return result, nil
+}
+
+// setTotalCount - set X-TOTAL-COUNT and whether it is exact
+func setTotalCount(c *fiber.Ctx, count *crud.Count) {
+	c.Append("X-TOTAL-COUNT", strconv.FormatInt(count.Count, 10))
+	c.Set(HeaderTotalCountExact, strconv.FormatBool(count.Exact))
+}
+
+// countCacheKey - cache key of a request's count, by path and filter parameters
+func countCacheKey(c *fiber.Ctx) string {
+	query := url.Values{}
+	c.Context().QueryArgs().VisitAll(func(key []byte, value []byte) {
+		if stringInSlice(string(key), countPageParams) {
+			return
+		}
+		query.Add(string(key), string(value))
+	})
+
+	path := strings.TrimSuffix(c.Path(), "/")
+
+	return "count_" + path + "?" + query.Encode()
+}
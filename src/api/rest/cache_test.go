@@ -0,0 +1,34 @@
+package rest
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountCacheKey(t *testing.T) {
+	assert := assert.New(t)
+
+	keys := []string{}
+	app := fiber.New()
+	app.Get("/api/v1/transactions", func(c *fiber.Ctx) error {
+		keys = append(keys, countCacheKey(c))
+		return nil
+	})
+
+	for _, target := range []string{
+		"/api/v1/transactions?from=hx1&limit=10",
+		"/api/v1/transactions?skip=25&from=hx1&sort=asc",
+		"/api/v1/transactions?from=hx2",
+	} {
+		_, err := app.Test(httptest.NewRequest("GET", target, nil))
+		assert.Equal(nil, err)
+	}
+
+	// Paging doesn't change the count
+	assert.Equal("count_/api/v1/transactions?from=hx1", keys[0])
+	assert.Equal(keys[0], keys[1])
+	assert.NotEqual(keys[0], keys[2])
+}
@@ -0,0 +1,191 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/config"
+)
+
+// Error codes
+// Stable, clients can match on them unlike messages
+const (
+	ErrorCodeInvalidParameter = "invalid_parameter"
+	ErrorCodeInvalidBody      = "invalid_body"
+	ErrorCodeNotFound         = "not_found"
+	ErrorCodeUnauthorized     = "unauthorized"
+	ErrorCodeRateLimited      = "rate_limited"
+	ErrorCodeQuotaExceeded    = "quota_exceeded"
+	ErrorCodeQueryTimeout     = "query_timeout"
+	ErrorCodeUnavailable      = "unavailable"
+	ErrorCodeInternal         = "internal_error"
+)
+
+// Locals key of the request id, set by the request id middleware
+const RequestIDLocalsKey = "requestid"
+
+// APIError - body of every error response
+type APIError struct {
+	Status    int           `json:"-"`
+	Code      string        `json:"code" example:"invalid_parameter"`
+	Message   string        `json:"message" example:"limit must be between 1 and 100"`
+	Details   []ErrorDetail `json:"details,omitempty"`
+	RequestID string        `json:"request_id,omitempty" example:"3f1b0e0c-5f8e-4b7e-9d7a-0c2b1a9e4f10"`
+}
+
+// ErrorDetail - problem with one field of the request
+type ErrorDetail struct {
+	Field   string `json:"field" example:"limit"`
+	Message string `json:"message" example:"limit must be between 1 and 100"`
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Unwrap - fiber error with the same status, for middleware that reads the status off the error
+func (e *APIError) Unwrap() error {
+	return fiber.NewError(e.Status, e.Message)
+}
+
+// fieldError - validation error about one field
+type fieldError interface {
+	error
+	Field() string
+}
+
+// ErrorHandler - fiber error handler, responds to every error with an APIError
+// Errors that aren't APIErrors or fiber errors are logged and hidden behind a generic message
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	apiErr := &APIError{}
+	if errors.As(err, &apiErr) == false {
+		fiberErr := &fiber.Error{}
+		if errors.As(err, &fiberErr) {
+			apiErr = &APIError{
+				Status:  fiberErr.Code,
+				Code:    errorCodeFromStatus(fiberErr.Code),
+				Message: fiberErr.Message,
+			}
+		} else {
+			zap.S().Warn("Endpoint=", c.Path(), " Error=", err.Error())
+			apiErr = errInternal("internal error")
+		}
+	}
+
+	// Shared errors aren't modified
+	response := *apiErr
+	response.RequestID, _ = c.Locals(RequestIDLocalsKey).(string)
+
+	body, _ := json.Marshal(&response)
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(response.Status).Send(body)
+}
+
+// errorCodeFromStatus - code for errors raised by fiber, like unmatched routes
+func errorCodeFromStatus(status int) string {
+	switch status {
+	case fiber.StatusNotFound:
+		return ErrorCodeNotFound
+	case fiber.StatusUnprocessableEntity:
+		return ErrorCodeInvalidParameter
+	case fiber.StatusServiceUnavailable:
+		return ErrorCodeUnavailable
+	case fiber.StatusInternalServerError:
+		return ErrorCodeInternal
+	}
+
+	return strings.ReplaceAll(strings.ToLower(utils.StatusMessage(status)), " ", "_")
+}
+
+// errInvalidParameter - 422 for a query or path parameter
+func errInvalidParameter(field string, message string) *APIError {
+	return &APIError{
+		Status:  fiber.StatusUnprocessableEntity,
+		Code:    ErrorCodeInvalidParameter,
+		Message: message,
+		Details: []ErrorDetail{{Field: field, Message: message}},
+	}
+}
+
+// errInvalidQuery - 422 for query parameters that can't be parsed
+func errInvalidQuery(err error) *APIError {
+	return &APIError{
+		Status:  fiber.StatusUnprocessableEntity,
+		Code:    ErrorCodeInvalidParameter,
+		Message: "could not parse query parameters",
+		Details: []ErrorDetail{{Field: "query", Message: err.Error()}},
+	}
+}
+
+// errInvalidBody - 422 for a body that can't be parsed or fails validation
+func errInvalidBody(err error) *APIError {
+	apiErr := &APIError{
+		Status:  fiber.StatusUnprocessableEntity,
+		Code:    ErrorCodeInvalidBody,
+		Message: "could not parse body",
+	}
+
+	fieldErr, ok := err.(fieldError)
+	if ok {
+		apiErr.Message = fieldErr.Error()
+		apiErr.Details = []ErrorDetail{{Field: fieldErr.Field(), Message: fieldErr.Error()}}
+	} else {
+		apiErr.Details = []ErrorDetail{{Field: "body", Message: err.Error()}}
+	}
+
+	return apiErr
+}
+
+// errNotFound - 404
+func errNotFound(message string) *APIError {
+	return &APIError{
+		Status:  fiber.StatusNotFound,
+		Code:    ErrorCodeNotFound,
+		Message: message,
+	}
+}
+
+// errUnauthorized - 401
+func errUnauthorized(message string) *APIError {
+	return &APIError{
+		Status:  fiber.StatusUnauthorized,
+		Code:    ErrorCodeUnauthorized,
+		Message: message,
+	}
+}
+
+// errUnavailable - 503
+func errUnavailable(message string) *APIError {
+	return &APIError{
+		Status:  fiber.StatusServiceUnavailable,
+		Code:    ErrorCodeUnavailable,
+		Message: message,
+	}
+}
+
+// errInternal - 500
+func errInternal(message string) *APIError {
+	return &APIError{
+		Status:  fiber.StatusInternalServerError,
+		Code:    ErrorCodeInternal,
+		Message: message,
+	}
+}
+
+// checkPaging - limit and skip are within the configured bounds
+func checkPaging(limit int, skip int) error {
+	if limit < 1 || limit > config.Config.MaxPageSize {
+		return errInvalidParameter("limit", fmt.Sprintf("limit must be between 1 and %d", config.Config.MaxPageSize))
+	}
+	if skip < 0 || skip > config.Config.MaxPageSkip {
+		return errInvalidParameter("skip", fmt.Sprintf("skip must be between 0 and %d", config.Config.MaxPageSkip))
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/apikeys"
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func TestErrorHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	config.ReadEnvironment()
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(requestid.New(requestid.Config{
+		ContextKey: RequestIDLocalsKey,
+		Generator:  func() string { return "test-request-id" },
+	}))
+	app.Get("/paging", func(c *fiber.Ctx) error {
+		return checkPaging(0, 0)
+	})
+	app.Get("/body", func(c *fiber.Ctx) error {
+		return errInvalidBody(apikeys.ValidateApiKey(&models.ApiKey{Rate: 1, Burst: 0}))
+	})
+	app.Get("/internal", func(c *fiber.Ctx) error {
+		return errors.New("connection string with a password")
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{
+			"/paging",
+			422,
+			`{"code":"invalid_parameter","message":"limit must be between 1 and 100","details":[{"field":"limit","message":"limit must be between 1 and 100"}],"request_id":"test-request-id"}`,
+		},
+		{
+			"/body",
+			422,
+			`{"code":"invalid_body","message":"burst must be at least 1","details":[{"field":"burst","message":"burst must be at least 1"}],"request_id":"test-request-id"}`,
+		},
+		{
+			// Details of unexpected errors aren't exposed
+			"/internal",
+			500,
+			`{"code":"internal_error","message":"internal error","request_id":"test-request-id"}`,
+		},
+		{
+			// Raised by fiber
+			"/missing",
+			404,
+			`{"code":"not_found","message":"Cannot GET /missing","request_id":"test-request-id"}`,
+		},
+	}
+
+	for _, test := range tests {
+		resp, err := app.Test(httptest.NewRequest("GET", test.path, nil))
+		assert.Equal(nil, err)
+		assert.Equal(test.status, resp.StatusCode, test.path)
+		assert.Equal(fiber.MIMEApplicationJSON, resp.Header.Get(fiber.HeaderContentType))
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(test.body, string(body), test.path)
+	}
+}
@@ -15,8 +15,9 @@ import (
 )
 
 type LogsQuery struct {
-	Limit int `query:"limit"`
-	Skip  int `query:"skip"`
+	Limit  int    `query:"limit"`
+	Skip   int    `query:"skip"`
+	Cursor string `query:"cursor"`
 
 	BlockNumber     uint32 `query:"block_number"`
 	BlockStart      uint32 `query:"block_start"`
@@ -30,7 +31,9 @@ func LogsAddHandlers(app *fiber.App) {
 
 	prefix := config.Config.RestPrefix + "/logs"
 
-	app.Get(prefix+"/", handlerGetLogs)
+	app.Use(prefix, QueryTimeout(config.Config.RestQueryTimeoutLogs))
+
+	app.Get(prefix+"/", ResponseCache(), handlerGetLogs)
 }
 
 // Logs
@@ -42,6 +45,7 @@ func LogsAddHandlers(app *fiber.App) {
 // @Produce json
 // @Param limit query int false "amount of records"
 // @Param skip query int false "skip to a record"
+// @Param cursor query string false "cursor from the X-NEXT-CURSOR header of the previous page, invalid with skip"
 // @Param block_number query int false "skip to a block"
 // @Param block_start query int false "For block range queries, a start block. Invalid with block_number"
 // @Param block_end query int false "For block range queries, an end block. Invalid with block_number"
@@ -50,14 +54,16 @@ func LogsAddHandlers(app *fiber.App) {
 // @Param method query string false "find by method"
 // @Router /api/v1/logs [get]
 // @Success 200 {object} []models.Log
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetLogs(c *fiber.Ctx) error {
 	params := new(LogsQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Logs Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -66,41 +72,53 @@ func handlerGetLogs(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 
 	if params.BlockNumber != 0 && (params.BlockEnd != 0 || params.BlockStart != 0) {
-		c.Status(422)
-		return c.SendString(`{"error": "can't supply both block_number and block_start or block_end'"}`)
+		return errInvalidParameter("block_number", "can't supply both block_number and block_start or block_end")
+	}
+
+	// Cursor
+	var cursor *crud.Cursor
+	if params.Cursor != "" {
+		if params.Skip != 0 {
+			return errInvalidParameter("cursor", "can't supply both skip and cursor")
+		}
+
+		var err error
+		cursor, err = crud.DecodeCursor(params.Cursor)
+		if err != nil {
+			return errInvalidParameter("cursor", "invalid cursor")
+		}
+		if cursor.Sort != "desc" {
+			return errInvalidParameter("cursor", "cursor is for a different sort")
+		}
 	}
 
 	// Get Logs
-	logs, err := crud.GetLogCrud().SelectMany(
+	logs, lastCursor, err := crud.GetLogCrud().SelectMany(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
+		cursor,
 		params.BlockNumber,
 		params.BlockStart,
 		params.BlockEnd,
 		params.TransactionHash,
 		params.Address,
 		params.Method,
+		"desc",
 	)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.Status(404)
-			return c.SendString(`{"error": "logs not found"}`)
+			return errNotFound("logs not found")
 		}
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetLogs", " Error=Could not retrieve logs: ", err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve logs"}`)
+		return errQuery(err, "could not retrieve logs")
 	}
 
 	if len(*logs) == 0 {
@@ -111,7 +129,7 @@ func handlerGetLogs(c *fiber.Ctx) error {
 	// Set X-TOTAL-COUNT
 	if params.TransactionHash != "" {
 		// By Transaction
-		transaction, err := crud.GetTransactionCrud().SelectOne(params.TransactionHash, -1)
+		transaction, err := crud.GetTransactionCrud().SelectOne(c.UserContext(), params.TransactionHash, -1)
 		count := int64(0)
 		if err != nil {
 			zap.S().Warn("Logs CRUD ERROR: ", err.Error())
@@ -138,6 +156,12 @@ func handlerGetLogs(c *fiber.Ctx) error {
 		}
 		c.Append("X-TOTAL-COUNT", strconv.FormatInt(count, 10))
 	}
+
+	// Set X-NEXT-CURSOR
+	if len(*logs) == params.Limit {
+		c.Append("X-NEXT-CURSOR", lastCursor.Encode())
+	}
+
 	if c.Get("Accept") == "text/csv" {
 		return respondWithCSV(c, *logs)
 	}
@@ -145,7 +169,7 @@ func handlerGetLogs(c *fiber.Ctx) error {
 	// Continue with JSON response if not CSV
 	body, err := json.Marshal(&logs)
 	if err != nil {
-		return c.SendString(`{"error": "parsing error"}`)
+		return errInternal("could not encode response")
 	}
 
 	return c.SendString(string(body))
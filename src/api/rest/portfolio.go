@@ -0,0 +1,260 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+	"github.com/sudoblockio/icon-go-api/prices"
+	"github.com/sudoblockio/icon-go-api/service"
+)
+
+// Decimals of IRC2 tokens by contract address, they can't change
+var tokenDecimals sync.Map
+
+// IRC2 tokens whose decimals are being read from their contracts in the background
+var tokenDecimalsLoading sync.Map
+
+// Portfolio - ICX and token holdings of an address
+type Portfolio struct {
+	Address string             `json:"address"`
+	Icx     PortfolioHolding   `json:"icx"`
+	Tokens  []PortfolioHolding `json:"tokens"`
+
+	// Sum of the holdings with a price, left out if none have one
+	TotalValueUSD *float64 `json:"total_value_usd,omitempty"`
+}
+
+// PortfolioHolding - balance of ICX or a token, valued if a price source has its price
+type PortfolioHolding struct {
+	TokenContractAddress string   `json:"token_contract_address,omitempty"`
+	TokenStandard        string   `json:"token_standard" example:"irc2"`
+	Name                 string   `json:"name"`
+	Symbol               string   `json:"symbol"`
+	Balance              float64  `json:"balance"`
+	PriceUSD             *float64 `json:"price_usd,omitempty"`
+	ValueUSD             *float64 `json:"value_usd,omitempty"`
+
+	// Left out of IRC2 tokens until read from the token's contract in the background
+	Decimals *int `json:"decimals,omitempty"`
+}
+
+// Address Portfolio
+// @Summary Get Address Portfolio
+// @Description get the ICX and IRC2, IRC3 and IRC31 token holdings of an address, with USD values where a price source has the price
+// @Description tokens are sorted by USD value, then contract address, and paged
+// @Tags Addresses
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param address path string true "address"
+// @Param limit query int false "amount of tokens"
+// @Param skip query int false "skip to a token"
+// @Router /api/v1/addresses/{address}/portfolio [get]
+// @Success 200 {object} Portfolio
+// @Failure 422 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerGetAddressPortfolio(c *fiber.Ctx) error {
+	addressString := c.Params("address")
+
+	params := new(SkipLimitQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Address Portfolio Get Handler ERROR: %s", err.Error())
+
+		return errInvalidQuery(err)
+	}
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 100
+	}
+
+	// Check Params
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
+	}
+
+	address, err := crud.GetAddressCrud().SelectOne(c.UserContext(), addressString)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errNotFound("address not found")
+		}
+		zap.S().Warn(
+			"Endpoint=handlerGetAddressPortfolio",
+			" Error=Could not retrieve address: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve address")
+	}
+
+	tokenAddresses, err := crud.GetTokenAddressCrud().SelectManyByAddress(c.UserContext(), addressString)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetAddressPortfolio",
+			" Error=Could not retrieve token addresses: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve token balances")
+	}
+
+	tokenContractAddresses := make([]string, len(*tokenAddresses))
+	for i, tokenAddress := range *tokenAddresses {
+		tokenContractAddresses[i] = tokenAddress.TokenContractAddress
+	}
+	contracts, err := crud.GetAddressCrud().SelectManyContractsByAddresses(c.UserContext(), tokenContractAddresses)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetAddressPortfolio",
+			" Error=Could not retrieve token contracts: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve token contracts")
+	}
+
+	portfolio := buildPortfolio(address, *tokenAddresses, *contracts)
+
+	// Set X-TOTAL-COUNT
+	c.Append("X-TOTAL-COUNT", strconv.Itoa(len(portfolio.Tokens)))
+
+	// Page of tokens
+	if params.Skip >= len(portfolio.Tokens) {
+		portfolio.Tokens = []PortfolioHolding{}
+	} else {
+		portfolio.Tokens = portfolio.Tokens[params.Skip:]
+	}
+	if len(portfolio.Tokens) > params.Limit {
+		portfolio.Tokens = portfolio.Tokens[:params.Limit]
+	}
+
+	setTokenDecimals(portfolio.Tokens)
+
+	body, _ := json.Marshal(&portfolio)
+	return c.SendString(string(body))
+}
+
+// buildPortfolio - holdings of an address named and valued, tokens sorted by value
+func buildPortfolio(
+	address *models.Address,
+	tokenAddresses []models.TokenAddress,
+	contracts []models.ContractList,
+) *Portfolio {
+	icxDecimals := 18
+	portfolio := &Portfolio{
+		Address: address.Address,
+		Icx: PortfolioHolding{
+			TokenStandard: "icx",
+			Name:          "ICON",
+			Symbol:        "ICX",
+			Decimals:      &icxDecimals,
+			Balance:       address.Balance,
+		},
+		Tokens: []PortfolioHolding{},
+	}
+	portfolio.valueHolding(&portfolio.Icx, prices.IcxID)
+
+	contractsByAddress := map[string]models.ContractList{}
+	for _, contract := range contracts {
+		contractsByAddress[contract.Address] = contract
+	}
+
+	for _, tokenAddress := range tokenAddresses {
+		contract := contractsByAddress[tokenAddress.TokenContractAddress]
+
+		holding := PortfolioHolding{
+			TokenContractAddress: tokenAddress.TokenContractAddress,
+			TokenStandard:        tokenAddress.TokenStandard,
+			Name:                 contract.Name,
+			Symbol:               contract.Symbol,
+			Balance:              tokenAddress.Balance,
+		}
+
+		// NFT balances are counts, they have no price
+		if holding.TokenStandard == "irc2" {
+			portfolio.valueHolding(&holding, holding.TokenContractAddress)
+		}
+
+		portfolio.Tokens = append(portfolio.Tokens, holding)
+	}
+
+	sort.SliceStable(portfolio.Tokens, func(i, j int) bool {
+		a, b := portfolio.Tokens[i], portfolio.Tokens[j]
+		if (a.ValueUSD == nil) != (b.ValueUSD == nil) {
+			return a.ValueUSD != nil
+		}
+		if a.ValueUSD != nil && *a.ValueUSD != *b.ValueUSD {
+			return *a.ValueUSD > *b.ValueUSD
+		}
+		return a.TokenContractAddress < b.TokenContractAddress
+	})
+
+	return portfolio
+}
+
+// valueHolding - set the holding's USD price and value, and add it to the total
+func (p *Portfolio) valueHolding(holding *PortfolioHolding, priceID string) {
+	price, ok := prices.Get(priceID)
+	if ok == false {
+		return
+	}
+
+	value := holding.Balance * price
+	holding.PriceUSD = &price
+	holding.ValueUSD = &value
+
+	if p.TotalValueUSD == nil {
+		p.TotalValueUSD = new(float64)
+	}
+	*p.TotalValueUSD += value
+}
+
+// setTokenDecimals - decimals of IRC2 holdings from their contracts, unset until they've been read
+func setTokenDecimals(holdings []PortfolioHolding) {
+	for i := range holdings {
+		holding := &holdings[i]
+		if holding.TokenStandard != "irc2" {
+			continue
+		}
+
+		if decimals, ok := getTokenDecimals(holding.TokenContractAddress); ok {
+			holding.Decimals = &decimals
+		}
+	}
+}
+
+// getTokenDecimals - decimals of an IRC2 token if they've been read from its contract
+// Misses are read in the background once, so requests never wait on the node
+func getTokenDecimals(tokenContractAddress string) (int, bool) {
+	if decimals, ok := tokenDecimals.Load(tokenContractAddress); ok {
+		return decimals.(int), true
+	}
+
+	if _, loading := tokenDecimalsLoading.LoadOrStore(tokenContractAddress, true); loading == false {
+		go loadTokenDecimals(tokenContractAddress)
+	}
+
+	return 0, false
+}
+
+// loadTokenDecimals - read the decimals of an IRC2 token from its contract into the cache
+func loadTokenDecimals(tokenContractAddress string) {
+	defer tokenDecimalsLoading.Delete(tokenContractAddress)
+
+	decimals, err := service.IconNodeServiceGetTokenDecimals(context.Background(), tokenContractAddress)
+	if err != nil {
+		zap.S().Warn(
+			"Could not retrieve decimals TOKEN_CONTRACT_ADDRESS=", tokenContractAddress,
+			" ERROR=", err.Error(),
+		)
+		return
+	}
+
+	tokenDecimals.Store(tokenContractAddress, decimals)
+}
@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/models"
+	"github.com/sudoblockio/icon-go-api/prices"
+)
+
+func TestBuildPortfolio(t *testing.T) {
+	assert := assert.New(t)
+
+	oracle := prices.NewOracle([]prices.PriceProvider{
+		prices.NewStaticProvider(map[string]float64{
+			"icx": 0.25,
+			"cx2": 2,
+		}),
+	}, 0)
+	oracle.Refresh(context.Background())
+	prices.SetOracle(oracle)
+	defer prices.SetOracle(prices.NewOracle(nil, 0))
+
+	address := &models.Address{Address: "hx1", Balance: 100}
+	tokenAddresses := []models.TokenAddress{
+		{TokenContractAddress: "cx1", Balance: 1000, TokenStandard: "irc2"},
+		{TokenContractAddress: "cx2", Balance: 10, TokenStandard: "irc2"},
+		{TokenContractAddress: "cx3", Balance: 2, TokenStandard: "irc3"},
+	}
+	contracts := []models.ContractList{
+		{Address: "cx1", Name: "Unpriced", Symbol: "UNP"},
+		{Address: "cx2", Name: "Priced", Symbol: "PRC"},
+		{Address: "cx3", Name: "Collectible", Symbol: "NFT"},
+	}
+
+	portfolio := buildPortfolio(address, tokenAddresses, contracts)
+
+	assert.Equal("ICX", portfolio.Icx.Symbol)
+	assert.Equal(25.0, *portfolio.Icx.ValueUSD)
+
+	// Valued tokens first
+	assert.Equal(3, len(portfolio.Tokens))
+	assert.Equal("PRC", portfolio.Tokens[0].Symbol)
+	assert.Equal(20.0, *portfolio.Tokens[0].ValueUSD)
+	assert.Equal("UNP", portfolio.Tokens[1].Symbol)
+	assert.Nil(portfolio.Tokens[1].ValueUSD)
+	assert.Equal("irc3", portfolio.Tokens[2].TokenStandard)
+
+	assert.Equal(45.0, *portfolio.TotalValueUSD)
+
+	// No price source
+	prices.SetOracle(prices.NewOracle(nil, 0))
+	portfolio = buildPortfolio(address, tokenAddresses, contracts)
+	assert.Nil(portfolio.TotalValueUSD)
+}
+
+func TestSetTokenDecimals(t *testing.T) {
+	assert := assert.New(t)
+
+	tokenDecimals.Store("cx1", 18)
+	defer tokenDecimals.Delete("cx1")
+
+	// Already being read, so no node call is made
+	tokenDecimalsLoading.Store("cx2", true)
+	defer tokenDecimalsLoading.Delete("cx2")
+
+	holdings := []PortfolioHolding{
+		{TokenContractAddress: "cx1", TokenStandard: "irc2"},
+		{TokenContractAddress: "cx2", TokenStandard: "irc2"},
+		{TokenContractAddress: "cx3", TokenStandard: "irc3"},
+	}
+	setTokenDecimals(holdings)
+
+	assert.Equal(18, *holdings[0].Decimals)
+	// Left out on a miss instead of waiting on the node
+	assert.Nil(holdings[1].Decimals)
+	assert.Nil(holdings[2].Decimals)
+}
@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgconn"
+)
+
+// Postgres error codes
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgCodeQueryCanceled    = "57014"
+	pgCodeCannotConnectNow = "57P03"
+
+	// Includes too_many_connections
+	pgClassInsufficientResources = "53"
+)
+
+// QueryTimeout - cancel the request's queries once the timeout passes
+// Handlers pass c.UserContext() to crud, 0 for no timeout
+// Queries aren't canceled when the client disconnects, fasthttp doesn't report it until the response is written
+func QueryTimeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+
+		c.SetUserContext(ctx)
+
+		return c.Next()
+	}
+}
+
+// queryErrorStatus - status for a failed query
+// 504 when it ran out of time, 503 when it was canceled or postgres is out of connections, otherwise 500
+func queryErrorStatus(err error) int {
+	if errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err) {
+		return fiber.StatusGatewayTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return fiber.StatusServiceUnavailable
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == pgCodeQueryCanceled:
+			// statement_timeout set on the server
+			return fiber.StatusGatewayTimeout
+		case pgErr.Code == pgCodeCannotConnectNow || strings.HasPrefix(pgErr.Code, pgClassInsufficientResources):
+			return fiber.StatusServiceUnavailable
+		}
+	}
+
+	return fiber.StatusInternalServerError
+}
+
+// errQuery - error for a failed query, message is used for errors other than timeouts
+func errQuery(err error, message string) *APIError {
+	status := queryErrorStatus(err)
+	switch status {
+	case fiber.StatusGatewayTimeout:
+		return &APIError{Status: status, Code: ErrorCodeQueryTimeout, Message: "query timed out"}
+	case fiber.StatusServiceUnavailable:
+		return &APIError{Status: status, Code: ErrorCodeUnavailable, Message: "database unavailable"}
+	}
+
+	return errInternal(message)
+}
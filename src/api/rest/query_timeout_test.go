@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use("/api/v1/blocks", QueryTimeout(10*time.Millisecond))
+	app.Get("/api/v1/blocks", func(c *fiber.Ctx) error {
+		// Stand in for a slow query
+		<-c.UserContext().Done()
+		return errQuery(c.UserContext().Err(), "could not retrieve blocks")
+	})
+	app.Get("/api/v1/logs", func(c *fiber.Ctx) error {
+		_, ok := c.UserContext().Deadline()
+		assert.Equal(false, ok)
+		return c.SendString("[]")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/blocks", nil))
+	assert.Equal(nil, err)
+	assert.Equal(504, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(`{"code":"query_timeout","message":"query timed out"}`, string(body))
+
+	// Other groups are unaffected
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/logs", nil))
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+}
+
+func TestQueryErrorStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(504, queryErrorStatus(context.DeadlineExceeded))
+	assert.Equal(504, queryErrorStatus(fmt.Errorf("query: %w", context.DeadlineExceeded)))
+	assert.Equal(504, queryErrorStatus(&pgconn.PgError{Code: "57014"}))
+	assert.Equal(503, queryErrorStatus(context.Canceled))
+	assert.Equal(503, queryErrorStatus(&pgconn.PgError{Code: "53300"}))
+	assert.Equal(503, queryErrorStatus(&pgconn.PgError{Code: "57P03"}))
+	assert.Equal(500, queryErrorStatus(&pgconn.PgError{Code: "42P01"}))
+	assert.Equal(500, queryErrorStatus(errors.New("connection reset")))
+}
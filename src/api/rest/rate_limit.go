@@ -0,0 +1,123 @@
+package rest
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/apikeys"
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/redis"
+)
+
+// Rate limit headers
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+	HeaderQuotaLimit         = "X-Quota-Limit"
+	HeaderQuotaRemaining     = "X-Quota-Remaining"
+)
+
+// rateLimit - limits of a request
+type rateLimit struct {
+	// Bucket and quota key
+	id         string
+	rate       float64
+	burst      int64
+	dailyQuota int64
+}
+
+// RateLimit - rate limit middleware
+// The api key is read from the api key header or query parameter, requests without one are limited by ip
+func RateLimit() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if strings.Contains(c.Path(), "/docs/") {
+			return c.Next()
+		}
+
+		key := c.Get(config.Config.APIKeyHeader)
+		if key == "" {
+			key = c.Query(config.Config.APIKeyQueryParam)
+		}
+		// Handlers reject unknown query parameters
+		c.Context().QueryArgs().Del(config.Config.APIKeyQueryParam)
+
+		limit := &rateLimit{
+			id:         "ip_" + c.IP(),
+			rate:       config.Config.RateLimitAnonymousRate,
+			burst:      config.Config.RateLimitAnonymousBurst,
+			dailyQuota: config.Config.RateLimitAnonymousDailyQuota,
+		}
+		if key != "" {
+			apiKey, ok := apikeys.Lookup(key)
+			if ok == false {
+				return errUnauthorized("invalid api key")
+			}
+
+			limit = &rateLimit{
+				id:         "key_" + apiKey.KeyHash,
+				rate:       apiKey.Rate,
+				burst:      apiKey.Burst,
+				dailyQuota: apiKey.DailyQuota,
+			}
+		}
+
+		// Rate
+		taken, tokens, err := redis.GetRedisClient().TakeToken(
+			config.Config.RedisKeyPrefix+"rate_limit_"+limit.id,
+			limit.rate,
+			limit.burst,
+		)
+		if err != nil {
+			// Don't take the api down with redis
+			zap.S().Warn("RateLimit: Unable to take token ERROR=", err.Error())
+			return c.Next()
+		}
+		c.Set(HeaderRateLimitLimit, strconv.FormatInt(limit.burst, 10))
+		c.Set(HeaderRateLimitRemaining, strconv.FormatInt(int64(math.Floor(tokens)), 10))
+		c.Set(HeaderRateLimitReset, strconv.FormatInt(secondsUntil(float64(limit.burst)-tokens, limit.rate), 10))
+		if taken == false {
+			c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(secondsUntil(1-tokens, limit.rate), 10))
+			return &APIError{Status: fiber.StatusTooManyRequests, Code: ErrorCodeRateLimited, Message: "rate limit exceeded"}
+		}
+
+		// Quota
+		if limit.dailyQuota > 0 {
+			day := time.Now().UTC().Format("2006-01-02")
+			count, err := redis.GetRedisClient().IncQuota(
+				config.Config.RedisKeyPrefix+"quota_"+limit.id+"_"+day,
+				25*time.Hour,
+			)
+			if err != nil {
+				zap.S().Warn("RateLimit: Unable to count quota ERROR=", err.Error())
+				return c.Next()
+			}
+
+			remaining := limit.dailyQuota - count
+			if remaining < 0 {
+				remaining = 0
+			}
+			c.Set(HeaderQuotaLimit, strconv.FormatInt(limit.dailyQuota, 10))
+			c.Set(HeaderQuotaRemaining, strconv.FormatInt(remaining, 10))
+			if count > limit.dailyQuota {
+				return &APIError{Status: fiber.StatusTooManyRequests, Code: ErrorCodeQuotaExceeded, Message: "daily quota exceeded"}
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// secondsUntil - seconds until a number of tokens are refilled
+func secondsUntil(tokens float64, rate float64) int64 {
+	if tokens <= 0 {
+		return 0
+	}
+
+	return int64(math.Ceil(tokens / rate))
+}
@@ -0,0 +1,168 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/metrics"
+	"github.com/sudoblockio/icon-go-api/redis"
+)
+
+// Header set on cacheable responses, HIT or MISS
+const HeaderCache = "X-CACHE"
+
+// Latest block number seen on the blocks channel, 0 until the first block
+var cacheLatestBlockNumber int64
+
+// cachedResponse - response stored in redis
+type cachedResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers"`
+	Body    []byte            `json:"body"`
+}
+
+// StartResponseCache - track the latest block to version cached responses
+func StartResponseCache() {
+	go func() {
+		for {
+			msgChan := make(chan []byte, config.Config.WebsocketClientBufferSize)
+			broadcasterID := redis.GetBroadcaster(config.Config.RedisBlocksChannel).AddBroadcastChannel(msgChan)
+
+			for msg := range msgChan {
+				block := &struct {
+					Number int64 `json:"number"`
+				}{}
+				err := json.Unmarshal(msg, block)
+				if err != nil {
+					zap.S().Warn("ResponseCache: Unable to parse block ERROR=", err.Error())
+					continue
+				}
+
+				if block.Number > atomic.LoadInt64(&cacheLatestBlockNumber) {
+					atomic.StoreInt64(&cacheLatestBlockNumber, block.Number)
+				}
+			}
+
+			// Removed by the broadcaster as a slow consumer, subscribe again
+			redis.GetBroadcaster(config.Config.RedisBlocksChannel).RemoveBroadcastChannel(broadcasterID)
+		}
+	}()
+}
+
+// ResponseCache - cache successful responses in redis until a new block arrives
+func ResponseCache() fiber.Handler {
+	return responseCache("")
+}
+
+// ResponseCacheByBlock - like ResponseCache, but responses about a block below the latest block
+// are kept for the finalized ttl since they can't change
+// blockNumberParam is the route parameter with the block number
+func ResponseCacheByBlock(blockNumberParam string) fiber.Handler {
+	return responseCache(blockNumberParam)
+}
+
+func responseCache(blockNumberParam string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		latestBlockNumber := atomic.LoadInt64(&cacheLatestBlockNumber)
+		if config.Config.RestCacheEnabled == false || latestBlockNumber == 0 {
+			// Nothing to version responses by until the first block
+			return c.Next()
+		}
+
+		version := strconv.FormatInt(latestBlockNumber, 10)
+		ttl := config.Config.RestCacheTTL
+		if blockNumberParam != "" {
+			blockNumber, err := strconv.ParseInt(c.Params(blockNumberParam), 10, 64)
+			if err == nil && blockNumber < latestBlockNumber {
+				version = "finalized"
+				ttl = config.Config.RestCacheFinalizedTTL
+			}
+		}
+		key := config.Config.RedisKeyPrefix + responseCacheKey(c, version)
+
+		// Check cache
+		cached, err := redis.GetRedisClient().GetCache(key)
+		if err != nil {
+			zap.S().Warn("ResponseCache: Unable to get cached response ERROR=", err.Error())
+		} else if cached != nil {
+			response := &cachedResponse{}
+			err = json.Unmarshal(cached, response)
+			if err == nil {
+				for name, value := range response.Headers {
+					c.Set(name, value)
+				}
+				c.Set(HeaderCache, "HIT")
+				metrics.ResponseCacheCounter.WithLabelValues("hit").Inc()
+				c.Status(response.Status)
+				return c.Send(response.Body)
+			}
+			zap.S().Warn("ResponseCache: Unable to parse cached response ERROR=", err.Error())
+		}
+
+		metrics.ResponseCacheCounter.WithLabelValues("miss").Inc()
+
+		// Headers set by earlier middleware, like rate limits, are per request
+		requestHeaders := map[string]bool{}
+		c.Response().Header.VisitAll(func(name []byte, value []byte) {
+			if strings.HasPrefix(string(name), "X-") {
+				requestHeaders[string(name)] = true
+			}
+		})
+
+		err = c.Next()
+		if err != nil {
+			return err
+		}
+		c.Set(HeaderCache, "MISS")
+
+		// Only cache successes
+		status := c.Response().StatusCode()
+		if status < 200 || status > 299 {
+			return nil
+		}
+
+		response := &cachedResponse{
+			Status:  status,
+			Headers: map[string]string{},
+			Body:    c.Response().Body(),
+		}
+		c.Response().Header.VisitAll(func(name []byte, value []byte) {
+			headerName := string(name)
+			if requestHeaders[headerName] {
+				return
+			}
+			if headerName == fiber.HeaderContentType || strings.HasPrefix(headerName, "X-") {
+				response.Headers[headerName] = string(value)
+			}
+		})
+		delete(response.Headers, HeaderCache)
+
+		cached, _ = json.Marshal(response)
+		err = redis.GetRedisClient().SetCache(key, cached, ttl)
+		if err != nil {
+			zap.S().Warn("ResponseCache: Unable to cache response ERROR=", err.Error())
+		}
+
+		return nil
+	}
+}
+
+// responseCacheKey - cache key of a request, by route and normalized query
+// Query parameters are sorted so their order doesn't matter, the Accept header picks json or csv
+func responseCacheKey(c *fiber.Ctx, version string) string {
+	query := url.Values{}
+	c.Context().QueryArgs().VisitAll(func(key []byte, value []byte) {
+		query.Add(string(key), string(value))
+	})
+
+	path := strings.TrimSuffix(c.Path(), "/")
+
+	return "response_cache_" + version + "_" + path + "?" + query.Encode() + "_" + c.Get(fiber.HeaderAccept)
+}
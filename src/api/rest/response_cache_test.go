@@ -0,0 +1,48 @@
+package rest
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseCacheKey(t *testing.T) {
+	assert := assert.New(t)
+
+	keys := []string{}
+	app := fiber.New()
+	app.Get("/api/v1/blocks", func(c *fiber.Ctx) error {
+		keys = append(keys, responseCacheKey(c, "100"))
+		return nil
+	})
+
+	requests := []struct {
+		url    string
+		accept string
+	}{
+		{"/api/v1/blocks?limit=10&skip=5", ""},
+		{"/api/v1/blocks?skip=5&limit=10", ""},
+		{"/api/v1/blocks/?limit=10&skip=5", ""},
+		{"/api/v1/blocks?limit=10&skip=5", "text/csv"},
+		{"/api/v1/blocks?limit=10", ""},
+	}
+	for _, request := range requests {
+		req := httptest.NewRequest("GET", request.url, nil)
+		if request.accept != "" {
+			req.Header.Set(fiber.HeaderAccept, request.accept)
+		}
+		_, err := app.Test(req)
+		assert.Equal(nil, err)
+	}
+
+	// Query order and trailing slash don't matter
+	assert.Equal(5, len(keys))
+	assert.Equal(keys[0], keys[1])
+	assert.Equal(keys[0], keys[2])
+
+	// Accept and query values do
+	assert.NotEqual(keys[0], keys[3])
+	assert.NotEqual(keys[0], keys[4])
+}
@@ -0,0 +1,316 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+)
+
+// Search result types
+const (
+	SearchTypeBlock       = "block"
+	SearchTypeTransaction = "transaction"
+	SearchTypeAddress     = "address"
+	SearchTypeContract    = "contract"
+	SearchTypeToken       = "token"
+)
+
+// Search result matches, best first
+const (
+	SearchMatchExact  = "exact"
+	SearchMatchPrefix = "prefix"
+	SearchMatchFuzzy  = "fuzzy"
+)
+
+var (
+	searchHashRegex    = regexp.MustCompile(`^(0x)?[0-9a-f]{64}$`)
+	searchAddressRegex = regexp.MustCompile(`^(hx|cx)[0-9a-f]{40}$`)
+)
+
+type SearchQuery struct {
+	Q     string `query:"q"`
+	Limit int    `query:"limit"`
+}
+
+// SearchResult - block, transaction, address, contract or token matching a search
+type SearchResult struct {
+	Type   string `json:"type" example:"token"`
+	Value  string `json:"value" example:"cx88fd7df7ddff82f7cc735c871dc519838cb235bb"` // Block number, hash, or address
+	Name   string `json:"name,omitempty" example:"Balanced Dollar"`
+	Symbol string `json:"symbol,omitempty" example:"bnUSD"`
+	Match  string `json:"match" example:"prefix"`
+}
+
+// searchClassification - what a search query could identify
+type searchClassification struct {
+	blockNumber uint32
+	isNumber    bool
+	hash        string
+	address     string
+	text        string
+}
+
+func SearchAddHandlers(app *fiber.App) {
+
+	prefix := config.Config.RestPrefix + "/search"
+
+	app.Use(prefix, QueryTimeout(config.Config.RestQueryTimeoutSearch))
+
+	app.Get(prefix+"/", ResponseCache(), handlerSearch)
+}
+
+// Search
+// @Summary Search
+// @Description find blocks by number or hash, transactions by hash, addresses, and contracts or tokens by name or symbol
+// @Description names and symbols match by prefix, and by word similarity from 3 characters by default, shorter than 2 characters by default they are not searched
+// @Tags Search
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param q query string true "block number, block or transaction hash, address, or token name or symbol"
+// @Param limit query int false "amount of records, for typeahead"
+// @Router /api/v1/search [get]
+// @Success 200 {object} []SearchResult
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerSearch(c *fiber.Ctx) error {
+	params := new(SearchQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Search Get Handler ERROR: %s", err.Error())
+
+		return errInvalidQuery(err)
+	}
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+
+	// Check Params
+	params.Q = strings.TrimSpace(params.Q)
+	if params.Q == "" {
+		return errInvalidParameter("q", "q required")
+	}
+	if params.Limit > config.Config.SearchMaxLimit {
+		return errInvalidParameter("limit", "limit must be between 1 and "+strconv.Itoa(config.Config.SearchMaxLimit))
+	}
+
+	classification := classifySearchQuery(params.Q)
+
+	var wg sync.WaitGroup
+	var mux sync.Mutex
+	results := []SearchResult{}
+	var queryErr error
+
+	// Collect a query's results, not found isn't an error
+	collect := func(found []SearchResult, err error) {
+		mux.Lock()
+		defer mux.Unlock()
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) == false && queryErr == nil {
+				queryErr = err
+			}
+			return
+		}
+		results = append(results, found...)
+	}
+
+	if classification.isNumber {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			block, err := crud.GetBlockCrud().SelectOne(c.UserContext(), classification.blockNumber)
+			if err != nil {
+				collect(nil, err)
+				return
+			}
+			collect([]SearchResult{{
+				Type:  SearchTypeBlock,
+				Value: strconv.FormatInt(block.Number, 10),
+				Match: SearchMatchExact,
+			}}, nil)
+		}()
+	}
+
+	if classification.hash != "" {
+		// Blocks and transactions share the hash format
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			block, err := crud.GetBlockCrud().SelectOneByHash(c.UserContext(), classification.hash)
+			if err != nil {
+				collect(nil, err)
+				return
+			}
+			collect([]SearchResult{{
+				Type:  SearchTypeBlock,
+				Value: strconv.FormatInt(block.Number, 10),
+				Match: SearchMatchExact,
+			}}, nil)
+		}()
+		go func() {
+			defer wg.Done()
+			transaction, err := crud.GetTransactionCrud().SelectOne(c.UserContext(), classification.hash, -1)
+			if err != nil {
+				collect(nil, err)
+				return
+			}
+			collect([]SearchResult{{
+				Type:  SearchTypeTransaction,
+				Value: transaction.Hash,
+				Match: SearchMatchExact,
+			}}, nil)
+		}()
+	}
+
+	if classification.address != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			address, err := crud.GetAddressCrud().SelectOne(c.UserContext(), classification.address)
+			if err != nil {
+				collect(nil, err)
+				return
+			}
+			result := SearchResult{
+				Type:   SearchTypeAddress,
+				Value:  address.Address,
+				Name:   address.Name,
+				Symbol: address.Symbol,
+				Match:  SearchMatchExact,
+			}
+			if address.IsToken {
+				result.Type = SearchTypeToken
+			} else if address.IsContract {
+				result.Type = SearchTypeContract
+			}
+			collect([]SearchResult{result}, nil)
+		}()
+	}
+
+	// Shorter names and symbols match too many contracts to be worth a query
+	if len(classification.text) >= config.Config.SearchMinLength {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			contracts, err := crud.GetAddressCrud().SearchContracts(
+				c.UserContext(),
+				classification.text,
+				len(classification.text) >= config.Config.SearchMinFuzzyLength,
+				params.Limit,
+			)
+			if err != nil {
+				collect(nil, err)
+				return
+			}
+
+			found := make([]SearchResult, len(*contracts))
+			for i, contract := range *contracts {
+				found[i] = SearchResult{
+					Type:   SearchTypeContract,
+					Value:  contract.Address,
+					Name:   contract.Name,
+					Symbol: contract.Symbol,
+					Match:  searchTextMatch(classification.text, contract.Name, contract.Symbol),
+				}
+				if contract.IsToken {
+					found[i].Type = SearchTypeToken
+				}
+			}
+			collect(found, nil)
+		}()
+	}
+
+	// All use the request, wait for all before responding
+	wg.Wait()
+
+	if queryErr != nil {
+		zap.S().Warn(
+			"Endpoint=handlerSearch",
+			" Error=Could not search: ", queryErr.Error(),
+		)
+		return errQuery(queryErr, "could not search")
+	}
+
+	results = rankSearchResults(results)
+	if len(results) > params.Limit {
+		results = results[:params.Limit]
+	}
+
+	body, _ := json.Marshal(&results)
+	return c.SendString(string(body))
+}
+
+// classifySearchQuery - identifiers a query could be
+// Hashes and addresses are matched case insensitively, anything else is searched as a name or symbol
+func classifySearchQuery(query string) searchClassification {
+	classification := searchClassification{}
+
+	lower := strings.ToLower(query)
+
+	// Block 0 is left to the name search, selecting block 0 selects the latest block
+	blockNumber, err := strconv.ParseUint(query, 10, 32)
+	if err == nil && blockNumber != 0 {
+		classification.blockNumber = uint32(blockNumber)
+		classification.isNumber = true
+		return classification
+	}
+
+	if searchHashRegex.MatchString(lower) {
+		classification.hash = "0x" + strings.TrimPrefix(lower, "0x")
+		return classification
+	}
+
+	if searchAddressRegex.MatchString(lower) {
+		classification.address = lower
+		return classification
+	}
+
+	classification.text = query
+	return classification
+}
+
+// searchTextMatch - how a contract's name or symbol matches a text query
+func searchTextMatch(text string, name string, symbol string) string {
+	text = strings.ToLower(text)
+	name = strings.ToLower(name)
+	symbol = strings.ToLower(symbol)
+
+	switch {
+	case name == text || symbol == text:
+		return SearchMatchExact
+	case strings.HasPrefix(name, text) || strings.HasPrefix(symbol, text):
+		return SearchMatchPrefix
+	default:
+		return SearchMatchFuzzy
+	}
+}
+
+// rankSearchResults - exact matches first, then prefix, then fuzzy
+// Results with the same match keep the order they were queried in
+func rankSearchResults(results []SearchResult) []SearchResult {
+	rank := map[string]int{
+		SearchMatchExact:  0,
+		SearchMatchPrefix: 1,
+		SearchMatchFuzzy:  2,
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return rank[results[i].Match] < rank[results[j].Match]
+	})
+
+	return results
+}
@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifySearchQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	hash := "0x" + "ab12000000000000000000000000000000000000000000000000000000000000"
+
+	tests := []struct {
+		query    string
+		expected searchClassification
+	}{
+		{"1234", searchClassification{blockNumber: 1234, isNumber: true}},
+		{hash, searchClassification{hash: hash}},
+		{"AB12000000000000000000000000000000000000000000000000000000000000", searchClassification{hash: hash}},
+		{"hx0000000000000000000000000000000000000001", searchClassification{address: "hx0000000000000000000000000000000000000001"}},
+		{"CX0000000000000000000000000000000000000001", searchClassification{address: "cx0000000000000000000000000000000000000001"}},
+		{"bnUSD", searchClassification{text: "bnUSD"}},
+		// Too short for an address
+		{"hx00", searchClassification{text: "hx00"}},
+		// Larger than a block number
+		{"99999999999", searchClassification{text: "99999999999"}},
+		{"0", searchClassification{text: "0"}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.expected, classifySearchQuery(test.query), test.query)
+	}
+}
+
+func TestSearchTextMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(SearchMatchExact, searchTextMatch("bnusd", "Balanced Dollar", "bnUSD"))
+	assert.Equal(SearchMatchPrefix, searchTextMatch("bal", "Balanced Dollar", "bnUSD"))
+	assert.Equal(SearchMatchPrefix, searchTextMatch("bn", "Balanced Dollar", "bnUSD"))
+	assert.Equal(SearchMatchFuzzy, searchTextMatch("dollar", "Balanced Dollar", "bnUSD"))
+}
+
+func TestRankSearchResults(t *testing.T) {
+	assert := assert.New(t)
+
+	results := rankSearchResults([]SearchResult{
+		{Value: "fuzzy", Match: SearchMatchFuzzy},
+		{Value: "prefix-1", Match: SearchMatchPrefix},
+		{Value: "exact", Match: SearchMatchExact},
+		{Value: "prefix-2", Match: SearchMatchPrefix},
+	})
+
+	values := []string{}
+	for _, result := range results {
+		values = append(values, result.Value)
+	}
+	assert.Equal([]string{"exact", "prefix-1", "prefix-2", "fuzzy"}, values)
+}
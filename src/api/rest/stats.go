@@ -3,54 +3,69 @@ package rest
 import (
 	"encoding/json"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
 	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/stats"
 )
 
 func StatsAddHandlers(app *fiber.App) {
 	prefix := config.Config.RestPrefix + "/stats"
 
+	app.Use(prefix, QueryTimeout(config.Config.RestQueryTimeoutStats))
+
 	app.Get(prefix+"/", handlerGetStats)
 	app.Get(prefix+"/circulating-supply", handlerGetCirculatingSupply)
 	app.Get(prefix+"/total-supply", handlerGetTotalSupply)
 	app.Get(prefix+"/market-cap", handlerGetMarketCap)
+	app.Get(prefix+"/daily", handlerGetDailyStats)
+}
+
+type DailyStatsQuery struct {
+	Metric string `query:"metric"`
+	Start  string `query:"start"`
+	End    string `query:"end"`
 }
 
 // Stats
 // @Summary Get Stats
-// @Description get json with a summary of stats
+// @Description get json with a summary of stats, refreshed in the background
+// @Description values are from the last successful refresh, with its timestamp and the error of the last refresh if it failed
 // @Tags Stats
 // @BasePath /api/v1
 // @Accept */*
 // @Router /api/v1/stats [get]
-// @Success 200 {object} map[string]interface{}
-// @Failure 422 {object} map[string]interface{}
+// @Success 200 {object} stats.Stats
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
 func handlerGetStats(c *fiber.Ctx) error {
-	UpdateCirculatingSupply()
-	UpdateMarketCap()
-
-	stats := map[string]interface{}{
-		"circulating-supply": CirculatingSupply,
-		"market-cap":         MarketCap,
-	}
-	body, _ := json.Marshal(stats)
+	body, _ := json.Marshal(stats.GetStats())
 
 	return c.SendString(string(body))
 }
 
 // Circulating Supply
 // @Summary Get Circulating Supply
-// @Description get circulating supply (total supply - burn wallet balance)
+// @Description get circulating supply (total supply - burned and locked wallet balances)
 // @Tags Stats
 // @BasePath /api/v1
 // @Accept */*
 // @Router /api/v1/stats/circulating-supply [get]
 // @Success 200 {object} float64
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 503 {object} APIError
 func handlerGetCirculatingSupply(c *fiber.Ctx) error {
-	UpdateCirculatingSupply()
-	return c.SendString(strconv.FormatFloat(CirculatingSupply, 'f', -1, 64))
+	latest := stats.GetStats()
+	if latest.SupplyUpdatedTimestamp == 0 {
+		return errUnavailable("circulating supply is not available yet")
+	}
+
+	return c.SendString(strconv.FormatFloat(latest.CirculatingSupply, 'f', -1, 64))
 }
 
 // Total Supply
@@ -61,22 +76,117 @@ func handlerGetCirculatingSupply(c *fiber.Ctx) error {
 // @Accept */*
 // @Router /api/v1/stats/total-supply [get]
 // @Success 200 {object} float64
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 503 {object} APIError
 func handlerGetTotalSupply(c *fiber.Ctx) error {
-	UpdateCirculatingSupply()
-	return c.SendString(strconv.FormatFloat(TotalSupply, 'f', -1, 64))
+	latest := stats.GetStats()
+	if latest.SupplyUpdatedTimestamp == 0 {
+		return errUnavailable("total supply is not available yet")
+	}
+
+	return c.SendString(strconv.FormatFloat(latest.TotalSupply, 'f', -1, 64))
 }
 
 // Market Cap
 // @Summary Get Market Cap
-// @Description get mkt cap (Coin Gecko Price * circulating supply)
+// @Description get mkt cap (ICX price * circulating supply)
 // @Tags Stats
 // @BasePath /api/v1
 // @Accept */*
 // @Router /api/v1/stats/market-cap [get]
 // @Success 200 {object} float64
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 503 {object} APIError
 func handlerGetMarketCap(c *fiber.Ctx) error {
-	UpdateMarketCap()
-	return c.SendString(strconv.FormatFloat(MarketCap, 'f', -1, 64))
+	latest := stats.GetStats()
+	if latest.MarketCapUpdatedTimestamp == 0 {
+		return errUnavailable("market cap is not available yet")
+	}
+
+	return c.SendString(strconv.FormatFloat(latest.MarketCap, 'f', -1, 64))
+}
+
+// Daily Stats
+// @Summary Get Daily Stats
+// @Description get a network metric per UTC day, rolled up in the background from the chain tables by the stats writer
+// @Description days are empty until an instance runs with STATS_WRITER_ENABLED
+// @Description metrics are transaction_count, active_addresses, new_addresses, new_contracts, total_fees, icx_volume, token_transfer_count
+// @Description there is no token transfer volume, amounts of different tokens can't be summed, token_transfer_count counts the transfers of every token instead
+// @Description responds with CSV when the Accept header is text/csv
+// @Tags Stats
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param metric query string true "metric"
+// @Param start query string false "first day, YYYY-MM-DD, defaults to 30 days up to end"
+// @Param end query string false "last day, YYYY-MM-DD, defaults to today"
+// @Router /api/v1/stats/daily [get]
+// @Success 200 {object} []models.DailyStat
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerGetDailyStats(c *fiber.Ctx) error {
+	params := new(DailyStatsQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Daily Stats Get Handler ERROR: %s", err.Error())
+
+		return errInvalidQuery(err)
+	}
+
+	// Check Params
+	if isDailyStatMetric(params.Metric) == false {
+		return errInvalidParameter("metric", "metric must be one of "+strings.Join(crud.DailyStatMetrics, ", "))
+	}
+
+	// Default Params
+	end := time.Now().UTC().Truncate(24 * time.Hour)
+	if params.End != "" {
+		var err error
+		end, err = time.Parse(stats.DateFormat, params.End)
+		if err != nil {
+			return errInvalidParameter("end", "end must be a date, YYYY-MM-DD")
+		}
+	}
+	start := end.AddDate(0, 0, -29)
+	if params.Start != "" {
+		var err error
+		start, err = time.Parse(stats.DateFormat, params.Start)
+		if err != nil {
+			return errInvalidParameter("start", "start must be a date, YYYY-MM-DD")
+		}
+	}
+	if start.After(end) {
+		return errInvalidParameter("start", "start must be before end")
+	}
+
+	dailyStats, err := crud.GetDailyStatCrud().SelectMany(
+		c.UserContext(),
+		params.Metric,
+		start.Format(stats.DateFormat),
+		end.Format(stats.DateFormat),
+	)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetDailyStats",
+			" Error=Could not retrieve daily stats: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve daily stats")
+	}
+
+	if c.Get("Accept") == "text/csv" {
+		return respondWithCSV(c, *dailyStats)
+	}
+
+	body, _ := json.Marshal(dailyStats)
+	return c.SendString(string(body))
+}
+
+func isDailyStatMetric(metric string) bool {
+	for _, dailyStatMetric := range crud.DailyStatMetrics {
+		if metric == dailyStatMetric {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/config"
+)
+
+func TestStatsHandlersValidation(t *testing.T) {
+	assert := assert.New(t)
+
+	config.ReadEnvironment()
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	StatsAddHandlers(app)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		// Not refreshed yet
+		{"/stats/circulating-supply", 503},
+		{"/stats/market-cap", 503},
+
+		{"/stats/daily", 422},
+		{"/stats/daily?metric=unknown", 422},
+		{"/stats/daily?metric=transaction_count&start=30-06-2022", 422},
+		{"/stats/daily?metric=transaction_count&start=2022-07-01&end=2022-06-30", 422},
+	}
+
+	for _, test := range tests {
+		resp, err := app.Test(httptest.NewRequest("GET", config.Config.RestPrefix+test.path, nil))
+		assert.Equal(nil, err)
+		assert.Equal(test.status, resp.StatusCode, test.path)
+	}
+}
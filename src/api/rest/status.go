@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/freshness"
+)
+
+// Header with the highest indexed block number
+const HeaderIndexedBlock = "X-Indexed-Block"
+
+func StatusAddHandlers(app *fiber.App) {
+	prefix := config.Config.RestPrefix + "/status"
+
+	app.Get(prefix+"/", handlerGetStatus)
+}
+
+// IndexedBlock - middleware setting the X-Indexed-Block header on every response
+func IndexedBlock() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		// After the handler so cached responses get the current block
+		status := freshness.GetStatus()
+		if status.IndexedBlockNumber != 0 {
+			c.Set(HeaderIndexedBlock, strconv.FormatInt(status.IndexedBlockNumber, 10))
+		}
+
+		return err
+	}
+}
+
+// Status
+// @Summary Get Status
+// @Description get data freshness, how many blocks the indexed data is behind the icon node. Status is ok, degraded when the lag is past the threshold, or unknown.
+// @Tags Status
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Router /api/v1/status [get]
+// @Success 200 {object} freshness.Status
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+func handlerGetStatus(c *fiber.Ctx) error {
+	body, _ := json.Marshal(freshness.GetStatus())
+
+	return c.Send(body)
+}
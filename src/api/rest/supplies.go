@@ -5,52 +5,67 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
 	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/stats"
 )
 
 func SuppliesAddHandlers(app *fiber.App) {
 	prefix := config.Config.RestPrefix + "/supplies"
 
+	app.Use(prefix, QueryTimeout(config.Config.RestQueryTimeoutSupplies))
+
 	app.Get(prefix+"/", handlerGetSupplies)
 	app.Get(prefix+"/circulating-supply", handlerGetSuppliesCirculatingSupply)
 	app.Get(prefix+"/total-supply", handlerGetSuppliesTotalSupply)
 	app.Get(prefix+"/market-cap", handlerGetSuppliesMarketCap)
+	app.Get(prefix+"/breakdown", handlerGetSuppliesBreakdown)
+	app.Get(prefix+"/breakdown/history", handlerGetSuppliesBreakdownHistory)
+}
+
+type SupplyHistoryQuery struct {
+	Limit          int   `query:"limit"`
+	Skip           int   `query:"skip"`
+	StartTimestamp int64 `query:"start_timestamp"`
+	EndTimestamp   int64 `query:"end_timestamp"`
 }
 
 // Supplies
 // @Summary Get Supplies
-// @Description get json with a summary of stats
+// @Description get json with a summary of stats, refreshed in the background
+// @Description values are from the last successful refresh, with its timestamp and the error of the last refresh if it failed
 // @Tags Supplies
 // @BasePath /api/v1
 // @Accept */*
 // @Router /api/v1/supplies [get]
-// @Success 200 {object} map[string]interface{}
-// @Failure 422 {object} map[string]interface{}
+// @Success 200 {object} stats.Stats
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
 func handlerGetSupplies(c *fiber.Ctx) error {
-	UpdateCirculatingSupply()
-	UpdateMarketCap()
-
-	stats := map[string]interface{}{
-		"circulating-supply": CirculatingSupply,
-		"market-cap":         MarketCap,
-	}
-	body, _ := json.Marshal(stats)
+	body, _ := json.Marshal(stats.GetStats())
 
 	return c.SendString(string(body))
 }
 
 // Circulating Supply
 // @Summary Get Circulating Supply
-// @Description get circulating supply (total supply - burn wallet balance)
+// @Description get circulating supply (total supply - burned and locked wallet balances)
 // @Tags Supplies
 // @BasePath /api/v1
 // @Accept */*
 // @Router /api/v1/supplies/circulating-supply [get]
 // @Success 200 {object} float64
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 503 {object} APIError
 func handlerGetSuppliesCirculatingSupply(c *fiber.Ctx) error {
-	UpdateCirculatingSupply()
-	return c.SendString(strconv.FormatFloat(CirculatingSupply, 'f', -1, 64))
+	latest := stats.GetStats()
+	if latest.SupplyUpdatedTimestamp == 0 {
+		return errUnavailable("circulating supply is not available yet")
+	}
+
+	return c.SendString(strconv.FormatFloat(latest.CirculatingSupply, 'f', -1, 64))
 }
 
 // Total Supply
@@ -61,22 +76,130 @@ func handlerGetSuppliesCirculatingSupply(c *fiber.Ctx) error {
 // @Accept */*
 // @Router /api/v1/supplies/total-supply [get]
 // @Success 200 {object} float64
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 503 {object} APIError
 func handlerGetSuppliesTotalSupply(c *fiber.Ctx) error {
-	UpdateCirculatingSupply()
-	return c.SendString(strconv.FormatFloat(TotalSupply, 'f', -1, 64))
+	latest := stats.GetStats()
+	if latest.SupplyUpdatedTimestamp == 0 {
+		return errUnavailable("total supply is not available yet")
+	}
+
+	return c.SendString(strconv.FormatFloat(latest.TotalSupply, 'f', -1, 64))
 }
 
 // Market Cap
 // @Summary Get Market Cap
-// @Description get mkt cap (Coin Gecko Price * circulating supply)
+// @Description get mkt cap (ICX price * circulating supply)
 // @Tags Supplies
 // @BasePath /api/v1
 // @Accept */*
 // @Router /api/v1/supplies/market-cap [get]
 // @Success 200 {object} float64
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 503 {object} APIError
 func handlerGetSuppliesMarketCap(c *fiber.Ctx) error {
-	UpdateMarketCap()
-	return c.SendString(strconv.FormatFloat(MarketCap, 'f', -1, 64))
+	latest := stats.GetStats()
+	if latest.MarketCapUpdatedTimestamp == 0 {
+		return errUnavailable("market cap is not available yet")
+	}
+
+	return c.SendString(strconv.FormatFloat(latest.MarketCap, 'f', -1, 64))
+}
+
+// Supply Breakdown
+// @Summary Get Supply Breakdown
+// @Description get the ICX supply broken down into staked, bonded, delegated, burned, locked and treasury ICX
+// @Description staking is from the chain SCORE's network info, burned, locked and treasury are the balances of the configured wallets
+// @Tags Supplies
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Router /api/v1/supplies/breakdown [get]
+// @Success 200 {object} models.SupplySnapshot
+// @Failure 422 {object} APIError
+// @Failure 503 {object} APIError
+func handlerGetSuppliesBreakdown(c *fiber.Ctx) error {
+	latest := stats.GetStats()
+	if latest.Supply == nil {
+		return errUnavailable("supply breakdown is not available yet")
+	}
+
+	body, _ := json.Marshal(latest.Supply)
+	return c.SendString(string(body))
+}
+
+// Supply Breakdown History
+// @Summary Get Supply Breakdown History
+// @Description get snapshots of the supply breakdown, one per history interval, latest first
+// @Description snapshots are only recorded by an instance running with STATS_WRITER_ENABLED
+// @Tags Supplies
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param limit query int false "amount of records"
+// @Param skip query int false "skip to a record"
+// @Param start_timestamp query int false "snapshots at or after, unix seconds"
+// @Param end_timestamp query int false "snapshots at or before, unix seconds"
+// @Router /api/v1/supplies/breakdown/history [get]
+// @Success 200 {object} []models.SupplySnapshot
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerGetSuppliesBreakdownHistory(c *fiber.Ctx) error {
+	params := new(SupplyHistoryQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Supplies Breakdown History Get Handler ERROR: %s", err.Error())
+
+		return errInvalidQuery(err)
+	}
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 100
+	}
+
+	// Check Params
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
+	}
+	if params.StartTimestamp < 0 {
+		return errInvalidParameter("start_timestamp", "invalid start_timestamp")
+	}
+	if params.EndTimestamp < 0 {
+		return errInvalidParameter("end_timestamp", "invalid end_timestamp")
+	}
+	if params.EndTimestamp != 0 && params.StartTimestamp > params.EndTimestamp {
+		return errInvalidParameter("start_timestamp", "start_timestamp must be before end_timestamp")
+	}
+
+	supplySnapshots, err := crud.GetSupplySnapshotCrud().SelectMany(
+		c.UserContext(),
+		params.Limit,
+		params.Skip,
+		params.StartTimestamp,
+		params.EndTimestamp,
+	)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetSuppliesBreakdownHistory",
+			" Error=Could not retrieve supply snapshots: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve supply history")
+	}
+
+	if len(*supplySnapshots) == 0 {
+		// No Content
+		c.Status(204)
+	}
+
+	// X-TOTAL-COUNT
+	count, err := crud.GetSupplySnapshotCrud().CountBy(c.UserContext(), params.StartTimestamp, params.EndTimestamp)
+	if err != nil {
+		count = 0
+		zap.S().Warn("Could not retrieve supply snapshot count: ", err.Error())
+	}
+	c.Append("X-TOTAL-COUNT", strconv.FormatInt(count, 10))
+
+	body, _ := json.Marshal(supplySnapshots)
+	return c.SendString(string(body))
 }
@@ -0,0 +1,273 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"strconv"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+	"github.com/sudoblockio/icon-go-api/service"
+)
+
+type TokenBalancesQuery struct {
+	BlockNumber int64 `query:"block_number"`
+	Verify      bool  `query:"verify"`
+}
+
+// TokenBalance - IRC2 balance of an address at a block
+type TokenBalance struct {
+	TokenContractAddress string `json:"token_contract_address"`
+
+	// Decimal amount, as precise as the indexer's current balance it's folded back from
+	Balance string `json:"balance"`
+
+	// Hex amount in the token's smallest unit, like transfer values
+	// Left out with the decimals when the token's decimals aren't known yet
+	Value    string `json:"value,omitempty"`
+	Decimals *int   `json:"decimals,omitempty"`
+
+	// Set when verified against a node, which needs the state of the block
+	NodeValue *string `json:"node_value,omitempty"`
+	Verified  *bool   `json:"verified,omitempty"`
+}
+
+// Address Token Balances
+// @Summary Get Address Token Balances
+// @Description get the IRC2 token balances of an address at a block, from its current token balances less the token transfers after the block
+// @Tags Addresses
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param address path string true "address"
+// @Param block_number query int false "block to get the balances at, the latest block if left out"
+// @Param verify query bool false "check each balance exactly against balanceOf on a node, needs a node that keeps the state of the block"
+// @Router /api/v1/addresses/{address}/token-balances [get]
+// @Success 200 {object} []TokenBalance
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerGetAddressTokenBalances(c *fiber.Ctx) error {
+	address := c.Params("address")
+
+	params := new(TokenBalancesQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Address Token Balances Get Handler ERROR: %s", err.Error())
+
+		return errInvalidQuery(err)
+	}
+
+	// Check Params
+	if params.BlockNumber < 0 {
+		return errInvalidParameter("block_number", "invalid block number")
+	}
+
+	latestBlock, err := crud.GetBlockCrud().SelectOne(c.UserContext(), 0)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetAddressTokenBalances",
+			" Error=Could not retrieve latest block: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve latest block")
+	}
+	if params.BlockNumber > latestBlock.Number {
+		return errInvalidParameter("block_number", "block_number is after the latest block")
+	}
+	if params.BlockNumber == 0 {
+		params.BlockNumber = latestBlock.Number
+	}
+
+	// Anchor on the current balances
+	tokenAddresses, err := crud.GetTokenAddressCrud().SelectManyByAddress(c.UserContext(), address)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetAddressTokenBalances",
+			" Error=Could not retrieve token addresses: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve token balances")
+	}
+
+	// Fold back the transfers after the block
+	balanceChanges, err := crud.GetTokenTransferCrud().SelectBalanceChangesAfter(c.UserContext(), address, params.BlockNumber)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetAddressTokenBalances",
+			" Error=Could not retrieve token transfers: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve token balances")
+	}
+
+	decimals := map[string]int{}
+	for _, tokenAddress := range *tokenAddresses {
+		setTokenBalanceDecimals(decimals, tokenAddress.TokenContractAddress)
+	}
+	for _, balanceChange := range *balanceChanges {
+		setTokenBalanceDecimals(decimals, balanceChange.TokenContractAddress)
+	}
+
+	tokenBalances, values, err := newTokenBalances(*tokenAddresses, *balanceChanges, decimals)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetAddressTokenBalances",
+			" Error=Could not read token balances: ", err.Error(),
+		)
+		return errInternal("could not read token balances")
+	}
+
+	if params.Verify {
+		for i := range tokenBalances {
+			// Needs the decimals to compare values
+			if values[i] == nil {
+				continue
+			}
+
+			verifyTokenBalance(c, address, params.BlockNumber, &tokenBalances[i], values[i])
+		}
+	}
+
+	body, _ := json.Marshal(&tokenBalances)
+	return c.SendString(string(body))
+}
+
+// newTokenBalances - nonzero IRC2 balances at the block ordered by token, the current balances less the changes after it
+// Values are in the token's smallest unit, nil for tokens without decimals
+func newTokenBalances(
+	tokenAddresses []models.TokenAddress,
+	balanceChanges []crud.TokenBalanceChange,
+	decimals map[string]int,
+) ([]TokenBalance, []*big.Int, error) {
+	anchors := map[string]float64{}
+	for _, tokenAddress := range tokenAddresses {
+		if tokenAddress.TokenStandard != "irc2" {
+			continue
+		}
+		anchors[tokenAddress.TokenContractAddress] = tokenAddress.Balance
+	}
+
+	changes := map[string]crud.TokenBalanceChange{}
+	for _, balanceChange := range balanceChanges {
+		changes[balanceChange.TokenContractAddress] = balanceChange
+	}
+
+	tokenContractAddresses := []string{}
+	for tokenContractAddress := range anchors {
+		tokenContractAddresses = append(tokenContractAddresses, tokenContractAddress)
+	}
+	for tokenContractAddress := range changes {
+		if _, ok := anchors[tokenContractAddress]; ok == false {
+			tokenContractAddresses = append(tokenContractAddresses, tokenContractAddress)
+		}
+	}
+	sort.Strings(tokenContractAddresses)
+
+	tokenBalances := []TokenBalance{}
+	values := []*big.Int{}
+	for _, tokenContractAddress := range tokenContractAddresses {
+		anchor := anchors[tokenContractAddress]
+		change := changes[tokenContractAddress]
+
+		tokenDecimals, ok := decimals[tokenContractAddress]
+		if ok == false {
+			// Token units only
+			balance := anchor - change.ChangeDecimal
+			if balance == 0 {
+				continue
+			}
+
+			tokenBalances = append(tokenBalances, TokenBalance{
+				TokenContractAddress: tokenContractAddress,
+				Balance:              strconv.FormatFloat(balance, 'f', -1, 64),
+			})
+			values = append(values, nil)
+			continue
+		}
+
+		value, err := tokenValue(anchor, tokenDecimals)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Transfers after the block are exact
+		if change.Change != "" {
+			changeValue, ok := new(big.Int).SetString(change.Change, 10)
+			if ok == false {
+				return nil, nil, errors.New("invalid balance change " + change.Change + " of " + tokenContractAddress)
+			}
+			value.Sub(value, changeValue)
+		}
+
+		// Spent before the block
+		if value.Sign() == 0 {
+			continue
+		}
+
+		tokenBalances = append(tokenBalances, TokenBalance{
+			TokenContractAddress: tokenContractAddress,
+			Balance:              service.FormatUnits(value, tokenDecimals),
+			Value:                formatHex(value),
+			Decimals:             &tokenDecimals,
+		})
+		values = append(values, value)
+	}
+
+	return tokenBalances, values, nil
+}
+
+// tokenValue - a balance in token units to the token's smallest unit, rounded to the nearest
+func tokenValue(balance float64, decimals int) (*big.Int, error) {
+	// Shortest text that reads back as the same float
+	balanceText := strconv.FormatFloat(balance, 'f', -1, 64)
+
+	scaled, ok := new(big.Rat).SetString(balanceText)
+	if ok == false {
+		return nil, errors.New("invalid balance " + balanceText)
+	}
+	scaled.Mul(scaled, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+
+	value, _ := new(big.Int).SetString(scaled.FloatString(0), 10)
+	return value, nil
+}
+
+// setTokenBalanceDecimals - add a token's decimals, left out until they've been read
+func setTokenBalanceDecimals(decimals map[string]int, tokenContractAddress string) {
+	if tokenDecimals, ok := getTokenDecimals(tokenContractAddress); ok {
+		decimals[tokenContractAddress] = tokenDecimals
+	}
+}
+
+// verifyTokenBalance - compare a balance exactly with the node's balanceOf at the block
+// Left unverified when no node has the state of the block
+func verifyTokenBalance(c *fiber.Ctx, address string, blockNumber int64, tokenBalance *TokenBalance, value *big.Int) {
+	nodeValue, err := service.IconNodeServiceGetTokenBalanceAt(
+		c.UserContext(),
+		tokenBalance.TokenContractAddress,
+		address,
+		blockNumber,
+	)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetAddressTokenBalances",
+			" Error=Could not verify balance TOKEN_CONTRACT_ADDRESS=", tokenBalance.TokenContractAddress,
+			" ERROR=", err.Error(),
+		)
+		return
+	}
+
+	nodeValueHex := formatHex(nodeValue)
+	verified := value.Cmp(nodeValue) == 0
+	tokenBalance.NodeValue = &nodeValueHex
+	tokenBalance.Verified = &verified
+}
+
+// formatHex - 0x prefixed hex of an amount, like the values on chain
+func formatHex(value *big.Int) string {
+	if value.Sign() < 0 {
+		return "-0x" + new(big.Int).Neg(value).Text(16)
+	}
+	return "0x" + value.Text(16)
+}
@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func TestNewTokenBalances(t *testing.T) {
+	assert := assert.New(t)
+
+	tokenBalances, values, err := newTokenBalances(
+		[]models.TokenAddress{
+			{TokenContractAddress: "cx2", Balance: 1.5, TokenStandard: "irc2"},
+			{TokenContractAddress: "cx1", Balance: 100, TokenStandard: "irc2"},
+			// Not an IRC2 token
+			{TokenContractAddress: "cx4", Balance: 1, TokenStandard: "irc3"},
+			// Decimals not known
+			{TokenContractAddress: "cx5", Balance: 2.5, TokenStandard: "irc2"},
+		},
+		[]crud.TokenBalanceChange{
+			// Received 30 after the block
+			{TokenContractAddress: "cx1", Change: "30", ChangeDecimal: 30},
+			// Received all of it after the block
+			{TokenContractAddress: "cx2", Change: "1500000000000000000", ChangeDecimal: 1.5},
+			// Sent all of it after the block
+			{TokenContractAddress: "cx3", Change: "-1000000000000000000000000001", ChangeDecimal: -1e9},
+			{TokenContractAddress: "cx5", Change: "-500", ChangeDecimal: -0.5},
+		},
+		map[string]int{"cx1": 0, "cx2": 18, "cx3": 18},
+	)
+	assert.Equal(nil, err)
+
+	zero, eighteen := 0, 18
+	expectedValue, _ := new(big.Int).SetString("1000000000000000000000000001", 10)
+	assert.Equal([]TokenBalance{
+		{TokenContractAddress: "cx1", Balance: "70", Value: "0x46", Decimals: &zero},
+		{TokenContractAddress: "cx3", Balance: "1000000000.000000000000000001", Value: "0x33b2e3c9fd0803ce8000001", Decimals: &eighteen},
+		{TokenContractAddress: "cx5", Balance: "3"},
+	}, tokenBalances)
+	assert.Equal([]*big.Int{big.NewInt(70), expectedValue, nil}, values)
+
+	_, _, err = newTokenBalances(nil, []crud.TokenBalanceChange{{TokenContractAddress: "cx1", Change: "1.5"}}, map[string]int{"cx1": 0})
+	assert.NotEqual(nil, err)
+}
+
+func TestTokenValue(t *testing.T) {
+	assert := assert.New(t)
+
+	value, err := tokenValue(0.1, 18)
+	assert.Equal(nil, err)
+	assert.Equal(big.NewInt(100000000000000000), value)
+
+	value, err = tokenValue(2.5, 0)
+	assert.Equal(nil, err)
+	assert.Equal(big.NewInt(3), value)
+}
+
+func TestFormatHex(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("0x0", formatHex(big.NewInt(0)))
+	assert.Equal("0xde0b6b3a7640000", formatHex(big.NewInt(1000000000000000000)))
+	assert.Equal("-0x7", formatHex(big.NewInt(-7)))
+}
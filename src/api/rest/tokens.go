@@ -0,0 +1,202 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+	"github.com/sudoblockio/icon-go-api/redis"
+	"github.com/sudoblockio/icon-go-api/stats"
+)
+
+func TokensAddHandlers(app *fiber.App) {
+	prefix := config.Config.RestPrefix + "/tokens"
+
+	app.Use(prefix, QueryTimeout(config.Config.RestQueryTimeoutTokens))
+
+	app.Get(prefix+"/:contract/holder-stats", handlerGetTokenHolderStats)
+}
+
+// TokenHolderStats - distribution of a token's balances over its holders
+type TokenHolderStats struct {
+	TokenContractAddress string  `json:"token_contract_address"`
+	HolderCount          int64   `json:"holder_count"`
+	TotalBalance         float64 `json:"total_balance"`
+
+	// Shares of the total balance held by the largest holders, 0 to 1
+	Top10Share  float64 `json:"top_10_share"`
+	Top100Share float64 `json:"top_100_share"`
+
+	// 0 when every holder has the same balance, towards 1 when one holder has it all
+	Gini float64 `json:"gini"`
+
+	Histogram []HolderBalanceBucket `json:"histogram"`
+
+	// Against the snapshots of the day before and a week before, left out without a snapshot
+	HolderCountChange24h *int64 `json:"holder_count_change_24h,omitempty"`
+	HolderCountChange7d  *int64 `json:"holder_count_change_7d,omitempty"`
+}
+
+// HolderBalanceBucket - holders with a balance at least min and below max, no max for the largest
+type HolderBalanceBucket struct {
+	Min     float64  `json:"min"`
+	Max     *float64 `json:"max,omitempty"`
+	Holders int64    `json:"holders"`
+}
+
+// Token Holder Stats
+// @Summary Get Token Holder Stats
+// @Description get the holder count, top 10 and top 100 holder shares, Gini coefficient and balance histogram of a token
+// @Description holder count changes are against the daily holder snapshots of the day before and a week before
+// @Description they are left out without snapshots, which are only taken by an instance running with STATS_WRITER_ENABLED
+// @Tags Tokens
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param contract path string true "token contract address"
+// @Router /api/v1/tokens/{contract}/holder-stats [get]
+// @Success 200 {object} TokenHolderStats
+// @Failure 422 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerGetTokenHolderStats(c *fiber.Ctx) error {
+	contract := c.Params("contract")
+	if contract == "" {
+		return errInvalidParameter("contract", "contract required")
+	}
+
+	holderStats, err := getCachedTokenHolderStats(c, contract)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errNotFound("token contract not found")
+		}
+		zap.S().Warn(
+			"Endpoint=handlerGetTokenHolderStats",
+			" Error=Could not retrieve token holder stats: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve token holder stats")
+	}
+
+	body, _ := json.Marshal(holderStats)
+	return c.SendString(string(body))
+}
+
+// getCachedTokenHolderStats - holder stats of a token, cached in redis per token
+func getCachedTokenHolderStats(c *fiber.Ctx, contract string) (*TokenHolderStats, error) {
+	key := config.Config.RedisKeyPrefix + "token_holder_stats_" + contract
+
+	cached, err := redis.GetRedisClient().GetCache(key)
+	if err != nil {
+		zap.S().Warn("Could not retrieve cached token holder stats: ", err.Error())
+	} else if cached != nil {
+		holderStats := &TokenHolderStats{}
+		err = json.Unmarshal(cached, holderStats)
+		if err == nil {
+			return holderStats, nil
+		}
+	}
+
+	_, err = crud.GetAddressCrud().SelectOne(c.UserContext(), contract)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenHolderStats, err := crud.GetTokenAddressCrud().SelectHolderStatsByTokenContractAddress(c.UserContext(), contract)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenHolderBuckets, err := crud.GetTokenAddressCrud().SelectHolderHistogramByTokenContractAddress(c.UserContext(), contract)
+	if err != nil {
+		return nil, err
+	}
+
+	holderStats := &TokenHolderStats{
+		TokenContractAddress: contract,
+		HolderCount:          tokenHolderStats.HolderCount,
+		TotalBalance:         tokenHolderStats.TotalBalance,
+		Top10Share:           tokenHolderStats.TopTenShare,
+		Top100Share:          tokenHolderStats.TopHundredShare,
+		Gini:                 tokenHolderStats.Gini,
+		Histogram:            holderBalanceHistogram(*tokenHolderBuckets),
+	}
+
+	today := time.Now().UTC()
+	dayBefore := today.AddDate(0, 0, -1).Format(stats.DateFormat)
+	weekBefore := today.AddDate(0, 0, -7).Format(stats.DateFormat)
+	tokenHolderSnapshots, err := crud.GetTokenHolderSnapshotCrud().SelectManyByDates(
+		c.UserContext(),
+		contract,
+		[]string{dayBefore, weekBefore},
+	)
+	if err != nil {
+		// Changes are left out, the rest of the stats don't need the snapshots
+		zap.S().Warn("Could not retrieve token holder snapshots: ", err.Error())
+	} else {
+		holderStats.setHolderCountChanges(*tokenHolderSnapshots, dayBefore, weekBefore)
+	}
+
+	cached, _ = json.Marshal(holderStats)
+	err = redis.GetRedisClient().SetCache(key, cached, config.Config.TokenHolderStatsCacheTTL)
+	if err != nil {
+		zap.S().Warn("Could not cache token holder stats: ", err.Error())
+	}
+
+	return holderStats, nil
+}
+
+// holderBalanceHistogram - every bucket up to the largest with holders, the largest has no max
+func holderBalanceHistogram(tokenHolderBuckets []crud.TokenHolderBucket) []HolderBalanceBucket {
+	histogram := []HolderBalanceBucket{}
+	if len(tokenHolderBuckets) == 0 {
+		return histogram
+	}
+
+	// Smallest first, so the last bucket sets the count
+	buckets := tokenHolderBuckets[len(tokenHolderBuckets)-1].Bucket + 1
+
+	for i := 0; i < buckets; i++ {
+		bucket := HolderBalanceBucket{}
+		if i > 0 {
+			bucket.Min = math.Pow10(i - 1)
+		}
+		if i < buckets-1 {
+			max := math.Pow10(i)
+			bucket.Max = &max
+		}
+		histogram = append(histogram, bucket)
+	}
+
+	for _, tokenHolderBucket := range tokenHolderBuckets {
+		histogram[tokenHolderBucket.Bucket].Holders = tokenHolderBucket.Holders
+	}
+
+	return histogram
+}
+
+// setHolderCountChanges - holder count changes against the snapshots of the day before and a week before
+func (s *TokenHolderStats) setHolderCountChanges(
+	tokenHolderSnapshots []models.TokenHolderSnapshot,
+	dayBefore string,
+	weekBefore string,
+) {
+	for _, tokenHolderSnapshot := range tokenHolderSnapshots {
+		change := s.HolderCount - tokenHolderSnapshot.HolderCount
+
+		switch tokenHolderSnapshot.Date {
+		case dayBefore:
+			s.HolderCountChange24h = &change
+		case weekBefore:
+			s.HolderCountChange7d = &change
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func TestHolderBalanceHistogram(t *testing.T) {
+	assert := assert.New(t)
+
+	// Buckets without holders are filled in
+	histogram := holderBalanceHistogram([]crud.TokenHolderBucket{
+		{Bucket: 0, Holders: 109},
+		{Bucket: 4, Holders: 1},
+	})
+	assert.Equal(5, len(histogram))
+	assert.Equal(0.0, histogram[0].Min)
+	assert.Equal(1.0, *histogram[0].Max)
+	assert.Equal(int64(109), histogram[0].Holders)
+	assert.Equal(10.0, histogram[2].Min)
+	assert.Equal(100.0, *histogram[2].Max)
+	assert.Equal(int64(0), histogram[2].Holders)
+	assert.Equal(1000.0, histogram[4].Min)
+	assert.Nil(histogram[4].Max)
+	assert.Equal(int64(1), histogram[4].Holders)
+
+	// No holders
+	histogram = holderBalanceHistogram([]crud.TokenHolderBucket{})
+	assert.Equal(0, len(histogram))
+}
+
+func TestSetHolderCountChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	holderStats := &TokenHolderStats{HolderCount: 100}
+	holderStats.setHolderCountChanges([]models.TokenHolderSnapshot{
+		{Date: "2022-06-29", HolderCount: 90},
+	}, "2022-06-29", "2022-06-23")
+
+	assert.Equal(int64(10), *holderStats.HolderCountChange24h)
+	assert.Nil(holderStats.HolderCountChange7d)
+}
@@ -3,7 +3,6 @@ package rest
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"strconv"
 	"sync"
 
@@ -20,6 +19,7 @@ import (
 type TransactionsQuery struct {
 	Limit                int    `query:"limit"`
 	Skip                 int    `query:"skip"`
+	Cursor               string `query:"cursor"`
 	From                 string `query:"from"`
 	To                   string `query:"to"`
 	Type                 string `query:"type"`
@@ -37,15 +37,17 @@ func TransactionsAddHandlers(app *fiber.App) {
 
 	prefix := config.Config.RestPrefix + "/transactions"
 
-	app.Get(prefix+"/", handlerGetTransactions)
+	app.Use(prefix, QueryTimeout(config.Config.RestQueryTimeoutTransactions))
+
+	app.Get(prefix+"/", ResponseCache(), handlerGetTransactions)
 	app.Get(prefix+"/details/:hash", handlerGetTransaction)
 	app.Get(prefix+"/icx/:address", handlerGetIcxTransactionsAddress)
-	app.Get(prefix+"/block-number/:block_number", handlerGetTransactionBlockNumber)
+	app.Get(prefix+"/block-number/:block_number", ResponseCacheByBlock("block_number"), handlerGetTransactionBlockNumber)
 	app.Get(prefix+"/address/:address", handlerGetTransactionAddress)
 	app.Get(prefix+"/internal/:hash", handlerGetInternalTransactionsByHash)
 	app.Get(prefix+"/internal/address/:address", handlerGetInternalTransactionsAddress)
-	app.Get(prefix+"/internal/block-number/:block_number", handlerGetInternalTransactionsBlockNumber)
-	app.Get(prefix+"/token-transfers", handlerGetTokenTransfers)
+	app.Get(prefix+"/internal/block-number/:block_number", ResponseCacheByBlock("block_number"), handlerGetInternalTransactionsBlockNumber)
+	app.Get(prefix+"/token-transfers", ResponseCache(), handlerGetTokenTransfers)
 	app.Get(prefix+"/token-transfers/address/:address", handlerGetTokenTransfersAddress)
 	app.Get(prefix+"/token-transfers/token-contract/:token_contract_address", handlerGetTokenTransfersTokenContract)
 	app.Get(prefix+"/token-holders/token-contract/:token_contract_address", handlerGetTokenAddressesTokenContract)
@@ -57,7 +59,7 @@ type TransactionResult struct {
 }
 
 type TransactionCountResult struct {
-	Val int64
+	Val *crud.Count
 	Err error
 }
 
@@ -70,6 +72,7 @@ type TransactionCountResult struct {
 // @Produce json
 // @Param limit query int false "amount of records"
 // @Param skip query int false "skip to a record"
+// @Param cursor query string false "cursor from the X-NEXT-CURSOR header of the previous page with the same sort, invalid with skip"
 // @Param from query string false "find by from address"
 // @Param to query string false "find by to address"
 // @Param type query string false "find by type"
@@ -81,15 +84,16 @@ type TransactionCountResult struct {
 // @Router /api/v1/transactions [get]
 // @Success 200 {object} []models.TransactionList
 // @Success 200 {string} string "CSV Response"
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetTransactions(c *fiber.Ctx) error {
 	params := new(TransactionsQuery)
 	err := c.QueryParser(params)
 	if err != nil {
 		zap.S().Warnf("Transactions Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -101,18 +105,29 @@ func handlerGetTransactions(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 	if params.Sort != "desc" && params.Sort != "asc" {
 		params.Sort = "desc"
 	}
 
+	// Cursor
+	var cursor *crud.Cursor
+	if params.Cursor != "" {
+		if params.Skip != 0 {
+			return errInvalidParameter("cursor", "can't supply both skip and cursor")
+		}
+
+		cursor, err = crud.DecodeCursor(params.Cursor)
+		if err != nil {
+			return errInvalidParameter("cursor", "invalid cursor")
+		}
+		if cursor.Sort != params.Sort {
+			return errInvalidParameter("cursor", "cursor is for a different sort")
+		}
+	}
+
 	// NOTE: casting string types for type field
 	if params.Type == "regular" || params.Type == "" {
 		params.Type = "transaction"
@@ -121,42 +136,40 @@ func handlerGetTransactions(c *fiber.Ctx) error {
 	}
 
 	var wg sync.WaitGroup
-	transactionResultChan := make(chan TransactionResult)
-	transactionCountResultChan := make(chan TransactionCountResult)
+	transactionResultChan := make(chan TransactionResult, 1)
+	transactionCountResultChan := make(chan TransactionCountResult, 1)
 
-	var count int64
-	if params.From == "" && params.To == "" && params.BlockNumber == 0 && params.StartBlockNumber == 0 && params.Method == "" {
+	if params.From == "" && params.To == "" && params.Type == "transaction" &&
+		params.BlockNumber == 0 && params.StartBlockNumber == 0 && params.EndBlockNumber == 0 && params.Method == "" {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			count, err = GetRedisCount("transaction_regular_count")
+			count, err := GetRedisCount("transaction_regular_count")
 			if err != nil {
 				zap.S().Warn("Could not retrieve transaction count: ", err.Error())
 			}
-			transactionCountResultChan <- TransactionCountResult{Val: count, Err: err}
+			transactionCountResultChan <- TransactionCountResult{Val: &crud.Count{Count: count, Exact: true}, Err: err}
 		}()
 	} else {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			transactionCount, err := crud.GetTransactionCrud().CountMany(
-				params.From,
-				params.To,
-				params.Type,
-				params.BlockNumber,
-				params.StartBlockNumber,
-				params.EndBlockNumber,
-				params.Method,
-			)
+			transactionCount, err := GetCachedCount(c, func() (*crud.Count, error) {
+				return crud.GetTransactionCrud().CountMany(
+					c.UserContext(),
+					params.From,
+					params.To,
+					params.Type,
+					params.BlockNumber,
+					params.StartBlockNumber,
+					params.EndBlockNumber,
+					params.Method,
+				)
+			})
 			if err != nil {
-				count, err = GetRedisCount("transaction_regular_count")
-				if err != nil {
-					zap.S().Warn("Could not retrieve transaction count: ", err.Error())
-				}
-				transactionCountResultChan <- TransactionCountResult{Val: count, Err: err}
-				return
+				zap.S().Warn("Could not retrieve transaction count: ", err.Error())
 			}
-			transactionCountResultChan <- TransactionCountResult{Val: *transactionCount, Err: err}
+			transactionCountResultChan <- TransactionCountResult{Val: transactionCount, Err: err}
 		}()
 	}
 
@@ -165,8 +178,10 @@ func handlerGetTransactions(c *fiber.Ctx) error {
 	go func() {
 		defer wg.Done()
 		transactions, err := crud.GetTransactionCrud().SelectMany(
+			c.UserContext(),
 			params.Limit,
 			params.Skip,
+			cursor,
 			params.From,
 			params.To,
 			params.Type,
@@ -179,18 +194,17 @@ func handlerGetTransactions(c *fiber.Ctx) error {
 		transactionResultChan <- TransactionResult{Val: transactions, Err: err}
 	}()
 
+	// Both use the request, wait for both before responding
+	transactionResult := <-transactionResultChan
 	transactionCountResult := <-transactionCountResultChan
-	if transactionCountResult.Err != nil {
-		c.Status(500)
+
+	if transactionResult.Err != nil {
 		zap.S().Warn(
 			"Endpoint=handlerGetTransactions",
-			" Error=Could not retrieve transactions: ", transactionCountResult.Err,
+			" Error=Could not retrieve transactions: ", transactionResult.Err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve transactions"}`)
+		return errQuery(transactionResult.Err, "could not retrieve transactions")
 	}
-	count = transactionCountResult.Val
-
-	transactionResult := <-transactionResultChan
 	transactions := transactionResult.Val
 
 	if len(*transactions) == 0 {
@@ -198,7 +212,23 @@ func handlerGetTransactions(c *fiber.Ctx) error {
 		c.Status(204)
 	}
 
-	c.Append("X-TOTAL-COUNT", strconv.FormatInt(count, 10))
+	// Set X-TOTAL-COUNT
+	// Left out rather than the unfiltered count when the filtered count fails
+	if transactionCountResult.Err == nil {
+		setTotalCount(c, transactionCountResult.Val)
+	}
+
+	// Set X-NEXT-CURSOR
+	if len(*transactions) == params.Limit {
+		lastTransaction := (*transactions)[len(*transactions)-1]
+		nextCursor := &crud.Cursor{
+			BlockNumber:      lastTransaction.BlockNumber,
+			TransactionIndex: lastTransaction.TransactionIndex,
+			LogIndex:         lastTransaction.LogIndex,
+			Sort:             params.Sort,
+		}
+		c.Append("X-NEXT-CURSOR", nextCursor.Encode())
+	}
 
 	if c.Get("Accept") == "text/csv" {
 		return respondWithCSV(c, *transactions)
@@ -207,7 +237,7 @@ func handlerGetTransactions(c *fiber.Ctx) error {
 	// Continue with JSON response if not CSV
 	body, err := json.Marshal(&transactions)
 	if err != nil {
-		return c.SendString(`{"error": "parsing error"}`)
+		return errInternal("could not encode response")
 	}
 
 	return c.SendString(string(body))
@@ -223,24 +253,24 @@ func handlerGetTransactions(c *fiber.Ctx) error {
 // @Param hash path string true "transaction hash"
 // @Router /api/v1/transactions/details/{hash} [get]
 // @Success 200 {object} models.Transaction
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetTransaction(c *fiber.Ctx) error {
 	hash := c.Params("hash")
 
 	if hash == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "hash required"}`)
+		return errInvalidParameter("hash", "hash required")
 	}
 
-	transaction, err := crud.GetTransactionCrud().SelectOne(hash, -1)
+	transaction, err := crud.GetTransactionCrud().SelectOne(c.UserContext(), hash, -1)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.Status(404)
-			return c.SendString(`{"error": "transaction not found"}`)
+			return errNotFound("transaction not found")
 		}
-		c.Status(500)
 		zap.S().Warn(err.Error())
-		return c.SendString(`{"error": "could not retrieve transaction"}`)
+		return errQuery(err, "could not retrieve transaction")
 	}
 
 	body, _ := json.Marshal(&transaction)
@@ -259,20 +289,20 @@ func handlerGetTransaction(c *fiber.Ctx) error {
 // @Param address path string true "address"
 // @Router /api/v1/transactions/icx/{address} [get]
 // @Success 200 {object} []models.Transaction
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetIcxTransactionsAddress(c *fiber.Ctx) error {
 	address := c.Params("address")
 	if address == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "address required"}`)
+		return errInvalidParameter("address", "address required")
 	}
 
 	params := new(TransactionsQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Transactions Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -281,33 +311,27 @@ func handlerGetIcxTransactionsAddress(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 
 	transactions, err := crud.GetTransactionCrud().SelectManyIcxByAddress(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
 		address,
 	)
 	if err != nil {
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetTransactionAddress",
 			" Error=Could not retrieve transactions: ", err.Error(),
 		)
-		return c.SendString(`{"error": "no transactions found"}`)
+		return errQuery(err, "no transactions found")
 	}
 
-	count, err := crud.GetTransactionCrud().CountManyIcxByAddress(address)
+	count, err := crud.GetTransactionCrud().CountManyIcxByAddress(c.UserContext(), address)
 	if err != nil {
-		c.Status(500)
-		return c.SendString(`{"error": "count server error"}`)
+		return errQuery(err, "count server error")
 	}
 	c.Append("X-TOTAL-COUNT", strconv.FormatInt(count, 10))
 
@@ -318,7 +342,7 @@ func handlerGetIcxTransactionsAddress(c *fiber.Ctx) error {
 	// Continue with JSON response if not CSV
 	body, err := json.Marshal(transactions)
 	if err != nil {
-		return c.SendString(`{"error": "parsing error"}`)
+		return errInternal("could not encode response")
 	}
 
 	return c.SendString(string(body))
@@ -336,26 +360,25 @@ func handlerGetIcxTransactionsAddress(c *fiber.Ctx) error {
 // @Param block_number path string true "block_number"
 // @Router /api/v1/transactions/block-number/{block_number} [get]
 // @Success 200 {object} models.TransactionList
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetTransactionBlockNumber(c *fiber.Ctx) error {
 	blockNumberRaw := c.Params("block_number")
 	if blockNumberRaw == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "block_number required"}`)
+		return errInvalidParameter("block_number", "block_number required")
 	}
 
 	blockNumber, err := strconv.Atoi(blockNumberRaw)
 	if err != nil {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid block_number"}`)
+		return errInvalidParameter("block_number", "invalid block_number")
 	}
 
 	params := new(TransactionsQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Transactions Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -364,19 +387,16 @@ func handlerGetTransactionBlockNumber(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 
 	// Get Transactions
 	transactions, err := crud.GetTransactionCrud().SelectMany(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
+		nil,
 		"",
 		"",
 		"transaction",
@@ -387,16 +407,15 @@ func handlerGetTransactionBlockNumber(c *fiber.Ctx) error {
 		"desc",
 	)
 	if err != nil {
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetTransactionBlockNumber",
 			" Error=Could not retrieve transactions: ", err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve transactions"}`)
+		return errQuery(err, "could not retrieve transactions")
 	}
 
 	// X-TOTAL-COUNT
-	block, err := crud.GetBlockCrud().SelectOne(uint32(blockNumber))
+	block, err := crud.GetBlockCrud().SelectOne(c.UserContext(), uint32(blockNumber))
 	count := int64(0)
 	if err != nil {
 		zap.S().Warn("Could not retrieve transaction count: ", err.Error())
@@ -421,18 +440,19 @@ func handlerGetTransactionBlockNumber(c *fiber.Ctx) error {
 // @Param address path string true "address"
 // @Router /api/v1/transactions/address/{address} [get]
 // @Success 200 {object} models.TransactionList
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetTransactionAddress(c *fiber.Ctx) error {
 	address := c.Params("address")
 	if address == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "address required"}`)
+		return errInvalidParameter("address", "address required")
 	}
 
 	params := new(SkipLimitQuery)
 	if err := c.QueryParser(params); err != nil {
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -441,30 +461,24 @@ func handlerGetTransactionAddress(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 
 	transactions, err := crud.GetTransactionCrud().SelectManyByAddress(
+		c.UserContext(),
 		params.Limit, params.Skip, address,
 	)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.Status(404)
-			return c.SendString(`{"error": "no transactions found"}`)
+			return errNotFound("no transactions found")
 		}
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetTransactionAddress",
 			" Error=Could not retrieve transactions: ",
 			err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve transactions"}`)
+		return errQuery(err, "could not retrieve transactions")
 	}
 
 	// X-TOTAL-COUNT
@@ -491,20 +505,20 @@ func handlerGetTransactionAddress(c *fiber.Ctx) error {
 // @Param hash path string true "find by hash"
 // @Router /api/v1/transactions/internal/{hash} [get]
 // @Success 200 {object} []models.TransactionInternalList
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetInternalTransactionsByHash(c *fiber.Ctx) error {
 	hash := c.Params("hash")
 	if hash == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "hash required"}`)
+		return errInvalidParameter("hash", "hash required")
 	}
 
 	params := new(TransactionsQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Transactions Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -513,33 +527,27 @@ func handlerGetInternalTransactionsByHash(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 
 	if hash == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "hash required"}`)
+		return errInvalidParameter("hash", "hash required")
 	}
 
 	internalTransactions, err := crud.GetTransactionCrud().SelectManyInternal(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
 		hash,
 		0,
 	)
 	if err != nil {
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetInternalTransactionsByHash",
 			" Error=Could not retrieve transactions: ", err.Error(),
 		)
-		return c.SendString(`{"error": "no internal transaction found"}`)
+		return errQuery(err, "could not retrieve internal transactions")
 	}
 
 	if len(*internalTransactions) == 0 {
@@ -563,20 +571,20 @@ func handlerGetInternalTransactionsByHash(c *fiber.Ctx) error {
 // @Param address path string true "find by address"
 // @Router /api/v1/transactions/internal/address/{address} [get]
 // @Success 200 {object} []models.TransactionInternalList
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetInternalTransactionsAddress(c *fiber.Ctx) error {
 	address := c.Params("address")
 	if address == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "address required"}`)
+		return errInvalidParameter("address", "address required")
 	}
 
 	params := new(TransactionsQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Transactions Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -585,27 +593,22 @@ func handlerGetInternalTransactionsAddress(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(fmt.Sprintf(`{"error": "invalid skip, must be less than %d"}`, config.Config.MaxPageSkip))
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 
 	internalTransactions, err := crud.GetTransactionCrud().SelectManyInternalByAddress(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
 		address,
 	)
 	if err != nil {
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetInternalTransactionsAddress",
 			" Error=Could not retrieve transactions: ", err.Error(),
 		)
-		return c.SendString(`{"error": "no internal transaction found"}`)
+		return errQuery(err, "could not retrieve internal transactions")
 	}
 
 	if len(*internalTransactions) == 0 {
@@ -637,22 +640,25 @@ func handlerGetInternalTransactionsAddress(c *fiber.Ctx) error {
 // @Param block_number path string true "block_number"
 // @Router /api/v1/transactions/internal/block-number/{block_number} [get]
 // @Success 200 {object} []models.TransactionInternalList
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetInternalTransactionsBlockNumber(c *fiber.Ctx) error {
 	blockNumberRaw := c.Params("block_number")
 	if blockNumberRaw == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "block number required"}`)
+		return errInvalidParameter("block_number", "block number required")
 	}
 
 	blockNumber, err := strconv.Atoi(blockNumberRaw)
+	if err != nil {
+		return errInvalidParameter("block_number", "invalid block number")
+	}
 
 	params := new(TransactionsQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Transactions Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -661,28 +667,23 @@ func handlerGetInternalTransactionsBlockNumber(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 
 	internalTransactions, err := crud.GetTransactionCrud().SelectManyInternal(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
 		"",
 		blockNumber,
 	)
 	if err != nil {
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetInternalTransactionsBlock",
 			" Error=Could not retrieve transactions: ", err.Error(),
 		)
-		return c.SendString(`{"error": "no internal transaction found"}`)
+		return errQuery(err, "could not retrieve internal transactions")
 	}
 
 	if len(*internalTransactions) == 0 {
@@ -691,7 +692,7 @@ func handlerGetInternalTransactionsBlockNumber(c *fiber.Ctx) error {
 	}
 
 	// X-TOTAL-COUNT
-	block, err := crud.GetBlockCrud().SelectOne(uint32(blockNumber))
+	block, err := crud.GetBlockCrud().SelectOne(c.UserContext(), uint32(blockNumber))
 	count := int64(0)
 	if err != nil {
 		zap.S().Warn("Could not retrieve transaction count: ", err.Error())
@@ -713,6 +714,7 @@ func handlerGetInternalTransactionsBlockNumber(c *fiber.Ctx) error {
 // @Produce json
 // @Param limit query int false "amount of records"
 // @Param skip query int false "skip to a record"
+// @Param cursor query string false "cursor from the X-NEXT-CURSOR header of the previous page, invalid with skip"
 // @Param from query string false "find by from address"
 // @Param to query string false "find by to address"
 // @Param block_number query int false "find by block number"
@@ -722,14 +724,15 @@ func handlerGetInternalTransactionsBlockNumber(c *fiber.Ctx) error {
 // @Param transaction_hash query string false "find by transaction hash"
 // @Router /api/v1/transactions/token-transfers [get]
 // @Success 200 {object} []models.TokenTransfer
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetTokenTransfers(c *fiber.Ctx) error {
 	params := new(TransactionsQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Transactions Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -738,19 +741,33 @@ func handlerGetTokenTransfers(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+
+	// Cursor
+	var cursor *crud.Cursor
+	if params.Cursor != "" {
+		if params.Skip != 0 {
+			return errInvalidParameter("cursor", "can't supply both skip and cursor")
+		}
+
+		var err error
+		cursor, err = crud.DecodeCursor(params.Cursor)
+		if err != nil {
+			return errInvalidParameter("cursor", "invalid cursor")
+		}
+		if cursor.Sort != "desc" {
+			return errInvalidParameter("cursor", "cursor is for a different sort")
+		}
 	}
 
 	// Get Transactions
 	tokenTransfers, err := crud.GetTokenTransferCrud().SelectMany(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
+		cursor,
 		params.From,
 		params.To,
 		params.BlockNumber,
@@ -758,14 +775,14 @@ func handlerGetTokenTransfers(c *fiber.Ctx) error {
 		params.EndBlockNumber,
 		params.TransactionHash,
 		params.TokenContractAddress,
+		"desc",
 	)
 	if err != nil {
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetTokenTransfers",
 			" Error=Could not retrieve token transfers: ", err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve transactions"}`)
+		return errQuery(err, "could not retrieve transactions")
 	}
 
 	if len(*tokenTransfers) == 0 {
@@ -781,6 +798,18 @@ func handlerGetTokenTransfers(c *fiber.Ctx) error {
 	}
 	c.Append("X-TOTAL-COUNT", strconv.FormatInt(count, 10))
 
+	// Set X-NEXT-CURSOR
+	if len(*tokenTransfers) == params.Limit {
+		lastTokenTransfer := (*tokenTransfers)[len(*tokenTransfers)-1]
+		nextCursor := &crud.Cursor{
+			BlockNumber:      lastTokenTransfer.BlockNumber,
+			TransactionIndex: lastTokenTransfer.TransactionIndex,
+			LogIndex:         lastTokenTransfer.LogIndex,
+			Sort:             "desc",
+		}
+		c.Append("X-NEXT-CURSOR", nextCursor.Encode())
+	}
+
 	if c.Get("Accept") == "text/csv" {
 		return respondWithCSV(c, *tokenTransfers)
 	}
@@ -788,7 +817,7 @@ func handlerGetTokenTransfers(c *fiber.Ctx) error {
 	// Continue with JSON response if not CSV
 	body, err := json.Marshal(&tokenTransfers)
 	if err != nil {
-		return c.SendString(`{"error": "parsing error"}`)
+		return errInternal("could not encode response")
 	}
 
 	return c.SendString(string(body))
@@ -806,20 +835,20 @@ func handlerGetTokenTransfers(c *fiber.Ctx) error {
 // @Param address path string true "find by address"
 // @Router /api/v1/transactions/token-transfers/address/{address} [get]
 // @Success 200 {object} []models.TokenTransfer
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetTokenTransfersAddress(c *fiber.Ctx) error {
 	address := c.Params("address")
 	if address == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "address required"}`)
+		return errInvalidParameter("address", "address required")
 	}
 
 	params := new(SkipLimitQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Transactions Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -828,28 +857,23 @@ func handlerGetTokenTransfersAddress(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 
 	// Get Transactions
 	tokenTransfers, err := crud.GetTokenTransferCrud().SelectManyByAddress(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
 		address,
 	)
 	if err != nil {
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetTokenTransfersAddress",
 			" Error=Could not retrieve token transfers: ", err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve transactions"}`)
+		return errQuery(err, "could not retrieve transactions")
 	}
 
 	if len(*tokenTransfers) == 0 {
@@ -881,20 +905,20 @@ func handlerGetTokenTransfersAddress(c *fiber.Ctx) error {
 // @Param token_contract_address path string true "find by token contract address"
 // @Router /api/v1/transactions/token-transfers/token-contract/{token_contract_address} [get]
 // @Success 200 {object} []models.TokenTransfer
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetTokenTransfersTokenContract(c *fiber.Ctx) error {
 	tokenContractAddress := c.Params("token_contract_address")
 	if tokenContractAddress == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "token_contract_address required"}`)
+		return errInvalidParameter("token_contract_address", "token_contract_address required")
 	}
 
 	params := new(TransactionsQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Transactions Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -903,28 +927,23 @@ func handlerGetTokenTransfersTokenContract(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 
 	// Get Transactions
 	tokenTransfers, err := crud.GetTokenTransferCrud().SelectManyByTokenContractAddress(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
 		tokenContractAddress,
 	)
 	if err != nil {
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetTokenTransfersTokenContract",
 			" Error=Could not retrieve token transfers: ", err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve transactions"}`)
+		return errQuery(err, "could not retrieve transactions")
 	}
 
 	if len(*tokenTransfers) == 0 {
@@ -956,20 +975,20 @@ func handlerGetTokenTransfersTokenContract(c *fiber.Ctx) error {
 // @Param token_contract_address path string true "find by token contract address"
 // @Router /api/v1/transactions/token-holders/token-contract/{token_contract_address} [get]
 // @Success 200 {object} []models.TokenAddress
-// @Failure 422 {object} map[string]interface{}
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
 func handlerGetTokenAddressesTokenContract(c *fiber.Ctx) error {
 	tokenContractAddress := c.Params("token_contract_address")
 	if tokenContractAddress == "" {
-		c.Status(422)
-		return c.SendString(`{"error": "token_contract_address required"}`)
+		return errInvalidParameter("token_contract_address", "token_contract_address required")
 	}
 
 	params := new(TransactionsQuery)
 	if err := c.QueryParser(params); err != nil {
 		zap.S().Warnf("Transactions Get Handler ERROR: %s", err.Error())
 
-		c.Status(422)
-		return c.SendString(`{"error": "could not parse query parameters"}`)
+		return errInvalidQuery(err)
 	}
 
 	// Default Params
@@ -978,28 +997,23 @@ func handlerGetTokenAddressesTokenContract(c *fiber.Ctx) error {
 	}
 
 	// Check Params
-	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
-		c.Status(422)
-		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
-	}
-	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
-		c.Status(422)
-		return c.SendString(`{"error": "invalid skip"}`)
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
 	}
 
 	// Get Transactions
 	tokenAddresses, err := crud.GetTokenAddressCrud().SelectManyByTokenContractAddress(
+		c.UserContext(),
 		params.Limit,
 		params.Skip,
 		tokenContractAddress,
 	)
 	if err != nil {
-		c.Status(500)
 		zap.S().Warn(
 			"Endpoint=handlerGetTokenAddressesTokenContract",
 			" Error=Could not retrieve token transfers: ", err.Error(),
 		)
-		return c.SendString(`{"error": "could not retrieve transactions"}`)
+		return errQuery(err, "could not retrieve transactions")
 	}
 
 	if len(*tokenAddresses) == 0 {
@@ -1009,6 +1023,7 @@ func handlerGetTokenAddressesTokenContract(c *fiber.Ctx) error {
 
 	// Get Transactions
 	count, err := crud.GetTokenAddressCrud().CountBy(
+		c.UserContext(),
 		"",
 		tokenContractAddress,
 	)
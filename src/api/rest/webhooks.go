@@ -0,0 +1,372 @@
+package rest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+	"github.com/sudoblockio/icon-go-api/webhooks"
+)
+
+type WebhookBody struct {
+	Url       string `json:"url"`
+	EventType string `json:"event_type"`
+	Address   string `json:"address"`
+	Contract  string `json:"contract"`
+	Method    string `json:"method"`
+}
+
+type WebhooksQuery struct {
+	Limit     int    `query:"limit"`
+	Skip      int    `query:"skip"`
+	EventType string `query:"event_type"`
+}
+
+type WebhookDeliveriesQuery struct {
+	Limit  int    `query:"limit"`
+	Skip   int    `query:"skip"`
+	Status string `query:"status"`
+}
+
+// WebhooksAddHandlers - add webhooks endpoints to fiber router
+func WebhooksAddHandlers(app *fiber.App) {
+
+	prefix := config.Config.RestPrefix + "/webhooks"
+
+	app.Use(prefix, handlerAdminAuth)
+	app.Use(prefix, QueryTimeout(config.Config.RestQueryTimeoutWebhooks))
+
+	app.Post(prefix+"/", handlerCreateWebhook)
+	app.Get(prefix+"/", handlerGetWebhooks)
+	app.Get(prefix+"/:id", handlerGetWebhook)
+	app.Delete(prefix+"/:id", handlerDeleteWebhook)
+	app.Get(prefix+"/:id/deliveries", handlerGetWebhookDeliveries)
+	app.Get(prefix+"/:id/dead-letters", handlerGetWebhookDeadLetters)
+}
+
+// Create Webhook
+// @Summary Create Webhook
+// @Description register a url to receive events as signed POST requests. Requires the X-ADMIN-KEY header. Urls resolving to loopback, private or link-local addresses are rejected. The secret is only returned here. Each delivery has an X-Webhook-Signature header of "sha256=" + hex HMAC-SHA256 of "<X-Webhook-Timestamp>.<body>" keyed with the secret.
+// @Tags Webhooks
+// @BasePath /api/v1
+// @Accept json
+// @Produce json
+// @Param X-ADMIN-KEY header string true "admin key"
+// @Param webhook body WebhookBody true "url, event_type (blocks, transactions, logs, token_transfers) and optional address, contract, and method filters"
+// @Router /api/v1/webhooks [post]
+// @Success 201 {object} models.Webhook
+// @Failure 401 {object} APIError
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerCreateWebhook(c *fiber.Ctx) error {
+	body := new(WebhookBody)
+	if err := c.BodyParser(body); err != nil {
+		zap.S().Warnf("Webhooks Create Handler ERROR: %s", err.Error())
+
+		return errInvalidBody(err)
+	}
+
+	// Check body
+	// Urls resolving inside the network are rejected so webhooks can't be used to reach internal services
+	err := webhooks.ValidateURL(c.UserContext(), body.Url)
+	if err != nil {
+		return errInvalidBody(err)
+	}
+
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return errInternal("could not create secret")
+	}
+
+	webhook := &models.Webhook{
+		Url:              body.Url,
+		EventType:        body.EventType,
+		Address:          body.Address,
+		Contract:         body.Contract,
+		Method:           body.Method,
+		Secret:           hex.EncodeToString(secret),
+		CreatedTimestamp: time.Now().Unix(),
+	}
+
+	err = webhooks.ValidateWebhook(webhook)
+	if err != nil {
+		return errInvalidBody(err)
+	}
+
+	err = crud.GetWebhookCrud().InsertOne(c.UserContext(), webhook)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerCreateWebhook",
+			" Error=Could not create webhook: ", err.Error(),
+		)
+		return errQuery(err, "could not create webhook")
+	}
+
+	// Start dispatching to it on this replica, others pick it up on refresh
+	webhooks.Refresh()
+
+	c.Status(201)
+	responseBody, _ := json.Marshal(webhook)
+	return c.Send(responseBody)
+}
+
+// Webhooks
+// @Summary Get Webhooks
+// @Description get list of webhooks. Requires the X-ADMIN-KEY header.
+// @Tags Webhooks
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param X-ADMIN-KEY header string true "admin key"
+// @Param limit query int false "amount of records"
+// @Param skip query int false "skip to a record"
+// @Param event_type query string false "find by event type"
+// @Router /api/v1/webhooks [get]
+// @Success 200 {object} []models.Webhook
+// @Failure 401 {object} APIError
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerGetWebhooks(c *fiber.Ctx) error {
+	params := new(WebhooksQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Webhooks Get Handler ERROR: %s", err.Error())
+
+		return errInvalidQuery(err)
+	}
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 25
+	}
+
+	// Check Params
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
+	}
+
+	webhookList, err := crud.GetWebhookCrud().SelectMany(
+		c.UserContext(),
+		params.Limit,
+		params.Skip,
+		params.EventType,
+	)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=handlerGetWebhooks",
+			" Error=Could not retrieve webhooks: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve webhooks")
+	}
+
+	if len(*webhookList) == 0 {
+		// No Content
+		c.Status(204)
+	}
+
+	// Secrets are only returned on create
+	for i := range *webhookList {
+		(*webhookList)[i].Secret = ""
+	}
+
+	body, _ := json.Marshal(webhookList)
+	return c.Send(body)
+}
+
+// Webhook
+// @Summary Get Webhook
+// @Description get details of a webhook. Requires the X-ADMIN-KEY header.
+// @Tags Webhooks
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param X-ADMIN-KEY header string true "admin key"
+// @Param id path int true "webhook id"
+// @Router /api/v1/webhooks/{id} [get]
+// @Success 200 {object} models.Webhook
+// @Failure 401 {object} APIError
+// @Failure 422 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerGetWebhook(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return errInvalidParameter("id", "invalid id")
+	}
+
+	webhook, err := crud.GetWebhookCrud().SelectOne(c.UserContext(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errNotFound("webhook not found")
+		}
+		zap.S().Warn(
+			"Endpoint=handlerGetWebhook",
+			" Error=Could not retrieve webhook: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve webhook")
+	}
+
+	// Secrets are only returned on create
+	webhook.Secret = ""
+
+	body, _ := json.Marshal(webhook)
+	return c.Send(body)
+}
+
+// Delete Webhook
+// @Summary Delete Webhook
+// @Description delete a webhook and its deliveries. Requires the X-ADMIN-KEY header.
+// @Tags Webhooks
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param X-ADMIN-KEY header string true "admin key"
+// @Param id path int true "webhook id"
+// @Router /api/v1/webhooks/{id} [delete]
+// @Success 204
+// @Failure 401 {object} APIError
+// @Failure 422 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 504 {object} APIError
+func handlerDeleteWebhook(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return errInvalidParameter("id", "invalid id")
+	}
+
+	err = crud.GetWebhookCrud().DeleteOne(c.UserContext(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errNotFound("webhook not found")
+		}
+		zap.S().Warn(
+			"Endpoint=handlerDeleteWebhook",
+			" Error=Could not delete webhook: ", err.Error(),
+		)
+		return errQuery(err, "could not delete webhook")
+	}
+
+	// Stop dispatching to it on this replica, others pick it up on refresh
+	webhooks.Refresh()
+
+	return c.SendStatus(204)
+}
+
+// Webhook Deliveries
+// @Summary Get Webhook Deliveries
+// @Description get the delivery log of a webhook, latest first. Requires the X-ADMIN-KEY header.
+// @Tags Webhooks
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param X-ADMIN-KEY header string true "admin key"
+// @Param id path int true "webhook id"
+// @Param limit query int false "amount of records"
+// @Param skip query int false "skip to a record"
+// @Param status query string false "one of pending, delivered, dead"
+// @Router /api/v1/webhooks/{id}/deliveries [get]
+// @Success 200 {object} []models.WebhookDelivery
+// @Failure 401 {object} APIError
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+func handlerGetWebhookDeliveries(c *fiber.Ctx) error {
+	return getWebhookDeliveries(c, "")
+}
+
+// Webhook Dead Letters
+// @Summary Get Webhook Dead Letters
+// @Description get deliveries of a webhook that failed every attempt, latest first. Requires the X-ADMIN-KEY header.
+// @Tags Webhooks
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param X-ADMIN-KEY header string true "admin key"
+// @Param id path int true "webhook id"
+// @Param limit query int false "amount of records"
+// @Param skip query int false "skip to a record"
+// @Router /api/v1/webhooks/{id}/dead-letters [get]
+// @Success 200 {object} []models.WebhookDelivery
+// @Failure 401 {object} APIError
+// @Failure 422 {object} APIError
+// @Failure 500 {object} APIError
+func handlerGetWebhookDeadLetters(c *fiber.Ctx) error {
+	return getWebhookDeliveries(c, crud.WebhookDeliveryStatusDead)
+}
+
+func getWebhookDeliveries(c *fiber.Ctx, status string) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return errInvalidParameter("id", "invalid id")
+	}
+
+	params := new(WebhookDeliveriesQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Webhook Deliveries Get Handler ERROR: %s", err.Error())
+
+		return errInvalidQuery(err)
+	}
+	if status != "" {
+		params.Status = status
+	}
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 25
+	}
+
+	// Check Params
+	if err := checkPaging(params.Limit, params.Skip); err != nil {
+		return err
+	}
+	if params.Status != "" &&
+		params.Status != crud.WebhookDeliveryStatusPending &&
+		params.Status != crud.WebhookDeliveryStatusDelivered &&
+		params.Status != crud.WebhookDeliveryStatusDead {
+		return errInvalidParameter("status", "status must be one of pending, delivered, dead")
+	}
+
+	webhookDeliveries, err := crud.GetWebhookDeliveryCrud().SelectMany(
+		c.UserContext(),
+		params.Limit,
+		params.Skip,
+		id,
+		params.Status,
+	)
+	if err != nil {
+		zap.S().Warn(
+			"Endpoint=getWebhookDeliveries",
+			" Error=Could not retrieve webhook deliveries: ", err.Error(),
+		)
+		return errQuery(err, "could not retrieve webhook deliveries")
+	}
+
+	if len(*webhookDeliveries) == 0 {
+		// No Content
+		c.Status(204)
+	}
+
+	// X-TOTAL-COUNT
+	count, err := crud.GetWebhookDeliveryCrud().CountBy(c.UserContext(), id, params.Status)
+	if err != nil {
+		count = 0
+		zap.S().Warn("Could not retrieve webhook delivery count: ", err.Error())
+	}
+	c.Append("X-TOTAL-COUNT", strconv.FormatInt(count, 10))
+
+	body, _ := json.Marshal(webhookDeliveries)
+	return c.Send(body)
+}
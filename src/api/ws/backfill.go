@@ -0,0 +1,291 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+
+	"github.com/gofiber/websocket/v2"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+)
+
+// backfillKey - identity of a row, shared by stored rows and live messages
+type backfillKey struct {
+	BlockNumber      int64
+	TransactionIndex int64
+	TransactionHash  string
+	LogIndex         int64
+}
+
+// backfillRow - a stored row serialized as a websocket message
+type backfillRow struct {
+	key backfillKey
+	msg []byte
+}
+
+// backfillSelectPage - select stored rows after the cursor, oldest first
+// Returns the rows and the cursor of the last row
+type backfillSelectPage func(fromBlock int64, cursor *crud.Cursor, filter *Filter) ([]backfillRow, *crud.Cursor, error)
+
+// backfillChannel - how to backfill and identify the messages of a redis channel
+type backfillChannel struct {
+	selectPage backfillSelectPage
+
+	// Live logs have no transaction index and are identified by transaction hash instead
+	keyedByTransactionHash bool
+}
+
+// backfillPosition - how far the backfill has written
+// Rows within a block are identified rather than ordered, live messages only carry some of the ordering columns
+type backfillPosition struct {
+	cursor      *crud.Cursor
+	blockNumber int64
+	blockKeys   map[backfillKey]bool
+}
+
+// add - record a row read by the backfill
+func (p *backfillPosition) add(key backfillKey) {
+	if key.BlockNumber != p.blockNumber || p.blockKeys == nil {
+		p.blockNumber = key.BlockNumber
+		p.blockKeys = map[backfillKey]bool{}
+	}
+	p.blockKeys[key] = true
+}
+
+// written - true if the backfill already read the row of a live message
+func (p *backfillPosition) written(key backfillKey) bool {
+	if key.BlockNumber != p.blockNumber {
+		return key.BlockNumber < p.blockNumber
+	}
+	return p.blockKeys[key]
+}
+
+// backfillChannels - channels that support resuming from a block height
+func backfillChannels() map[string]*backfillChannel {
+	return map[string]*backfillChannel{
+		config.Config.RedisTransactionsChannel: {
+			selectPage: backfillSelectTransactions,
+		},
+		config.Config.RedisLogsChannel: {
+			selectPage:             backfillSelectLogs,
+			keyedByTransactionHash: true,
+		},
+		config.Config.RedisTokenTransfersChannel: {
+			selectPage: backfillSelectTokenTransfers,
+		},
+	}
+}
+
+// messageKey - identity of a live message, comparable with the keys of stored rows
+// Fails if the message is missing a column of the key, a zero value would match the wrong row
+func (b *backfillChannel) messageKey(msg []byte) (backfillKey, error) {
+	message := &struct {
+		BlockNumber      *int64  `json:"block_number"`
+		TransactionIndex *int64  `json:"transaction_index"`
+		TransactionHash  *string `json:"transaction_hash"`
+		LogIndex         *int64  `json:"log_index"`
+	}{}
+
+	err := json.Unmarshal(msg, message)
+	if err != nil {
+		return backfillKey{}, err
+	}
+
+	if message.BlockNumber == nil {
+		return backfillKey{}, errors.New("message has no block_number")
+	}
+	if message.LogIndex == nil {
+		return backfillKey{}, errors.New("message has no log_index")
+	}
+
+	key := backfillKey{
+		BlockNumber: *message.BlockNumber,
+		LogIndex:    *message.LogIndex,
+	}
+	if b.keyedByTransactionHash {
+		if message.TransactionHash == nil || *message.TransactionHash == "" {
+			return backfillKey{}, errors.New("message has no transaction_hash")
+		}
+		key.TransactionHash = *message.TransactionHash
+	} else {
+		if message.TransactionIndex == nil {
+			return backfillKey{}, errors.New("message has no transaction_index")
+		}
+		key.TransactionIndex = *message.TransactionIndex
+	}
+
+	return key, nil
+}
+
+func backfillSelectTransactions(fromBlock int64, cursor *crud.Cursor, filter *Filter) ([]backfillRow, *crud.Cursor, error) {
+	transactions, err := crud.GetTransactionCrud().SelectMany(
+		context.Background(),
+		config.Config.MaxPageSize,
+		0,
+		cursor,
+		filter.From,
+		filter.To,
+		"transaction",
+		0,
+		int(fromBlock),
+		0,
+		filter.Method,
+		"asc",
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := make([]backfillRow, len(*transactions))
+	var lastCursor *crud.Cursor
+	for i, transaction := range *transactions {
+		msg, _ := json.Marshal(&transaction)
+		rows[i] = backfillRow{
+			key: backfillKey{
+				BlockNumber:      transaction.BlockNumber,
+				TransactionIndex: transaction.TransactionIndex,
+				LogIndex:         transaction.LogIndex,
+			},
+			msg: msg,
+		}
+		lastCursor = &crud.Cursor{
+			BlockNumber:      transaction.BlockNumber,
+			TransactionIndex: transaction.TransactionIndex,
+			LogIndex:         transaction.LogIndex,
+			Sort:             "asc",
+		}
+	}
+
+	return rows, lastCursor, nil
+}
+
+func backfillSelectLogs(fromBlock int64, cursor *crud.Cursor, filter *Filter) ([]backfillRow, *crud.Cursor, error) {
+	logs, lastCursor, err := crud.GetLogCrud().SelectMany(
+		context.Background(),
+		config.Config.MaxPageSize,
+		0,
+		cursor,
+		0,
+		uint32(fromBlock),
+		0,
+		"",
+		filter.Address,
+		filter.Method,
+		"asc",
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := make([]backfillRow, len(*logs))
+	for i, log := range *logs {
+		msg, _ := json.Marshal(&log)
+		rows[i] = backfillRow{
+			key: backfillKey{
+				BlockNumber:     log.BlockNumber,
+				TransactionHash: log.TransactionHash,
+				LogIndex:        log.LogIndex,
+			},
+			msg: msg,
+		}
+	}
+
+	return rows, lastCursor, nil
+}
+
+func backfillSelectTokenTransfers(fromBlock int64, cursor *crud.Cursor, filter *Filter) ([]backfillRow, *crud.Cursor, error) {
+	tokenTransfers, err := crud.GetTokenTransferCrud().SelectMany(
+		context.Background(),
+		config.Config.MaxPageSize,
+		0,
+		cursor,
+		filter.From,
+		filter.To,
+		0,
+		int(fromBlock),
+		0,
+		"",
+		filter.TokenContractAddress,
+		"asc",
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := make([]backfillRow, len(*tokenTransfers))
+	var lastCursor *crud.Cursor
+	for i, tokenTransfer := range *tokenTransfers {
+		msg, _ := json.Marshal(&tokenTransfer)
+		rows[i] = backfillRow{
+			key: backfillKey{
+				BlockNumber:      tokenTransfer.BlockNumber,
+				TransactionIndex: tokenTransfer.TransactionIndex,
+				LogIndex:         tokenTransfer.LogIndex,
+			},
+			msg: msg,
+		}
+		lastCursor = &crud.Cursor{
+			BlockNumber:      tokenTransfer.BlockNumber,
+			TransactionIndex: tokenTransfer.TransactionIndex,
+			LogIndex:         tokenTransfer.LogIndex,
+			Sort:             "asc",
+		}
+	}
+
+	return rows, lastCursor, nil
+}
+
+// writeBackfill - write stored rows after the position to the client until caught up
+// Returns the position after the last row read, continuing the passed position if there is one
+func writeBackfill(
+	c *websocket.Conn,
+	backfill *backfillChannel,
+	fromBlock int64,
+	position *backfillPosition,
+	filter *atomic.Value,
+	clientCloseSig chan bool,
+) (*backfillPosition, error) {
+	if position == nil {
+		position = &backfillPosition{}
+	}
+
+	for {
+		// Check for client close between pages
+		select {
+		case <-clientCloseSig:
+			return position, errors.New("client closed")
+		default:
+		}
+
+		currentFilter := filter.Load().(*Filter)
+
+		rows, lastCursor, err := backfill.selectPage(fromBlock, position.cursor, currentFilter)
+		if err != nil {
+			return position, err
+		}
+
+		for _, row := range rows {
+			position.add(row.key)
+
+			if currentFilter.Match(row.msg) == false {
+				continue
+			}
+
+			err = c.WriteMessage(websocket.TextMessage, row.msg)
+			if err != nil {
+				return position, err
+			}
+		}
+		if lastCursor != nil {
+			position.cursor = lastCursor
+		}
+
+		// Caught up
+		if len(rows) < config.Config.MaxPageSize {
+			return position, nil
+		}
+	}
+}
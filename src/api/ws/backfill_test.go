@@ -0,0 +1,135 @@
+package ws
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// Payloads as published on each channel
+const (
+	transactionPayload = `{"hash":"0x6e1f6bd4ff9c7c9a4d1e0e3a1b4b5c2d9f7d0c3e8a1b2c3d4e5f60718293a4b5","log_index":-1,` +
+		`"type":"transaction","method":"transfer","from_address":"hx1e8b2a4c9f3b8d7e6a5c4b3a2918f7e6d5c4b3a2",` +
+		`"to_address":"cx88fd7df7ddff82f7cc735c871dc519838cb235bb","block_number":71504920,"log_count":1,"version":"0x3",` +
+		`"value":"0x0","value_decimal":0,"step_limit":"0x30d40","timestamp":1695023412001234,` +
+		`"block_timestamp":1695023412001234,"nid":"0x1","nonce":"","transaction_index":1,` +
+		`"block_hash":"0x9a4c2f1e3b5d7f9a1c3e5b7d9f1a3c5e7b9d1f3a5c7e9b1d3f5a7c9e1b3d5f7a","transaction_fee":"0x5f5e100",` +
+		`"signature":"","data_type":"call","data":"","cumulative_step_used":"0x1d4c0","step_used":"0x1d4c0",` +
+		`"step_price":"0x2e90edd00","score_address":"","logs_bloom":"","status":"0x1"}`
+	logPayload = `{"transaction_hash":"0x6e1f6bd4ff9c7c9a4d1e0e3a1b4b5c2d9f7d0c3e8a1b2c3d4e5f60718293a4b5","log_index":0,` +
+		`"address":"cx88fd7df7ddff82f7cc735c871dc519838cb235bb","block_number":71504920,"method":"Transfer",` +
+		`"data":"[\"0x\"]","indexed":"[\"Transfer(Address,Address,int,bytes)\",\"hx1e8b2a4c9f3b8d7e6a5c4b3a2918f7e6d5c4b3a2\",` +
+		`\"hx5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b\",\"0xde0b6b3a7640000\"]","block_timestamp":1695023412001234}`
+	tokenTransferPayload = `{"token_contract_address":"cx88fd7df7ddff82f7cc735c871dc519838cb235bb",` +
+		`"from_address":"hx1e8b2a4c9f3b8d7e6a5c4b3a2918f7e6d5c4b3a2","to_address":"hx5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b",` +
+		`"value":"0xde0b6b3a7640000",` +
+		`"transaction_hash":"0x6e1f6bd4ff9c7c9a4d1e0e3a1b4b5c2d9f7d0c3e8a1b2c3d4e5f60718293a4b5","log_index":0,` +
+		`"block_number":71504920,"value_decimal":1,"block_timestamp":1695023412001234,"token_contract_name":"Balanced Dollar",` +
+		`"transaction_fee":"0x5f5e100","token_contract_symbol":"bnUSD","nft_id":0,"transaction_index":1}`
+)
+
+func TestBackfillMessageKey(t *testing.T) {
+	assert := assert.New(t)
+
+	transactions := &backfillChannel{}
+	key, err := transactions.messageKey([]byte(transactionPayload))
+	assert.Equal(nil, err)
+	assert.Equal(backfillKey{BlockNumber: 71504920, TransactionIndex: 1, LogIndex: -1}, key)
+
+	logs := &backfillChannel{keyedByTransactionHash: true}
+	key, err = logs.messageKey([]byte(logPayload))
+	assert.Equal(nil, err)
+	assert.Equal(backfillKey{
+		BlockNumber:     71504920,
+		TransactionHash: "0x6e1f6bd4ff9c7c9a4d1e0e3a1b4b5c2d9f7d0c3e8a1b2c3d4e5f60718293a4b5",
+		LogIndex:        0,
+	}, key)
+
+	tokenTransfers := &backfillChannel{}
+	key, err = tokenTransfers.messageKey([]byte(tokenTransferPayload))
+	assert.Equal(nil, err)
+	assert.Equal(backfillKey{BlockNumber: 71504920, TransactionIndex: 1, LogIndex: 0}, key)
+
+	_, err = logs.messageKey([]byte(`not json`))
+	assert.NotEqual(nil, err)
+}
+
+func TestBackfillMessageKeyMissing(t *testing.T) {
+	assert := assert.New(t)
+
+	transactions := &backfillChannel{}
+	logs := &backfillChannel{keyedByTransactionHash: true}
+
+	// Logs don't carry a transaction index, so the log payload can't be identified on an indexed channel
+	_, err := transactions.messageKey([]byte(logPayload))
+	assert.NotEqual(nil, err)
+
+	_, err = transactions.messageKey([]byte(`{"transaction_index": 1, "log_index": -1}`))
+	assert.NotEqual(nil, err)
+	_, err = transactions.messageKey([]byte(`{"block_number": 10, "transaction_index": 1}`))
+	assert.NotEqual(nil, err)
+	_, err = logs.messageKey([]byte(`{"block_number": 10, "log_index": 0}`))
+	assert.NotEqual(nil, err)
+	_, err = logs.messageKey([]byte(`{"block_number": 10, "transaction_hash": "", "log_index": 0}`))
+	assert.NotEqual(nil, err)
+
+	// Zero is a valid value, only absence is an error
+	key, err := transactions.messageKey([]byte(`{"block_number": 10, "transaction_index": 0, "log_index": 0}`))
+	assert.Equal(nil, err)
+	assert.Equal(backfillKey{BlockNumber: 10}, key)
+}
+
+func TestBackfillMessageKeyMatchesStoredRow(t *testing.T) {
+	assert := assert.New(t)
+
+	// Stored rows are written as the marshalled model, the live key has to match the row key
+	transaction := &models.TransactionList{}
+	assert.Equal(nil, json.Unmarshal([]byte(transactionPayload), transaction))
+	msg, _ := json.Marshal(transaction)
+	key, err := (&backfillChannel{}).messageKey(msg)
+	assert.Equal(nil, err)
+	assert.Equal(backfillKey{
+		BlockNumber:      transaction.BlockNumber,
+		TransactionIndex: transaction.TransactionIndex,
+		LogIndex:         transaction.LogIndex,
+	}, key)
+
+	log := &models.Log{}
+	assert.Equal(nil, json.Unmarshal([]byte(logPayload), log))
+	msg, _ = json.Marshal(log)
+	key, err = (&backfillChannel{keyedByTransactionHash: true}).messageKey(msg)
+	assert.Equal(nil, err)
+	assert.Equal(backfillKey{
+		BlockNumber:     log.BlockNumber,
+		TransactionHash: log.TransactionHash,
+		LogIndex:        log.LogIndex,
+	}, key)
+
+	tokenTransfer := &models.TokenTransfer{}
+	assert.Equal(nil, json.Unmarshal([]byte(tokenTransferPayload), tokenTransfer))
+	msg, _ = json.Marshal(tokenTransfer)
+	key, err = (&backfillChannel{}).messageKey(msg)
+	assert.Equal(nil, err)
+	assert.Equal(backfillKey{
+		BlockNumber:      tokenTransfer.BlockNumber,
+		TransactionIndex: tokenTransfer.TransactionIndex,
+		LogIndex:         tokenTransfer.LogIndex,
+	}, key)
+}
+
+func TestBackfillPositionWritten(t *testing.T) {
+	assert := assert.New(t)
+
+	position := &backfillPosition{}
+	position.add(backfillKey{BlockNumber: 9, TransactionHash: "0xc", LogIndex: 0})
+	position.add(backfillKey{BlockNumber: 10, TransactionHash: "0xb", LogIndex: 0})
+	position.add(backfillKey{BlockNumber: 10, TransactionHash: "0xa", LogIndex: 1})
+
+	assert.Equal(true, position.written(backfillKey{BlockNumber: 9, TransactionHash: "0xd", LogIndex: 0}))
+	assert.Equal(true, position.written(backfillKey{BlockNumber: 10, TransactionHash: "0xa", LogIndex: 1}))
+	assert.Equal(false, position.written(backfillKey{BlockNumber: 10, TransactionHash: "0xa", LogIndex: 0}))
+	assert.Equal(false, position.written(backfillKey{BlockNumber: 11, TransactionHash: "0xa", LogIndex: 0}))
+}
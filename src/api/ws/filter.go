@@ -0,0 +1,81 @@
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Filter - subscription filter sent by a client after connecting
+// Empty fields match everything
+type Filter struct {
+	From                 string `json:"from"`
+	To                   string `json:"to"`
+	Address              string `json:"address"`
+	Method               string `json:"method"`
+	TokenContractAddress string `json:"token_contract_address"`
+}
+
+// filterMessage - fields of transaction, log, and token transfer messages that can be filtered on
+type filterMessage struct {
+	FromAddress          string `json:"from_address"`
+	ToAddress            string `json:"to_address"`
+	Address              string `json:"address"`
+	Method               string `json:"method"`
+	TokenContractAddress string `json:"token_contract_address"`
+}
+
+// ParseFilter - parse a filter message from a client
+func ParseFilter(msg []byte) (*Filter, error) {
+	filter := &Filter{}
+
+	err := json.Unmarshal(msg, filter)
+	if err != nil {
+		return nil, errors.New("invalid filter")
+	}
+
+	return filter, nil
+}
+
+// IsEmpty - true if the filter matches every message
+func (f *Filter) IsEmpty() bool {
+	return *f == Filter{}
+}
+
+// Match - check if a message from the redis channel passes the filter
+func (f *Filter) Match(msg []byte) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	message := &filterMessage{}
+	err := json.Unmarshal(msg, message)
+	if err != nil {
+		return false
+	}
+
+	if f.From != "" && f.From != message.FromAddress {
+		return false
+	}
+
+	if f.To != "" && f.To != message.ToAddress {
+		return false
+	}
+
+	// Address matches either side of a transfer or the emitting contract of a log
+	if f.Address != "" &&
+		f.Address != message.FromAddress &&
+		f.Address != message.ToAddress &&
+		f.Address != message.Address {
+		return false
+	}
+
+	if f.Method != "" && f.Method != message.Method {
+		return false
+	}
+
+	if f.TokenContractAddress != "" && f.TokenContractAddress != message.TokenContractAddress {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,46 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	transaction := []byte(`{"hash": "0x1", "from_address": "hx1", "to_address": "cx1", "method": "transfer"}`)
+	log := []byte(`{"transaction_hash": "0x1", "address": "cx1", "method": "Transfer"}`)
+	tokenTransfer := []byte(`{"from_address": "hx1", "to_address": "hx2", "token_contract_address": "cx2"}`)
+
+	tests := []struct {
+		description string
+		filter      string
+		msg         []byte
+		expected    bool
+	}{
+		{"empty filter", `{}`, transaction, true},
+		{"from", `{"from": "hx1"}`, transaction, true},
+		{"from mismatch", `{"from": "hx2"}`, transaction, false},
+		{"from and to", `{"from": "hx1", "to": "cx1"}`, transaction, true},
+		{"method", `{"method": "transfer"}`, transaction, true},
+		{"method mismatch", `{"method": "vote"}`, transaction, false},
+		{"log address", `{"address": "cx1"}`, log, true},
+		{"log address mismatch", `{"address": "cx2"}`, log, false},
+		{"token contract", `{"token_contract_address": "cx2"}`, tokenTransfer, true},
+		{"token contract mismatch", `{"token_contract_address": "cx1"}`, tokenTransfer, false},
+		{"address either side", `{"address": "hx2"}`, tokenTransfer, true},
+		{"invalid message", `{"from": "hx1"}`, []byte(`not json`), false},
+	}
+
+	for _, test := range tests {
+		filter, err := ParseFilter([]byte(test.filter))
+		assert.Equal(nil, err, test.description)
+		assert.Equal(test.expected, filter.Match(test.msg), test.description)
+	}
+}
+
+func TestParseFilterInvalid(t *testing.T) {
+	_, err := ParseFilter([]byte(`{"from": 1}`))
+	assert.NotEqual(t, nil, err)
+}
@@ -1,10 +1,15 @@
 package ws
 
 import (
+	"strconv"
+	"sync/atomic"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/websocket/v2"
+	"go.uber.org/zap"
 
 	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/metrics"
 	"github.com/sudoblockio/icon-go-api/redis"
 )
 
@@ -31,50 +36,149 @@ func WebsocketsAddHandlers(app *fiber.App) {
 
 func handlerWebsocket(channelName string) func(*websocket.Conn) {
 
+	// Nil if the channel can't be resumed from a block height
+	backfill := backfillChannels()[channelName]
+
 	return func(c *websocket.Conn) {
-		// Add broadcaster
-		msgChan := make(chan []byte)
+		metrics.WebsocketConnectionsGauge.WithLabelValues(channelName).Inc()
+		defer metrics.WebsocketConnectionsGauge.WithLabelValues(channelName).Dec()
 
-		// If a msg comes into channel name (new subscriber), then send a message to the message channel
-		broadcasterID := redis.GetBroadcaster(channelName).AddBroadcastChannel(msgChan)
-		defer func() {
-			// Remove broadcaster
-			redis.GetBroadcaster(channelName).RemoveBroadcastChannel(broadcasterID)
-		}()
+		// Filter
+		// Initially set from the query params, updated by the client at any time after connecting
+		var filter atomic.Value
+		filter.Store(&Filter{
+			From:                 c.Query("from"),
+			To:                   c.Query("to"),
+			Address:              c.Query("address"),
+			Method:               c.Query("method"),
+			TokenContractAddress: c.Query("token_contract_address"),
+		})
 
-		// Read for close
+		// Read for filters and close
 		clientCloseSig := make(chan bool)
+		clientResponseChan := make(chan []byte, 1)
 		go func() {
 			for {
-				// TODO: If applying filters, this is where you would take the msg, read the filter, and store it outside of
-				//  the goroutine.
-				_, _, err := c.ReadMessage()
+				_, clientMsg, err := c.ReadMessage()
 				if err != nil {
-					clientCloseSig <- true
+					close(clientCloseSig)
 					break
 				}
+
+				newFilter, err := ParseFilter(clientMsg)
+				if err != nil {
+					select {
+					case clientResponseChan <- []byte(`{"error": "invalid filter"}`):
+					default:
+					}
+					continue
+				}
+				filter.Store(newFilter)
 			}
 		}()
 
-		for {
-			// Read
-			msg := <-msgChan
+		// Resume from a block height
+		// Stored rows are written before subscribing so the subscriber buffer only needs to cover the final page,
+		// then rows stored while subscribing are written and live messages already written are skipped
+		var position *backfillPosition
+		var fromBlock int64
+		fromBlockRaw := c.Query("from_block")
+		if fromBlockRaw != "" {
+			if backfill == nil {
+				c.WriteMessage(websocket.TextMessage, []byte(`{"error": "from_block not supported on this channel"}`))
+				return
+			}
 
-			// TODO: If building in filters, this is where you would apply the filter.
-			// TODO: If filter is nil, don't broadcast
+			var err error
+			fromBlock, err = strconv.ParseInt(fromBlockRaw, 10, 64)
+			if err != nil || fromBlock < 0 {
+				c.WriteMessage(websocket.TextMessage, []byte(`{"error": "invalid from_block"}`))
+				return
+			}
 
-			// Broadcast
-			err := c.WriteMessage(websocket.TextMessage, msg)
+			position, err = writeBackfill(c, backfill, fromBlock, nil, &filter, clientCloseSig)
 			if err != nil {
-				break
+				zap.S().Warn("Websocket backfill ERROR: ", err.Error())
+				return
 			}
+		}
 
-			// check for client close
+		// Add broadcaster
+		// Buffered so a slow client only affects itself, see config.WebsocketSlowConsumerPolicy
+		msgChan := make(chan []byte, config.Config.WebsocketClientBufferSize)
+
+		// If a msg comes into channel name (new subscriber), then send a message to the message channel
+		// A resuming client is disconnected if it falls behind until it's past the backfill, dropping a message would leave a gap
+		var broadcasterID redis.BroadcasterID
+		if fromBlockRaw != "" {
+			broadcasterID = redis.GetBroadcaster(channelName).AddBroadcastChannelWithPolicy(msgChan, redis.SlowConsumerPolicyDisconnect)
+		} else {
+			broadcasterID = redis.GetBroadcaster(channelName).AddBroadcastChannel(msgChan)
+		}
+		defer func() {
+			// Remove broadcaster
+			redis.GetBroadcaster(channelName).RemoveBroadcastChannel(broadcasterID)
+		}()
+
+		if fromBlockRaw != "" {
+			var err error
+			position, err = writeBackfill(c, backfill, fromBlock, position, &filter, clientCloseSig)
+			if err != nil {
+				zap.S().Warn("Websocket backfill ERROR: ", err.Error())
+				return
+			}
+		}
+
+		for {
 			select {
-			case _ = <-clientCloseSig:
-				break
-			default:
-				continue
+			case msg, ok := <-msgChan:
+				if ok == false {
+					// Removed by the broadcaster as a slow consumer
+					if position != nil {
+						c.WriteMessage(websocket.TextMessage, []byte(`{"error": "fell behind while resuming, resume again from the last block received"}`))
+					}
+					return
+				}
+
+				// Skip messages already written by the backfill
+				if position != nil {
+					// Messages that can't be identified are written, a duplicate is better than a dropped message
+					msgKey, err := backfill.messageKey(msg)
+					if err != nil {
+						zap.S().Warn("Websocket backfill could not identify message: ", err.Error())
+					} else {
+						if position.written(msgKey) {
+							continue
+						}
+						if msgKey.BlockNumber > position.blockNumber {
+							// Past the backfill, every message after this is new
+							position = nil
+							redis.GetBroadcaster(channelName).SetSlowConsumerPolicy(
+								broadcasterID,
+								redis.SlowConsumerPolicy(config.Config.WebsocketSlowConsumerPolicy),
+							)
+						}
+					}
+				}
+
+				// Filter
+				if filter.Load().(*Filter).Match(msg) == false {
+					continue
+				}
+
+				// Broadcast
+				err := c.WriteMessage(websocket.TextMessage, msg)
+				if err != nil {
+					return
+				}
+			case response := <-clientResponseChan:
+				err := c.WriteMessage(websocket.TextMessage, response)
+				if err != nil {
+					return
+				}
+			case <-clientCloseSig:
+				// Client closed
+				return
 			}
 		}
 	}
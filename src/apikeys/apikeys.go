@@ -0,0 +1,183 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// FileApiKey - api key in the api keys file
+//
+// [{"name": "partner", "key": "...", "rate": 50, "burst": 100, "daily_quota": 0}]
+type FileApiKey struct {
+	Name       string  `json:"name"`
+	Key        string  `json:"key"`
+	Rate       float64 `json:"rate"`
+	Burst      int64   `json:"burst"`
+	DailyQuota int64   `json:"daily_quota"`
+}
+
+// Api keys from the file and the database, by key hash
+var apiKeysByHash = map[string]models.ApiKey{}
+var apiKeysMux sync.RWMutex
+
+// Start - migrate the api_keys table and load api keys
+func Start() {
+	err := crud.GetApiKeyCrud().Migrate()
+	if err != nil {
+		zap.S().Fatal("ApiKeys: Unable to migrate api_keys table ERROR=", err.Error())
+	}
+
+	// Fail fast on a bad file
+	_, err = readFile(config.Config.APIKeysFile)
+	if err != nil {
+		zap.S().Fatal("ApiKeys: Unable to read api keys file ERROR=", err.Error())
+	}
+
+	Refresh()
+	go func() {
+		for {
+			time.Sleep(config.Config.APIKeysRefreshInterval)
+			Refresh()
+		}
+	}()
+
+	zap.S().Info("Started ApiKeys")
+}
+
+// Refresh - reload api keys from the file and the database
+func Refresh() {
+	fileApiKeys, err := readFile(config.Config.APIKeysFile)
+	if err != nil {
+		zap.S().Warn("ApiKeys: Unable to read api keys file ERROR=", err.Error())
+		return
+	}
+
+	// Keys created just before may not be on the database replicas yet
+	dbApiKeys, err := crud.GetApiKeyCrud().SelectMany(crud.WithPrimary(context.Background()), 0, 0)
+	if err != nil {
+		zap.S().Warn("ApiKeys: Unable to refresh api keys ERROR=", err.Error())
+		return
+	}
+
+	newApiKeysByHash := map[string]models.ApiKey{}
+	for _, apiKey := range *dbApiKeys {
+		newApiKeysByHash[apiKey.KeyHash] = apiKey
+	}
+	// File takes precedence
+	for _, apiKey := range fileApiKeys {
+		newApiKeysByHash[apiKey.KeyHash] = apiKey
+	}
+
+	apiKeysMux.Lock()
+	defer apiKeysMux.Unlock()
+
+	apiKeysByHash = newApiKeysByHash
+}
+
+// Lookup - find the api key for a key
+func Lookup(key string) (models.ApiKey, bool) {
+	apiKeysMux.RLock()
+	defer apiKeysMux.RUnlock()
+
+	apiKey, ok := apiKeysByHash[HashKey(key)]
+	return apiKey, ok
+}
+
+// HashKey - keys are only stored as hashes
+func HashKey(key string) string {
+	keyHash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(keyHash[:])
+}
+
+// NewKey - random key for a new api key
+func NewKey() (string, error) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+// ValidationError - invalid field of an api key
+type ValidationError struct {
+	field   string
+	message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.message
+}
+
+// Field - name of the invalid field in the request body
+func (e *ValidationError) Field() string {
+	return e.field
+}
+
+// ValidateApiKey - check the limits of an api key
+func ValidateApiKey(apiKey *models.ApiKey) error {
+	if apiKey.Rate <= 0 {
+		return &ValidationError{"rate", "rate must be greater than 0"}
+	}
+	if apiKey.Burst < 1 {
+		return &ValidationError{"burst", "burst must be at least 1"}
+	}
+	if apiKey.DailyQuota < 0 {
+		return &ValidationError{"daily_quota", "daily_quota can't be negative"}
+	}
+
+	return nil
+}
+
+func readFile(path string) ([]models.ApiKey, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	fileBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fileApiKeys := []FileApiKey{}
+	err = json.Unmarshal(fileBytes, &fileApiKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys := make([]models.ApiKey, len(fileApiKeys))
+	for i, fileApiKey := range fileApiKeys {
+		if fileApiKey.Key == "" {
+			return nil, errors.New("api key " + fileApiKey.Name + " has no key")
+		}
+
+		apiKeys[i] = models.ApiKey{
+			Name:       fileApiKey.Name,
+			KeyHash:    HashKey(fileApiKey.Key),
+			Rate:       fileApiKey.Rate,
+			Burst:      fileApiKey.Burst,
+			DailyQuota: fileApiKey.DailyQuota,
+		}
+
+		err = ValidateApiKey(&apiKeys[i])
+		if err != nil {
+			return nil, errors.New("api key " + fileApiKey.Name + ": " + err.Error())
+		}
+	}
+
+	return apiKeys, nil
+}
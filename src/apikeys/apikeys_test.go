@@ -0,0 +1,72 @@
+package apikeys
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func TestReadFile(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "api_keys.json")
+
+	// No file
+	apiKeys, err := readFile("")
+	assert.Equal(nil, err)
+	assert.Equal(0, len(apiKeys))
+
+	// Keys are hashed
+	err = ioutil.WriteFile(path, []byte(`[{"name": "partner", "key": "abc", "rate": 50, "burst": 100, "daily_quota": 1000}]`), 0600)
+	assert.Equal(nil, err)
+	apiKeys, err = readFile(path)
+	assert.Equal(nil, err)
+	assert.Equal(1, len(apiKeys))
+	assert.Equal("partner", apiKeys[0].Name)
+	assert.Equal(HashKey("abc"), apiKeys[0].KeyHash)
+	assert.Equal(float64(50), apiKeys[0].Rate)
+	assert.Equal(int64(100), apiKeys[0].Burst)
+	assert.Equal(int64(1000), apiKeys[0].DailyQuota)
+
+	// Invalid keys
+	err = ioutil.WriteFile(path, []byte(`[{"name": "partner", "rate": 50, "burst": 100}]`), 0600)
+	assert.Equal(nil, err)
+	_, err = readFile(path)
+	assert.NotEqual(nil, err)
+
+	err = ioutil.WriteFile(path, []byte(`[{"name": "partner", "key": "abc", "rate": 0, "burst": 100}]`), 0600)
+	assert.Equal(nil, err)
+	_, err = readFile(path)
+	assert.NotEqual(nil, err)
+
+	err = ioutil.WriteFile(path, []byte(`not json`), 0600)
+	assert.Equal(nil, err)
+	_, err = readFile(path)
+	assert.NotEqual(nil, err)
+}
+
+func TestValidateApiKey(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(nil, ValidateApiKey(&models.ApiKey{Rate: 0.5, Burst: 1}))
+	assert.NotEqual(nil, ValidateApiKey(&models.ApiKey{Rate: 0, Burst: 1}))
+	assert.NotEqual(nil, ValidateApiKey(&models.ApiKey{Rate: 1, Burst: 0}))
+	assert.NotEqual(nil, ValidateApiKey(&models.ApiKey{Rate: 1, Burst: 1, DailyQuota: -1}))
+}
+
+func TestNewKey(t *testing.T) {
+	assert := assert.New(t)
+
+	key, err := NewKey()
+	assert.Equal(nil, err)
+	assert.Equal(64, len(key))
+
+	otherKey, err := NewKey()
+	assert.Equal(nil, err)
+	assert.NotEqual(key, otherKey)
+	assert.NotEqual(HashKey(key), HashKey(otherKey))
+}
@@ -20,15 +20,32 @@ type configType struct {
 	MetricsPort string `envconfig:"METRICS_PORT" required:"false" default:"9400"`
 
 	// Prefix
-	RestPrefix      string `envconfig:"REST_PREFIX" required:"false" default:"/api/v1"`
-	WebsocketPrefix string `envconfig:"WEBSOCKET_PREFIX" required:"false" default:"/ws/v1"`
-	HealthPrefix    string `envconfig:"HEALTH_PREFIX" required:"false" default:"/health"`
-	MetricsPrefix   string `envconfig:"METRICS_PREFIX" required:"false" default:"/metrics"`
+	RestPrefix            string `envconfig:"REST_PREFIX" required:"false" default:"/api/v1"`
+	WebsocketPrefix       string `envconfig:"WEBSOCKET_PREFIX" required:"false" default:"/ws/v1"`
+	HealthPrefix          string `envconfig:"HEALTH_PREFIX" required:"false" default:"/health"`
+	HealthLivenessPrefix  string `envconfig:"HEALTH_LIVENESS_PREFIX" required:"false" default:"/health/live"`
+	HealthReadinessPrefix string `envconfig:"HEALTH_READINESS_PREFIX" required:"false" default:"/health/ready"`
+	MetricsPrefix         string `envconfig:"METRICS_PREFIX" required:"false" default:"/metrics"`
 
 	// Endpoints
 	MaxPageSize int `envconfig:"MAX_PAGE_SIZE" required:"false" default:"100"`
 	MaxPageSkip int `envconfig:"MAX_PAGE_SKIP" required:"false" default:"1500000"`
 
+	// Counts
+	// Filtered counts are exact when the query planner estimates fewer rows than the threshold, otherwise the estimate
+	// Counts are cached per filter for the ttl
+	CountExactThreshold int64         `envconfig:"COUNT_EXACT_THRESHOLD" required:"false" default:"50000"`
+	CountExactTimeout   time.Duration `envconfig:"COUNT_EXACT_TIMEOUT" required:"false" default:"2s"`
+	CountCacheTTL       time.Duration `envconfig:"COUNT_CACHE_TTL" required:"false" default:"1m"`
+
+	// Token Holder Stats
+	// Holder stats are cached per token for the ttl
+	TokenHolderStatsCacheTTL time.Duration `envconfig:"TOKEN_HOLDER_STATS_CACHE_TTL" required:"false" default:"5m"`
+
+	// Balance History
+	// ICX balance histories are cached per address and query for the ttl
+	BalanceHistoryCacheTTL time.Duration `envconfig:"BALANCE_HISTORY_CACHE_TTL" required:"false" default:"5m"`
+
 	// CORS
 	CORSAllowOrigins  string `envconfig:"CORS_ALLOW_ORIGINS" required:"false" default:"*"`
 	CORSAllowHeaders  string `envconfig:"CORS_ALLOW_HEADERS" required:"false" default:"*"`
@@ -39,7 +56,26 @@ type configType struct {
 	RestCompressLevel int `envconfig:"REST_COMPRESS_LEVEL" required:"false" default:"2"`
 
 	// Monitoring
-	HealthPollingInterval int `envconfig:"HEALTH_POLLING_INTERVAL" required:"false" default:"10"`
+	HealthPollingInterval   int           `envconfig:"HEALTH_POLLING_INTERVAL" required:"false" default:"10"`
+	HealthCheckTimeout      time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" required:"false" default:"5s"`
+	HealthSubscriberTimeout time.Duration `envconfig:"HEALTH_SUBSCRIBER_TIMEOUT" required:"false" default:"1m"`
+
+	// The ICON node is shared by every replica and only some endpoints use it, so it's only reported unless fatal
+	HealthIconNodeFatal bool `envconfig:"HEALTH_ICON_NODE_FATAL" required:"false" default:"false"`
+
+	// Tracing
+	// Spans are exported over OTLP/HTTP, a sample ratio of 1 keeps every trace
+	TracingEnabled      bool    `envconfig:"TRACING_ENABLED" required:"false" default:"false"`
+	TracingOTLPEndpoint string  `envconfig:"TRACING_OTLP_ENDPOINT" required:"false" default:"localhost:4318"`
+	TracingOTLPInsecure bool    `envconfig:"TRACING_OTLP_INSECURE" required:"false" default:"true"`
+	TracingSampleRatio  float64 `envconfig:"TRACING_SAMPLE_RATIO" required:"false" default:"1"`
+
+	// Indexer Lag
+	// Readiness is degraded when the blocks table is more than the threshold blocks behind the node
+	// Degraded readiness only fails the readiness probe if fatal
+	IndexerLagPollInterval time.Duration `envconfig:"INDEXER_LAG_POLL_INTERVAL" required:"false" default:"5s"`
+	IndexerLagThreshold    int64         `envconfig:"INDEXER_LAG_THRESHOLD" required:"false" default:"20"`
+	IndexerLagFatal        bool          `envconfig:"INDEXER_LAG_FATAL" required:"false" default:"false"`
 
 	// Logging
 	LogLevel         string `envconfig:"LOG_LEVEL" required:"false" default:"INFO"`
@@ -60,6 +96,15 @@ type configType struct {
 	DbMaxIdleConnections int    `envconfig:"DB_MAX_IDLE_CONNECTIONS" required:"false" default:"2"`
 	DbMaxOpenConnections int    `envconfig:"DB_MAX_OPEN_CONNECTIONS" required:"false" default:"10"`
 
+	// DB Replicas
+	// Reads are spread over the replicas by weight, defaulting to 1, and fall back to the primary
+	// Replicas that are down or more than the max lag blocks behind the primary are skipped until the next check
+	DbReplicaDSNs          []string      `envconfig:"DB_REPLICA_DSNS" required:"false" default:""`
+	DbReplicaWeights       []int         `envconfig:"DB_REPLICA_WEIGHTS" required:"false" default:""`
+	DbReplicaMaxLag        int64         `envconfig:"DB_REPLICA_MAX_LAG" required:"false" default:"20"`
+	DbReplicaCheckInterval time.Duration `envconfig:"DB_REPLICA_CHECK_INTERVAL" required:"false" default:"5s"`
+	DbReplicaCheckTimeout  time.Duration `envconfig:"DB_REPLICA_CHECK_TIMEOUT" required:"false" default:"2s"`
+
 	// Redis
 	RedisHost                     string `envconfig:"REDIS_HOST" required:"false" default:"localhost"`
 	RedisPort                     string `envconfig:"REDIS_PORT" required:"false" default:"6379"`
@@ -76,6 +121,67 @@ type configType struct {
 	RedisLogsChannel           string `envconfig:"REDIS_LOGS_CHANNEL" required:"false" default:"logs"`
 	RedisTokenTransfersChannel string `envconfig:"REDIS_TOKEN_TRANSFERS_CHANNEL" required:"false" default:"token_transfers"`
 
+	// Websockets
+	// Policy is one of drop_oldest or disconnect
+	WebsocketClientBufferSize   int    `envconfig:"WEBSOCKET_CLIENT_BUFFER_SIZE" required:"false" default:"100"`
+	WebsocketSlowConsumerPolicy string `envconfig:"WEBSOCKET_SLOW_CONSUMER_POLICY" required:"false" default:"drop_oldest"`
+
+	// Rate Limiting
+	// Requests are limited per api key, or per ip without a key, by a token bucket refilled at the rate per second
+	// A daily quota of 0 is unlimited
+	RateLimitEnabled             bool          `envconfig:"RATE_LIMIT_ENABLED" required:"false" default:"false"`
+	RateLimitAnonymousRate       float64       `envconfig:"RATE_LIMIT_ANONYMOUS_RATE" required:"false" default:"5"`
+	RateLimitAnonymousBurst      int64         `envconfig:"RATE_LIMIT_ANONYMOUS_BURST" required:"false" default:"20"`
+	RateLimitAnonymousDailyQuota int64         `envconfig:"RATE_LIMIT_ANONYMOUS_DAILY_QUOTA" required:"false" default:"0"`
+	RestProxyHeader              string        `envconfig:"REST_PROXY_HEADER" required:"false" default:""`
+	APIKeyHeader                 string        `envconfig:"API_KEY_HEADER" required:"false" default:"X-API-KEY"`
+	APIKeyQueryParam             string        `envconfig:"API_KEY_QUERY_PARAM" required:"false" default:"api_key"`
+	APIKeysFile                  string        `envconfig:"API_KEYS_FILE" required:"false" default:""`
+	APIKeysRefreshInterval       time.Duration `envconfig:"API_KEYS_REFRESH_INTERVAL" required:"false" default:"30s"`
+	AdminAPIKey                  string        `envconfig:"ADMIN_API_KEY" required:"false" default:""`
+
+	// Response Cache
+	// Responses are versioned by the latest block so a new block invalidates them
+	// Responses about blocks below the latest block can't change and are kept for the finalized ttl
+	RestCacheEnabled      bool          `envconfig:"REST_CACHE_ENABLED" required:"false" default:"true"`
+	RestCacheTTL          time.Duration `envconfig:"REST_CACHE_TTL" required:"false" default:"1m"`
+	RestCacheFinalizedTTL time.Duration `envconfig:"REST_CACHE_FINALIZED_TTL" required:"false" default:"24h"`
+
+	// Query Timeouts
+	// Queries for a request are canceled once the endpoint group's timeout passes, 0 for no timeout
+	RestQueryTimeoutBlocks       time.Duration `envconfig:"REST_QUERY_TIMEOUT_BLOCKS" required:"false" default:"5s"`
+	RestQueryTimeoutTransactions time.Duration `envconfig:"REST_QUERY_TIMEOUT_TRANSACTIONS" required:"false" default:"10s"`
+	RestQueryTimeoutAddresses    time.Duration `envconfig:"REST_QUERY_TIMEOUT_ADDRESSES" required:"false" default:"10s"`
+	RestQueryTimeoutLogs         time.Duration `envconfig:"REST_QUERY_TIMEOUT_LOGS" required:"false" default:"10s"`
+	RestQueryTimeoutWebhooks     time.Duration `envconfig:"REST_QUERY_TIMEOUT_WEBHOOKS" required:"false" default:"5s"`
+	RestQueryTimeoutAdmin        time.Duration `envconfig:"REST_QUERY_TIMEOUT_ADMIN" required:"false" default:"5s"`
+	RestQueryTimeoutSearch       time.Duration `envconfig:"REST_QUERY_TIMEOUT_SEARCH" required:"false" default:"5s"`
+	RestQueryTimeoutStats        time.Duration `envconfig:"REST_QUERY_TIMEOUT_STATS" required:"false" default:"10s"`
+	RestQueryTimeoutSupplies     time.Duration `envconfig:"REST_QUERY_TIMEOUT_SUPPLIES" required:"false" default:"5s"`
+	RestQueryTimeoutTokens       time.Duration `envconfig:"REST_QUERY_TIMEOUT_TOKENS" required:"false" default:"10s"`
+
+	// Search
+	// Names and symbols are searched from the min length, by prefix, and by word similarity from the min fuzzy length
+	// Fuzzy matches need pg_trgm on the database, see crud.SearchContracts for the indexes
+	SearchMaxLimit       int `envconfig:"SEARCH_MAX_LIMIT" required:"false" default:"25"`
+	SearchMinLength      int `envconfig:"SEARCH_MIN_LENGTH" required:"false" default:"2"`
+	SearchMinFuzzyLength int `envconfig:"SEARCH_MIN_FUZZY_LENGTH" required:"false" default:"3"`
+
+	// Webhooks
+	// Delivery attempts back off exponentially from the initial delay up to the max delay
+	// The webhook endpoints require the admin api key and aren't served without one
+	// Events are queued in memory and their deliveries inserted in batches, events past the queue size are dropped
+	WebhooksEnabled        bool          `envconfig:"WEBHOOKS_ENABLED" required:"false" default:"false"`
+	WebhookWorkers         int           `envconfig:"WEBHOOK_WORKERS" required:"false" default:"4"`
+	WebhookMaxAttempts     int64         `envconfig:"WEBHOOK_MAX_ATTEMPTS" required:"false" default:"10"`
+	WebhookBackoffInitial  time.Duration `envconfig:"WEBHOOK_BACKOFF_INITIAL" required:"false" default:"5s"`
+	WebhookBackoffMax      time.Duration `envconfig:"WEBHOOK_BACKOFF_MAX" required:"false" default:"1h"`
+	WebhookRequestTimeout  time.Duration `envconfig:"WEBHOOK_REQUEST_TIMEOUT" required:"false" default:"10s"`
+	WebhookPollInterval    time.Duration `envconfig:"WEBHOOK_POLL_INTERVAL" required:"false" default:"1s"`
+	WebhookRefreshInterval time.Duration `envconfig:"WEBHOOK_REFRESH_INTERVAL" required:"false" default:"10s"`
+	WebhookQueueSize       int           `envconfig:"WEBHOOK_QUEUE_SIZE" required:"false" default:"100000"`
+	WebhookBatchSize       int           `envconfig:"WEBHOOK_BATCH_SIZE" required:"false" default:"500"`
+
 	// GORM
 	GormLoggingThresholdMilli int `envconfig:"GORM_LOGGING_THRESHOLD_MILLI" required:"false" default:"250"`
 
@@ -87,9 +193,55 @@ type configType struct {
 	IconNodeRpcRetrySleepSeconds time.Duration `envconfig:"ICON_NODE_RPC_SLEEP_SECONDS" required:"false" default:"1s"`
 	IconNodeRpcRetryAttempts     int           `envconfig:"ICON_NODE_RPC_RETRY_ATTEMPTS" required:"false" default:"20"`
 
+	// Prices
+	// USD prices by "icx" or token contract address, the median of the providers' prices
+	// Providers are any of coingecko, json_url, static, file
+	// static prices from PRICES_USD are only used for ids no other provider has a fresh price for
+	// A provider that fails keeps its last prices until they are older than the max age
+	PricesProviders       []string      `envconfig:"PRICES_PROVIDERS" required:"false" default:"coingecko"`
+	PricesRefreshInterval time.Duration `envconfig:"PRICES_REFRESH_INTERVAL" required:"false" default:"1m"`
+	PricesRequestTimeout  time.Duration `envconfig:"PRICES_REQUEST_TIMEOUT" required:"false" default:"10s"`
+	PricesMaxAge          time.Duration `envconfig:"PRICES_MAX_AGE" required:"false" default:"15m"`
+
+	// CoinGecko ids by price id, ie icx:icon,cx88fd7df7ddff82f7cc735c871dc519838cb235bb:balanced-dollar
+	PricesCoinGeckoURL    string            `envconfig:"PRICES_COINGECKO_URL" required:"false" default:"https://api.coingecko.com/api/v3"`
+	PricesCoinGeckoAPIKey string            `envconfig:"PRICES_COINGECKO_API_KEY" required:"false" default:""`
+	PricesCoinGeckoIDs    map[string]string `envconfig:"PRICES_COINGECKO_IDS" required:"false" default:"icx:icon"`
+
+	// JSON url with prices, found by dotted paths by price id, ie icx:data.icx.usd
+	// Without paths the body is a flat object of prices by price id
+	PricesJSONURL   string            `envconfig:"PRICES_JSON_URL" required:"false" default:""`
+	PricesJSONPaths map[string]string `envconfig:"PRICES_JSON_PATHS" required:"false" default:""`
+
+	// Static prices, ie icx:0.25,cx88fd7df7ddff82f7cc735c871dc519838cb235bb:1
+	PricesUSD map[string]float64 `envconfig:"PRICES_USD" required:"false" default:""`
+
+	// JSON file of prices by price id, read on each refresh, ie {"icx": 0.25}
+	PricesFile string `envconfig:"PRICES_FILE" required:"false" default:""`
+
 	// Stats endpoints
 	StatsMarketCapUpdateTime         time.Duration `envconfig:"STATS_MARKET_CAP_UPDATE_TIME" required:"false" default:"5m"`
 	StatsCirculatingSupplyUpdateTime time.Duration `envconfig:"STATS_CIRCULATING_SUPPLY_UPDATE_TIME" required:"false" default:"5m"`
+
+	// Stats writer
+	// Writes the supply_snapshots, daily_stats and token_holder_snapshots tables, every instance migrates them at start
+	// Instances with the writer enabled elect one writer with a postgres advisory lock, checked on the interval
+	StatsWriterEnabled      bool          `envconfig:"STATS_WRITER_ENABLED" required:"false" default:"false"`
+	StatsWriterLockInterval time.Duration `envconfig:"STATS_WRITER_LOCK_INTERVAL" required:"false" default:"30s"`
+
+	// Daily stats are rolled up from the chain tables on an interval by the stats writer
+	// The latest day is rolled up again until it's over, backfill is the days before today rolled up when there are none
+	StatsDailyRollupInterval time.Duration `envconfig:"STATS_DAILY_ROLLUP_INTERVAL" required:"false" default:"1h"`
+	StatsDailyBackfillDays   int           `envconfig:"STATS_DAILY_BACKFILL_DAYS" required:"false" default:"365"`
+
+	// Supply breakdown
+	// Burned and locked wallets aren't circulating, the treasury wallet's balance is only reported, empty to leave it out
+	SupplyBurnAddresses   []string `envconfig:"SUPPLY_BURN_ADDRESSES" required:"false" default:"hx1000000000000000000000000000000000000000"`
+	SupplyLockedAddresses []string `envconfig:"SUPPLY_LOCKED_ADDRESSES" required:"false" default:""`
+	SupplyTreasuryAddress string   `envconfig:"SUPPLY_TREASURY_ADDRESS" required:"false" default:""`
+
+	// One supply snapshot is kept per interval for the supply history
+	SupplyHistoryInterval time.Duration `envconfig:"SUPPLY_HISTORY_INTERVAL" required:"false" default:"1h"`
 }
 
 // Config - runtime config struct
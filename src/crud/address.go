@@ -1,11 +1,14 @@
 package crud
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/sudoblockio/icon-go-api/models"
 )
@@ -38,9 +41,10 @@ func GetAddressCrud() *AddressCrud {
 
 // SelectOne - select one from addresses table
 func (m *AddressCrud) SelectOne(
+	ctx context.Context,
 	_address string,
 ) (*models.Address, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.Address{})
@@ -56,6 +60,7 @@ func (m *AddressCrud) SelectOne(
 
 // SelectMany - select many from addreses table
 func (m *AddressCrud) SelectMany(
+	ctx context.Context,
 	limit int,
 	skip int,
 	address string,
@@ -65,7 +70,7 @@ func (m *AddressCrud) SelectMany(
 	tokenStandard string,
 	sort string,
 ) (*[]models.AddressList, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.Address{})
@@ -125,53 +130,56 @@ func (m *AddressCrud) SelectMany(
 	return addresses, db.Error
 }
 
+// CountWithParamsSearch - count contracts from addresses table
+// Exact for small counts, estimated for large ones
 func (m *AddressCrud) CountWithParamsSearch(
+	ctx context.Context,
 	search string,
 	tokenStandard string,
 	status string,
 	isToken *bool,
 	isNft *bool,
 	isContract *bool,
-) (int64, error) {
-	db := m.db
-	db = db.Model(&models.Address{})
+) (*Count, error) {
+	return countWithStrategy(ctx, m.db, func(db *gorm.DB) *gorm.DB {
+		db = db.Model(&models.Address{})
 
-	// Support search functionality
-	if search != "" {
-		db.Where("LOWER(name) LIKE LOWER(?)", fmt.Sprintf("%%%s%%", search))
-	}
+		// Support search functionality
+		if search != "" {
+			db = db.Where("LOWER(name) LIKE LOWER(?)", fmt.Sprintf("%%%s%%", search))
+		}
 
-	if tokenStandard != "" {
-		db.Where("is_token = true")
-		db.Where("token_standard = ?", tokenStandard)
-	}
+		if tokenStandard != "" {
+			db = db.Where("is_token = true")
+			db = db.Where("token_standard = ?", tokenStandard)
+		}
 
-	if status != "" {
-		db.Where("status = ?", status)
-	}
+		if status != "" {
+			db = db.Where("status = ?", status)
+		}
 
-	if isToken != nil {
-		db = db.Where("is_token = ?", &isToken)
-	}
+		if isToken != nil {
+			db = db.Where("is_token = ?", &isToken)
+		}
 
-	if isNft != nil {
-		db = db.Where("is_nft = ?", &isNft)
-	}
+		if isNft != nil {
+			db = db.Where("is_nft = ?", &isNft)
+		}
 
-	if isContract != nil {
-		db = db.Where("is_contract = ?", &isContract)
-	}
+		if isContract != nil {
+			db = db.Where("is_contract = ?", &isContract)
+		}
 
-	// Is contract
-	db = db.Where("is_contract = true")
+		// Is contract
+		db = db.Where("is_contract = true")
 
-	var count int64
-	db = db.Count(&count)
-	return count, db.Error
+		return db
+	})
 }
 
 // SelectManyContracts - select many from addresses table
 func (m *AddressCrud) SelectManyContracts(
+	ctx context.Context,
 	search string,
 	tokenStandard string,
 	isToken *bool,
@@ -181,7 +189,7 @@ func (m *AddressCrud) SelectManyContracts(
 	skip int,
 	sort string,
 ) (*[]models.ContractList, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 	db = db.Model(&models.Address{})
 
 	// Support search functionality
@@ -242,3 +250,84 @@ func (m *AddressCrud) SelectManyContracts(
 
 	return contracts, db.Error
 }
+
+// SearchContracts - select contracts whose name or symbol starts with the query, or has a word similar to it when fuzzy
+// Exact matches come first, then prefix matches, then the most similar, then the most active contracts
+// The addresses table is the indexer's, the matches are only served by indexes when it has
+//
+//	CREATE INDEX addresses_idx_contract_lower_name ON addresses (LOWER(name) text_pattern_ops) WHERE is_contract = true;
+//	CREATE INDEX addresses_idx_contract_lower_symbol ON addresses (LOWER(symbol) text_pattern_ops) WHERE is_contract = true;
+//
+// and for fuzzy matches the pg_trgm extension with
+//
+//	CREATE EXTENSION IF NOT EXISTS pg_trgm;
+//	CREATE INDEX addresses_idx_contract_name_trgm ON addresses USING GIN (LOWER(name) gin_trgm_ops) WHERE is_contract = true;
+//	CREATE INDEX addresses_idx_contract_symbol_trgm ON addresses USING GIN (LOWER(symbol) gin_trgm_ops) WHERE is_contract = true;
+func (m *AddressCrud) SearchContracts(
+	ctx context.Context,
+	query string,
+	fuzzy bool,
+	limit int,
+) (*[]models.ContractList, error) {
+	db := m.db.WithContext(ctx)
+	db = db.Model(&models.Address{})
+
+	query = strings.ToLower(query)
+	prefix := escapeLike(query) + "%"
+
+	// Is contract
+	db = db.Where("is_contract = true")
+
+	// Name or symbol
+	// <% is true when the query is similar enough to a word of the name or symbol, see pg_trgm.word_similarity_threshold
+	if fuzzy {
+		db = db.Where(
+			"LOWER(name) LIKE ? OR LOWER(symbol) LIKE ? OR ? <% LOWER(name) OR ? <% LOWER(symbol)",
+			prefix, prefix, query, query,
+		)
+	} else {
+		db = db.Where("LOWER(name) LIKE ? OR LOWER(symbol) LIKE ?", prefix, prefix)
+	}
+
+	// Order by match, then similarity, then activity
+	order := "CASE WHEN LOWER(name) = ? OR LOWER(symbol) = ? THEN 0 " +
+		"WHEN LOWER(name) LIKE ? OR LOWER(symbol) LIKE ? THEN 1 " +
+		"ELSE 2 END, "
+	orderVars := []interface{}{query, query, prefix, prefix}
+	if fuzzy {
+		order += "GREATEST(word_similarity(?, LOWER(name)), word_similarity(?, LOWER(symbol))) DESC, "
+		orderVars = append(orderVars, query, query)
+	}
+	db = db.Clauses(clause.OrderBy{
+		Expression: clause.Expr{
+			SQL:                order + "transaction_count DESC",
+			Vars:               orderVars,
+			WithoutParentheses: true,
+		},
+	})
+
+	// Limit
+	db = db.Limit(limit)
+
+	contracts := &[]models.ContractList{}
+	db = db.Find(contracts)
+
+	return contracts, db.Error
+}
+
+// SelectManyContractsByAddresses - select contracts from addresses table by address
+func (m *AddressCrud) SelectManyContractsByAddresses(
+	ctx context.Context,
+	addresses []string,
+) (*[]models.ContractList, error) {
+	db := m.db.WithContext(ctx)
+	db = db.Model(&models.Address{})
+
+	// Addresses
+	db = db.Where("address IN ?", addresses)
+
+	contracts := &[]models.ContractList{}
+	db = db.Find(contracts)
+
+	return contracts, db.Error
+}
@@ -0,0 +1,47 @@
+package crud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestSearchContracts(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Equal(nil, err)
+
+	addressCrud := &AddressCrud{db: db}
+
+	var statement *gorm.Statement
+	db.Callback().Query().After("gorm:query").Register("test:statement", func(db *gorm.DB) {
+		statement = db.Statement
+	})
+
+	_, err = addressCrud.SearchContracts(context.Background(), "b%N_", false, 10)
+	assert.Equal(nil, err)
+
+	// Wildcards in the query match literally
+	assert.Contains(
+		statement.SQL.String(),
+		`FROM "addresses" WHERE is_contract = true AND (LOWER(name) LIKE $1 OR LOWER(symbol) LIKE $2) `+
+			`ORDER BY CASE WHEN LOWER(name) = $3 OR LOWER(symbol) = $4 THEN 0 WHEN LOWER(name) LIKE $5 OR LOWER(symbol) LIKE $6 THEN 1 ELSE 2 END, transaction_count DESC LIMIT 10`,
+	)
+	assert.Equal([]interface{}{`b\%n\_%`, `b\%n\_%`, `b%n_`, `b%n_`, `b\%n\_%`, `b\%n\_%`}, statement.Vars)
+
+	// Fuzzy matches words by trigram similarity, no leading wildcard
+	_, err = addressCrud.SearchContracts(context.Background(), "Dollar", true, 10)
+	assert.Equal(nil, err)
+
+	assert.Contains(
+		statement.SQL.String(),
+		`FROM "addresses" WHERE is_contract = true AND (LOWER(name) LIKE $1 OR LOWER(symbol) LIKE $2 OR $3 <% LOWER(name) OR $4 <% LOWER(symbol)) `+
+			`ORDER BY CASE WHEN LOWER(name) = $5 OR LOWER(symbol) = $6 THEN 0 WHEN LOWER(name) LIKE $7 OR LOWER(symbol) LIKE $8 THEN 1 ELSE 2 END, `+
+			`GREATEST(word_similarity($9, LOWER(name)), word_similarity($10, LOWER(symbol))) DESC, transaction_count DESC LIMIT 10`,
+	)
+	assert.Equal([]interface{}{"dollar%", "dollar%", "dollar", "dollar", "dollar", "dollar", "dollar%", "dollar%", "dollar", "dollar"}, statement.Vars)
+}
@@ -0,0 +1,54 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+)
+
+// AdvisoryLock - postgres session advisory lock, held on its own connection to the primary until released or the connection drops
+type AdvisoryLock struct {
+	conn *sql.Conn
+	key  int64
+}
+
+// TryAdvisoryLock - take the advisory lock for a key without waiting, nil if another session holds it
+func TryAdvisoryLock(ctx context.Context, key int64) (*AdvisoryLock, error) {
+	sqlDB, err := getPostgresConn().DB()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	locked := false
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked)
+	if err != nil || locked == false {
+		conn.Close()
+		return nil, err
+	}
+
+	return &AdvisoryLock{
+		conn: conn,
+		key:  key,
+	}, nil
+}
+
+// Check - error if the lock's connection no longer responds, the session and its lock are gone with it
+func (l *AdvisoryLock) Check(ctx context.Context) error {
+	return l.conn.PingContext(ctx)
+}
+
+// Release - release the lock and return its connection
+func (l *AdvisoryLock) Release(ctx context.Context) error {
+	_, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key)
+
+	closeErr := l.conn.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	return err
+}
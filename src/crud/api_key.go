@@ -0,0 +1,110 @@
+package crud
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// ApiKeyCrud - type for api_key table model
+type ApiKeyCrud struct {
+	db    *gorm.DB
+	model *models.ApiKey
+}
+
+var apiKeyCrud *ApiKeyCrud
+var apiKeyCrudOnce sync.Once
+
+// GetApiKeyCrud - create and/or return the api_keys table model
+func GetApiKeyCrud() *ApiKeyCrud {
+	apiKeyCrudOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		apiKeyCrud = &ApiKeyCrud{
+			db:    dbConn,
+			model: &models.ApiKey{},
+		}
+	})
+
+	return apiKeyCrud
+}
+
+// Migrate - migrate api_keys table
+// NOTE: unlike the chain tables, the api_keys table is owned by this service
+func (m *ApiKeyCrud) Migrate() error {
+	err := m.db.AutoMigrate(m.model)
+	if err != nil {
+		return err
+	}
+
+	// Lookup by key
+	err = m.db.Exec(
+		"CREATE UNIQUE INDEX IF NOT EXISTS api_keys_idx_key_hash ON api_keys (key_hash)",
+	).Error
+
+	return err
+}
+
+// InsertOne - insert one into api_keys table
+func (m *ApiKeyCrud) InsertOne(ctx context.Context, apiKey *models.ApiKey) error {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.ApiKey{})
+
+	db = db.Create(apiKey)
+
+	return db.Error
+}
+
+// SelectMany - select many from api_keys table
+func (m *ApiKeyCrud) SelectMany(
+	ctx context.Context,
+	limit int,
+	skip int,
+) (*[]models.ApiKey, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.ApiKey{})
+
+	// Oldest first
+	db = db.Order("id asc")
+
+	// Limit, 0 for all
+	if limit != 0 {
+		db = db.Limit(limit)
+	}
+
+	// Skip
+	if skip != 0 {
+		db = db.Offset(skip)
+	}
+
+	apiKeys := &[]models.ApiKey{}
+	db = db.Find(apiKeys)
+
+	return apiKeys, db.Error
+}
+
+// DeleteOne - delete one from api_keys table
+func (m *ApiKeyCrud) DeleteOne(ctx context.Context, id int64) error {
+	db := m.db.WithContext(ctx)
+
+	db = db.Where("id = ?", id).Delete(&models.ApiKey{})
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"sync"
 
 	"go.uber.org/zap"
@@ -38,6 +39,7 @@ func GetBlockCrud() *BlockCrud {
 // SelectMany - select from blocks table
 // Returns: models, error (if present)
 func (m *BlockCrud) SelectMany(
+	ctx context.Context,
 	limit int,
 	skip int,
 	number uint32,
@@ -47,7 +49,7 @@ func (m *BlockCrud) SelectMany(
 	createdBy string,
 	sort string,
 ) (*[]models.BlockList, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Latest blocks first
 	if sort != "" {
@@ -97,9 +99,10 @@ func (m *BlockCrud) SelectMany(
 
 // SelectOne - select from blocks table
 func (m *BlockCrud) SelectOne(
+	ctx context.Context,
 	number uint32,
 ) (*models.Block, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	db = db.Order("number desc")
 
@@ -113,9 +116,21 @@ func (m *BlockCrud) SelectOne(
 	return block, db.Error
 }
 
+// SelectOneByHash - select from blocks table
+func (m *BlockCrud) SelectOneByHash(ctx context.Context, hash string) (*models.Block, error) {
+	db := m.db.WithContext(ctx)
+
+	db = db.Where("hash = ?", hash)
+
+	block := &models.Block{}
+	db = db.First(block)
+
+	return block, db.Error
+}
+
 // SelectOne - select from blocks table
-func (m *BlockCrud) SelectOneByTimestamp(timestamp uint64) (*models.Block, error) {
-	db := m.db
+func (m *BlockCrud) SelectOneByTimestamp(ctx context.Context, timestamp uint64) (*models.Block, error) {
+	db := m.db.WithContext(ctx)
 
 	block := &models.Block{}
 	db.Raw("SELECT * FROM blocks WHERE timestamp < ? ORDER BY timestamp DESC LIMIT 1;", timestamp).Scan(&block)
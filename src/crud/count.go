@@ -0,0 +1,85 @@
+package crud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/config"
+)
+
+// Count - number of rows matching a filter
+// Exact is false when the count is the query planner's estimate
+type Count struct {
+	Count int64 `json:"count"`
+	Exact bool  `json:"exact"`
+}
+
+// countFilter - applies a query's table and filters to db
+type countFilter func(db *gorm.DB) *gorm.DB
+
+// countWithStrategy - exact count when the planner estimates fewer rows than the threshold, otherwise the estimate
+// An exact count that runs out of time falls back to the estimate
+func countWithStrategy(ctx context.Context, db *gorm.DB, filter countFilter) (*Count, error) {
+	estimate, err := estimateCount(ctx, db, filter)
+	if err != nil {
+		return nil, err
+	}
+	if estimate >= config.Config.CountExactThreshold {
+		return &Count{Count: estimate, Exact: false}, nil
+	}
+
+	exactCtx, cancel := context.WithTimeout(ctx, config.Config.CountExactTimeout)
+	defer cancel()
+
+	count := int64(0)
+	err = filter(db.WithContext(exactCtx)).Count(&count).Error
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(exactCtx.Err(), context.DeadlineExceeded) {
+			// Only the exact count ran out of time
+			return &Count{Count: estimate, Exact: false}, nil
+		}
+		return nil, err
+	}
+
+	return &Count{Count: count, Exact: true}, nil
+}
+
+// estimateCount - rows the query planner expects the filter to return
+// Based on the table's statistics, so it is fast but can be off
+func estimateCount(ctx context.Context, db *gorm.DB, filter countFilter) (int64, error) {
+	db = db.WithContext(ctx)
+
+	plan := ""
+	err := explainQuery(db, filter).Scan(&plan).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return parsePlanRows(plan)
+}
+
+// explainQuery - EXPLAIN of selecting the filter's rows, filter values stay bound parameters
+func explainQuery(db *gorm.DB, filter countFilter) *gorm.DB {
+	return db.Raw("EXPLAIN (FORMAT JSON) ?", filter(db).Select("*"))
+}
+
+// parsePlanRows - estimated rows of the top node of an EXPLAIN (FORMAT JSON) plan
+func parsePlanRows(plan string) (int64, error) {
+	explain := []struct {
+		Plan struct {
+			PlanRows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}{}
+	err := json.Unmarshal([]byte(plan), &explain)
+	if err != nil {
+		return 0, err
+	}
+	if len(explain) == 0 {
+		return 0, errors.New("empty query plan")
+	}
+
+	return int64(explain[0].Plan.PlanRows), nil
+}
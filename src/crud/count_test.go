@@ -0,0 +1,40 @@
+package crud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func TestExplainQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Equal(nil, err)
+
+	explain := explainQuery(db, func(db *gorm.DB) *gorm.DB {
+		return db.Model(&[]models.Transaction{}).Where("from_address = ?", "hx1' OR 1=1").Where("block_number >= ?", 10)
+	}).Scan(new(string))
+
+	// Filter values are parameters, not interpolated
+	assert.Equal(`EXPLAIN (FORMAT JSON) SELECT * FROM "transactions" WHERE from_address = $1 AND block_number >= $2`, explain.Statement.SQL.String())
+	assert.Equal([]interface{}{"hx1' OR 1=1", 10}, explain.Statement.Vars)
+}
+
+func TestParsePlanRows(t *testing.T) {
+	assert := assert.New(t)
+
+	rows, err := parsePlanRows(`[{"Plan": {"Node Type": "Index Scan", "Plan Rows": 123456, "Plan Width": 100}}]`)
+	assert.Equal(nil, err)
+	assert.Equal(int64(123456), rows)
+
+	_, err = parsePlanRows(`[]`)
+	assert.NotEqual(nil, err)
+
+	_, err = parsePlanRows(`not json`)
+	assert.NotEqual(nil, err)
+}
@@ -0,0 +1,55 @@
+package crud
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// Cursor - keyset pagination position, taken from the last row of a page
+// Rows are keyed by (block_number, transaction_index, log_index), logs by the index of their transaction
+// Sort is the direction of the page the cursor was taken from, a cursor only continues in that direction
+type Cursor struct {
+	BlockNumber      int64  `json:"b"`
+	TransactionIndex int64  `json:"t,omitempty"`
+	LogIndex         int64  `json:"l"`
+	Sort             string `json:"s,omitempty"`
+}
+
+// Encode - serialize cursor into an opaque url safe string
+func (c *Cursor) Encode() string {
+	cursorJSON, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(cursorJSON)
+}
+
+// Less - true if the cursor's row comes before the other cursor's row in ascending order
+func (c *Cursor) Less(other *Cursor) bool {
+	if c.BlockNumber != other.BlockNumber {
+		return c.BlockNumber < other.BlockNumber
+	}
+	if c.TransactionIndex != other.TransactionIndex {
+		return c.TransactionIndex < other.TransactionIndex
+	}
+	return c.LogIndex < other.LogIndex
+}
+
+// DecodeCursor - parse an opaque cursor string
+func DecodeCursor(cursorString string) (*Cursor, error) {
+	cursorJSON, err := base64.RawURLEncoding.DecodeString(cursorString)
+	if err != nil {
+		return nil, errors.New("invalid cursor encoding")
+	}
+
+	cursor := &Cursor{}
+	err = json.Unmarshal(cursorJSON, cursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor value")
+	}
+
+	if cursor.BlockNumber < 0 {
+		return nil, errors.New("invalid cursor block number")
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,58 @@
+package crud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorEncodeDecode(t *testing.T) {
+	assert := assert.New(t)
+
+	cursor := &Cursor{
+		BlockNumber:      12345678,
+		TransactionIndex: 3,
+		LogIndex:         -1,
+	}
+
+	decodedCursor, err := DecodeCursor(cursor.Encode())
+	assert.Equal(nil, err)
+	assert.Equal(cursor, decodedCursor)
+
+	ascCursor := &Cursor{
+		BlockNumber:      12345678,
+		TransactionIndex: 0,
+		LogIndex:         2,
+		Sort:             "asc",
+	}
+
+	decodedCursor, err = DecodeCursor(ascCursor.Encode())
+	assert.Equal(nil, err)
+	assert.Equal(ascCursor, decodedCursor)
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := DecodeCursor("not a cursor")
+	assert.NotEqual(nil, err)
+
+	_, err = DecodeCursor("bm90IGpzb24")
+	assert.NotEqual(nil, err)
+
+	negativeCursor := &Cursor{BlockNumber: -1}
+	_, err = DecodeCursor(negativeCursor.Encode())
+	assert.NotEqual(nil, err)
+}
+
+func TestCursorLess(t *testing.T) {
+	assert := assert.New(t)
+
+	cursor := &Cursor{BlockNumber: 10, TransactionIndex: 2, LogIndex: 1}
+
+	assert.Equal(true, cursor.Less(&Cursor{BlockNumber: 11}))
+	assert.Equal(true, cursor.Less(&Cursor{BlockNumber: 10, TransactionIndex: 3, LogIndex: -1}))
+	assert.Equal(true, cursor.Less(&Cursor{BlockNumber: 10, TransactionIndex: 2, LogIndex: 2}))
+	assert.Equal(false, cursor.Less(&Cursor{BlockNumber: 10, TransactionIndex: 2, LogIndex: 1}))
+	assert.Equal(false, cursor.Less(&Cursor{BlockNumber: 9, TransactionIndex: 5, LogIndex: 5}))
+}
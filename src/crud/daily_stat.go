@@ -0,0 +1,196 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// Daily stat metrics
+const (
+	DailyStatMetricTransactionCount   = "transaction_count"
+	DailyStatMetricActiveAddresses    = "active_addresses"
+	DailyStatMetricNewAddresses       = "new_addresses"
+	DailyStatMetricNewContracts       = "new_contracts"
+	DailyStatMetricTotalFees          = "total_fees"
+	DailyStatMetricIcxVolume          = "icx_volume"
+	DailyStatMetricTokenTransferCount = "token_transfer_count"
+)
+
+// DailyStatMetrics - every metric, in the order they are rolled up
+var DailyStatMetrics = []string{
+	DailyStatMetricTransactionCount,
+	DailyStatMetricActiveAddresses,
+	DailyStatMetricNewAddresses,
+	DailyStatMetricNewContracts,
+	DailyStatMetricTotalFees,
+	DailyStatMetricIcxVolume,
+	DailyStatMetricTokenTransferCount,
+}
+
+// DailyStatCrud - type for daily_stat table model
+type DailyStatCrud struct {
+	db    *gorm.DB
+	model *models.DailyStat
+}
+
+var dailyStatCrud *DailyStatCrud
+var dailyStatCrudOnce sync.Once
+
+// GetDailyStatCrud - create and/or return the daily_stats table model
+func GetDailyStatCrud() *DailyStatCrud {
+	dailyStatCrudOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		dailyStatCrud = &DailyStatCrud{
+			db:    dbConn,
+			model: &models.DailyStat{},
+		}
+	})
+
+	return dailyStatCrud
+}
+
+// Migrate - migrate daily_stats table
+// NOTE: unlike the chain tables, the daily_stats table is owned by this service
+func (m *DailyStatCrud) Migrate() error {
+	err := m.db.AutoMigrate(m.model)
+	if err != nil {
+		return err
+	}
+
+	// One value per metric per day, rolling up a day again replaces it
+	err = m.db.Exec(
+		"CREATE UNIQUE INDEX IF NOT EXISTS daily_stats_idx_metric_date ON daily_stats (metric, date)",
+	).Error
+
+	return err
+}
+
+// UpsertMany - insert into daily_stats table, replacing the values of days already rolled up
+func (m *DailyStatCrud) UpsertMany(ctx context.Context, dailyStats []models.DailyStat) error {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.DailyStat{})
+
+	db = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "metric"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_timestamp"}),
+	}).Create(&dailyStats)
+
+	return db.Error
+}
+
+// SelectMany - select a metric's days from daily_stats table, oldest first
+// Dates are YYYY-MM-DD so they compare in order as strings
+func (m *DailyStatCrud) SelectMany(
+	ctx context.Context,
+	metric string,
+	startDate string,
+	endDate string,
+) (*[]models.DailyStat, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.DailyStat{})
+
+	// Oldest days first
+	db = db.Order("date asc")
+
+	// Metric
+	db = db.Where("metric = ?", metric)
+
+	// Dates
+	db = db.Where("date >= ? AND date <= ?", startDate, endDate)
+
+	dailyStats := &[]models.DailyStat{}
+	db = db.Find(dailyStats)
+
+	return dailyStats, db.Error
+}
+
+// SelectLatestDate - latest day rolled up, empty if none are
+func (m *DailyStatCrud) SelectLatestDate(ctx context.Context) (string, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.DailyStat{})
+
+	var date string
+	db = db.Select("COALESCE(MAX(date), '')").Scan(&date)
+
+	return date, db.Error
+}
+
+// SelectMetric - aggregate a metric from the chain tables between two block timestamps
+// Timestamps are microsecond epochs, start inclusive and end exclusive
+func (m *DailyStatCrud) SelectMetric(
+	ctx context.Context,
+	metric string,
+	startTimestamp int64,
+	endTimestamp int64,
+) (float64, error) {
+	db := m.db.WithContext(ctx)
+
+	switch metric {
+	case DailyStatMetricTransactionCount:
+		db = db.Table("transactions").
+			Select("COUNT(*)").
+			Where("type = ?", "transaction").
+			Where("block_timestamp >= ? AND block_timestamp < ?", startTimestamp, endTimestamp)
+	case DailyStatMetricActiveAddresses:
+		// Senders and receivers of regular transactions
+		db = db.Raw(`SELECT COUNT(DISTINCT address) FROM (
+		SELECT from_address AS address FROM transactions
+		WHERE type = ? AND block_timestamp >= ? AND block_timestamp < ?
+		UNION ALL
+		SELECT to_address AS address FROM transactions
+		WHERE type = ? AND block_timestamp >= ? AND block_timestamp < ?
+	) AS active WHERE address IS NOT NULL AND address != ''`,
+			"transaction", startTimestamp, endTimestamp,
+			"transaction", startTimestamp, endTimestamp,
+		)
+	case DailyStatMetricNewAddresses, DailyStatMetricNewContracts:
+		db = db.Table("addresses").
+			Select("COUNT(*)").
+			Where("is_contract = ?", metric == DailyStatMetricNewContracts).
+			Where("created_timestamp >= ? AND created_timestamp < ?", startTimestamp, endTimestamp)
+	case DailyStatMetricTotalFees:
+		// Fees are only stored as hex amounts in loop, there is no decimal fee column
+		db = db.Table("transactions").
+			Select("COALESCE(SUM("+smallHexToNumeric("transaction_fee")+"), 0) / 1e18").
+			Where("type = ?", "transaction").
+			Where("transaction_fee LIKE ?", "0x%").
+			Where("block_timestamp >= ? AND block_timestamp < ?", startTimestamp, endTimestamp)
+	case DailyStatMetricIcxVolume:
+		// ICX moved by successful regular transactions
+		db = db.Table("transactions").
+			Select("COALESCE(SUM(value_decimal), 0)").
+			Where("type = ?", "transaction").
+			Where("status = ?", "0x1").
+			Where("block_timestamp >= ? AND block_timestamp < ?", startTimestamp, endTimestamp)
+	case DailyStatMetricTokenTransferCount:
+		// Stands in for a token transfer volume, amounts of different tokens can't be summed
+		// so transfers of every token are counted
+		db = db.Table("token_transfers").
+			Select("COUNT(*)").
+			Where("block_timestamp >= ? AND block_timestamp < ?", startTimestamp, endTimestamp)
+	default:
+		return 0, errors.New("unknown metric: " + metric)
+	}
+
+	var value float64
+	db = db.Scan(&value)
+
+	return value, db.Error
+}
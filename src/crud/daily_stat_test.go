@@ -0,0 +1,78 @@
+package crud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func TestDailyStatSelectMetric(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Equal(nil, err)
+
+	dailyStatCrud := &DailyStatCrud{db: db}
+
+	var statement *gorm.Statement
+	db.Callback().Row().After("gorm:row").Register("test:statement", func(db *gorm.DB) {
+		statement = db.Statement
+	})
+
+	// Scanning rows isn't supported in dry run mode, the statement is still built
+	_, _ = dailyStatCrud.SelectMetric(context.Background(), DailyStatMetricIcxVolume, 100, 200)
+	assert.Equal(
+		`SELECT COALESCE(SUM(value_decimal), 0) FROM "transactions" `+
+			`WHERE type = $1 AND status = $2 AND (block_timestamp >= $3 AND block_timestamp < $4)`,
+		statement.SQL.String(),
+	)
+	assert.Equal([]interface{}{"transaction", "0x1", int64(100), int64(200)}, statement.Vars)
+
+	// Fees summed exactly from hex, they can be larger than a bigint
+	_, _ = dailyStatCrud.SelectMetric(context.Background(), DailyStatMetricTotalFees, 100, 200)
+	assert.Equal(
+		`SELECT COALESCE(SUM(`+smallHexToNumeric("transaction_fee")+`), 0) / 1e18 FROM "transactions" `+
+			`WHERE type = $1 AND transaction_fee LIKE $2 AND (block_timestamp >= $3 AND block_timestamp < $4)`,
+		statement.SQL.String(),
+	)
+	assert.Equal([]interface{}{"transaction", "0x%", int64(100), int64(200)}, statement.Vars)
+
+	_, _ = dailyStatCrud.SelectMetric(context.Background(), DailyStatMetricNewContracts, 100, 200)
+	assert.Equal(
+		`SELECT COUNT(*) FROM "addresses" `+
+			`WHERE is_contract = $1 AND (created_timestamp >= $2 AND created_timestamp < $3)`,
+		statement.SQL.String(),
+	)
+	assert.Equal([]interface{}{true, int64(100), int64(200)}, statement.Vars)
+
+	_, err = dailyStatCrud.SelectMetric(context.Background(), "unknown", 100, 200)
+	assert.NotEqual(nil, err)
+}
+
+func TestDailyStatUpsertMany(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	assert.Equal(nil, err)
+
+	dailyStatCrud := &DailyStatCrud{db: db}
+
+	var statement *gorm.Statement
+	db.Callback().Create().After("gorm:create").Register("test:statement", func(db *gorm.DB) {
+		statement = db.Statement
+	})
+
+	err = dailyStatCrud.UpsertMany(context.Background(), []models.DailyStat{
+		{Date: "2022-06-30", Metric: DailyStatMetricTransactionCount, Value: 10, UpdatedTimestamp: 1},
+	})
+	assert.Equal(nil, err)
+	assert.Contains(
+		statement.SQL.String(),
+		`ON CONFLICT ("metric","date") DO UPDATE SET "value"="excluded"."value","updated_timestamp"="excluded"."updated_timestamp"`,
+	)
+}
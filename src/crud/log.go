@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"sync"
 
 	"go.uber.org/zap"
@@ -35,54 +36,91 @@ func GetLogCrud() *LogCrud {
 	return logCrud
 }
 
+// logTransactionIndex - SQL expression for the transaction index of a log's transaction
+// Logs whose transaction isn't indexed yet are still listed, first in their block
+const logTransactionIndex = "COALESCE(transactions.transaction_index, -1)"
+
+// logRow - log with the transaction index of its transaction, used for ordering
+type logRow struct {
+	models.Log
+	TransactionIndex int64
+}
+
 // Select - select from logs table
-// Returns: models, error (if present)
+// Logs have no transaction index of their own, it is joined from the transaction so logs page like transactions
+// Returns: models, cursor of the last log, error (if present)
 func (m *LogCrud) SelectMany(
+	ctx context.Context,
 	limit int,
 	skip int,
+	cursor *Cursor,
 	blockNumber uint32,
 	blockStart uint32,
 	blockEnd uint32,
 	transactionHash string,
 	scoreAddress string,
 	method string,
-) (*[]models.Log, error) {
-	db := m.db
+	sort string,
+) (*[]models.Log, *Cursor, error) {
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.Log{})
 
+	// Transaction index
+	db = db.Select("logs.*, " + logTransactionIndex + " AS transaction_index")
+	db = db.Joins("LEFT JOIN transactions ON transactions.hash = logs.transaction_hash AND transactions.log_index = -1")
+
 	// Latest logs first
-	db = db.Order("block_number desc")
+	if sort != "asc" {
+		sort = "desc"
+	}
+	db = db.Order("logs.block_number " + sort + ", " + logTransactionIndex + ", logs.log_index")
+
+	// Cursor
+	// Rows after the last row of the previous page, matching the sort order above
+	if cursor != nil {
+		if sort == "asc" {
+			db = db.Where(
+				"(logs.block_number > ? OR (logs.block_number = ? AND ("+logTransactionIndex+", logs.log_index) > (?, ?)))",
+				cursor.BlockNumber, cursor.BlockNumber, cursor.TransactionIndex, cursor.LogIndex,
+			)
+		} else {
+			db = db.Where(
+				"(logs.block_number < ? OR (logs.block_number = ? AND ("+logTransactionIndex+", logs.log_index) > (?, ?)))",
+				cursor.BlockNumber, cursor.BlockNumber, cursor.TransactionIndex, cursor.LogIndex,
+			)
+		}
+	}
 
 	// Number
 	if blockNumber != 0 {
-		db = db.Where("block_number = ?", blockNumber)
+		db = db.Where("logs.block_number = ?", blockNumber)
 	}
 
 	// Number Start
 	if blockStart != 0 {
-		db = db.Where("block_number >= ?", blockStart)
+		db = db.Where("logs.block_number >= ?", blockStart)
 	}
 
 	// Number End
 	if blockEnd != 0 {
-		db = db.Where("block_number <= ?", blockEnd)
+		db = db.Where("logs.block_number <= ?", blockEnd)
 	}
 
 	// Hash
 	if transactionHash != "" {
-		db = db.Where("transaction_hash = ?", transactionHash)
+		db = db.Where("logs.transaction_hash = ?", transactionHash)
 	}
 
 	// Address
 	if scoreAddress != "" {
-		db = db.Where("address = ?", scoreAddress)
+		db = db.Where("logs.address = ?", scoreAddress)
 	}
 
 	// Method
 	if method != "" {
-		db = db.Where("method = ?", method)
+		db = db.Where("logs.method = ?", method)
 	}
 
 	// Limit is required and defaulted to 1
@@ -93,8 +131,24 @@ func (m *LogCrud) SelectMany(
 		db = db.Offset(skip)
 	}
 
-	logs := &[]models.Log{}
-	db = db.Find(logs)
+	rows := &[]logRow{}
+	db = db.Find(rows)
+
+	logs := make([]models.Log, len(*rows))
+	for i, row := range *rows {
+		logs[i] = row.Log
+	}
+
+	var lastCursor *Cursor
+	if len(*rows) > 0 {
+		lastRow := (*rows)[len(*rows)-1]
+		lastCursor = &Cursor{
+			BlockNumber:      lastRow.BlockNumber,
+			TransactionIndex: lastRow.TransactionIndex,
+			LogIndex:         lastRow.LogIndex,
+			Sort:             sort,
+		}
+	}
 
-	return logs, db.Error
+	return &logs, lastCursor, db.Error
 }
@@ -0,0 +1,37 @@
+package crud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestLogSelectManyCursor(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Equal(nil, err)
+
+	logCrud := &LogCrud{db: db}
+
+	var statement *gorm.Statement
+	db.Callback().Query().After("gorm:query").Register("test:statement", func(db *gorm.DB) {
+		statement = db.Statement
+	})
+
+	cursor := &Cursor{BlockNumber: 100, TransactionIndex: 2, LogIndex: 1, Sort: "asc"}
+	_, _, _ = logCrud.SelectMany(context.Background(), 25, 0, cursor, 0, 0, 0, "", "cx1", "", "asc")
+
+	assert.Equal(
+		`SELECT logs.*, COALESCE(transactions.transaction_index, -1) AS transaction_index FROM "logs" `+
+			`LEFT JOIN transactions ON transactions.hash = logs.transaction_hash AND transactions.log_index = -1 `+
+			`WHERE ((logs.block_number > $1 OR (logs.block_number = $2 AND (COALESCE(transactions.transaction_index, -1), logs.log_index) > ($3, $4)))) `+
+			`AND logs.address = $5 `+
+			`ORDER BY logs.block_number asc, COALESCE(transactions.transaction_index, -1), logs.log_index LIMIT 25`,
+		statement.SQL.String(),
+	)
+	assert.Equal([]interface{}{int64(100), int64(100), int64(2), int64(1), "cx1"}, statement.Vars)
+}
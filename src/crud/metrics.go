@@ -0,0 +1,62 @@
+package crud
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/metrics"
+)
+
+const metricsStartKey = "metrics:start"
+
+// registerMetricsCallbacks - record query duration and errors by table and operation
+func registerMetricsCallbacks(db *gorm.DB) error {
+	callback := db.Callback()
+
+	for _, err := range []error{
+		callback.Query().Before("gorm:query").Register("metrics:before_query", metricsBefore),
+		callback.Query().After("gorm:query").Register("metrics:after_query", metricsAfter("query")),
+		callback.Row().Before("gorm:row").Register("metrics:before_row", metricsBefore),
+		callback.Row().After("gorm:row").Register("metrics:after_row", metricsAfter("row")),
+		callback.Raw().Before("gorm:raw").Register("metrics:before_raw", metricsBefore),
+		callback.Raw().After("gorm:raw").Register("metrics:after_raw", metricsAfter("raw")),
+		callback.Create().Before("gorm:create").Register("metrics:before_create", metricsBefore),
+		callback.Create().After("gorm:create").Register("metrics:after_create", metricsAfter("create")),
+		callback.Update().Before("gorm:update").Register("metrics:before_update", metricsBefore),
+		callback.Update().After("gorm:update").Register("metrics:after_update", metricsAfter("update")),
+		callback.Delete().Before("gorm:delete").Register("metrics:before_delete", metricsBefore),
+		callback.Delete().After("gorm:delete").Register("metrics:after_delete", metricsAfter("delete")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func metricsBefore(db *gorm.DB) {
+	db.InstanceSet(metricsStartKey, time.Now())
+}
+
+func metricsAfter(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		start, ok := db.InstanceGet(metricsStartKey)
+		if ok == false {
+			return
+		}
+
+		// Raw queries have no table
+		table := db.Statement.Table
+		if table == "" {
+			table = "raw"
+		}
+
+		metrics.DBQueryDurationHistogram.WithLabelValues(table, operation).Observe(time.Since(start.(time.Time)).Seconds())
+		if db.Error != nil && errors.Is(db.Error, gorm.ErrRecordNotFound) == false {
+			metrics.DBQueryErrorsCounter.WithLabelValues(table, operation).Inc()
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package crud
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/metrics"
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func TestMetricsCallbacks(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	assert.Equal(nil, err)
+
+	err = registerMetricsCallbacks(db)
+	assert.Equal(nil, err)
+
+	db.Where("number = ?", 1).Find(&[]models.Block{})
+	db.Where("number = ?", 2).Find(&[]models.Block{})
+	db.Create(&models.Webhook{Url: "http://localhost"})
+
+	// blocks query and webhooks create
+	assert.Equal(2, testutil.CollectAndCount(metrics.DBQueryDurationHistogram))
+	assert.Equal(0, testutil.CollectAndCount(metrics.DBQueryErrorsCounter))
+}
@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -43,6 +44,12 @@ func getPostgresConn() *gorm.DB {
 		}
 
 		zap.S().Info("Successful connection to postgres")
+
+		// Reads are sent to replicas when configured
+		err = startReplicaRouter(postgresSession)
+		if err != nil {
+			zap.S().Fatal("Cannot set up postgres replicas", err)
+		}
 	})
 
 	return postgresSession
@@ -86,5 +93,26 @@ func createSession(dsn string) (*gorm.DB, error) {
 	sqlDB.SetMaxIdleConns(config.Config.DbMaxIdleConnections)
 	sqlDB.SetMaxOpenConns(config.Config.DbMaxOpenConnections)
 
+	// Query metrics and spans
+	if err == nil {
+		err = registerMetricsCallbacks(db)
+	}
+	if err == nil {
+		err = registerTracingCallbacks(db)
+	}
+
 	return db, err
 }
+
+// PingPostgres - check the postgres connection responds within the timeout
+func PingPostgres(timeout time.Duration) error {
+	sqlDB, err := getPostgresConn().DB()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return sqlDB.PingContext(ctx)
+}
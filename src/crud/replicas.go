@@ -0,0 +1,295 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/metrics"
+)
+
+const (
+	primaryPoolName = "primary"
+	replicaPoolKey  = "replicas:pool"
+)
+
+type primaryContextKey struct{}
+
+// WithPrimary - send the context's queries to the primary
+// For reads that have to see a write made just before, replicas can be behind
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryContextKey{}, true)
+}
+
+// replicaPool - connection pool to a read replica
+type replicaPool struct {
+	name   string
+	sqlDB  *sql.DB
+	weight int
+
+	// Smooth weighted round-robin state, guarded by the router
+	currentWeight int
+
+	// Set by checks, 1 if the replica responds
+	healthy int32
+
+	// Set by checks, blocks behind the primary
+	lag int64
+}
+
+// replicaRouter - spreads reads over the replica pools
+type replicaRouter struct {
+	primary  *sql.DB
+	replicas []*replicaPool
+	maxLag   int64
+
+	mux sync.Mutex
+}
+
+// startReplicaRouter - route reads on db to the configured replicas, checking them in the background
+func startReplicaRouter(db *gorm.DB) error {
+	primary, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	router := &replicaRouter{
+		primary: primary,
+		maxLag:  config.Config.DbReplicaMaxLag,
+	}
+
+	for i, dsn := range config.Config.DbReplicaDSNs {
+		weight := 1
+		if i < len(config.Config.DbReplicaWeights) {
+			weight = config.Config.DbReplicaWeights[i]
+		}
+		if weight < 1 {
+			// Listed but not meant to take reads
+			continue
+		}
+
+		pool, err := openReplicaPool(dsn, weight)
+		if err != nil {
+			return err
+		}
+		router.replicas = append(router.replicas, pool)
+	}
+
+	// Replicas take reads once they have passed a check
+	router.check()
+	go func() {
+		for {
+			time.Sleep(config.Config.DbReplicaCheckInterval)
+			router.check()
+		}
+	}()
+
+	zap.S().Info("Started postgres replica router with ", len(router.replicas), " replicas")
+
+	return registerReplicaCallbacks(db, router)
+}
+
+// openReplicaPool - connection pool to a replica, named by its host and port so the dsn's password isn't exposed
+func openReplicaPool(dsn string, weight int) (*replicaPool, error) {
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, errors.New("invalid replica dsn: " + err.Error())
+	}
+
+	sqlDB := stdlib.OpenDB(*connConfig)
+	sqlDB.SetMaxIdleConns(config.Config.DbMaxIdleConnections)
+	sqlDB.SetMaxOpenConns(config.Config.DbMaxOpenConnections)
+
+	return &replicaPool{
+		name:   connConfig.Host + ":" + strconv.Itoa(int(connConfig.Port)),
+		sqlDB:  sqlDB,
+		weight: weight,
+	}, nil
+}
+
+// registerReplicaCallbacks - send reads to a replica picked by the router
+func registerReplicaCallbacks(db *gorm.DB, router *replicaRouter) error {
+	callback := db.Callback()
+
+	for _, err := range []error{
+		callback.Query().Before("gorm:query").Register("replicas:before_query", router.route),
+		callback.Query().After("gorm:query").Register("replicas:after_query", router.after),
+		callback.Row().Before("gorm:row").Register("replicas:before_row", router.route),
+		callback.Row().After("gorm:row").Register("replicas:after_row", router.after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// route - switch a read's connection pool to the next replica
+// Writes, transactions, and WithPrimary contexts stay on the primary
+func (r *replicaRouter) route(db *gorm.DB) {
+	if isReadStatement(db.Statement.SQL.String()) == false {
+		return
+	}
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return
+	}
+	if primary, _ := db.Statement.Context.Value(primaryContextKey{}).(bool); primary {
+		metrics.DBPoolQueriesCounter.WithLabelValues(primaryPoolName).Inc()
+		return
+	}
+
+	pool := r.next()
+	if pool == nil {
+		// No replica available
+		metrics.DBPoolQueriesCounter.WithLabelValues(primaryPoolName).Inc()
+		return
+	}
+
+	db.Statement.ConnPool = pool.sqlDB
+	db.InstanceSet(replicaPoolKey, pool)
+	metrics.DBPoolQueriesCounter.WithLabelValues(pool.name).Inc()
+}
+
+// after - take a replica out of rotation until the next check when its connection fails
+func (r *replicaRouter) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(replicaPoolKey)
+	if ok == false {
+		return
+	}
+	pool := value.(*replicaPool)
+
+	if db.Error != nil && isConnectionError(db.Error) {
+		if atomic.SwapInt32(&pool.healthy, 0) == 1 {
+			zap.S().Warn("Replicas: Replica down POOL=", pool.name, " ERROR=", db.Error.Error())
+			metrics.DBPoolHealthyGauge.WithLabelValues(pool.name).Set(0)
+		}
+	}
+}
+
+// next - smooth weighted round-robin over the available replicas, nil if there are none
+func (r *replicaRouter) next() *replicaPool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	var best *replicaPool
+	totalWeight := 0
+	for _, pool := range r.replicas {
+		if r.available(pool) == false {
+			continue
+		}
+
+		pool.currentWeight += pool.weight
+		totalWeight += pool.weight
+		if best == nil || pool.currentWeight > best.currentWeight {
+			best = pool
+		}
+	}
+	if best != nil {
+		best.currentWeight -= totalWeight
+	}
+
+	return best
+}
+
+// available - replica responds and isn't too far behind the primary
+func (r *replicaRouter) available(pool *replicaPool) bool {
+	return atomic.LoadInt32(&pool.healthy) == 1 && atomic.LoadInt64(&pool.lag) <= r.maxLag
+}
+
+// check - ping each replica and compare its latest block with the primary's
+func (r *replicaRouter) check() {
+	recordPoolStats(primaryPoolName, r.primary)
+	if len(r.replicas) == 0 {
+		return
+	}
+
+	primaryBlockNumber, primaryErr := latestBlockNumber(r.primary)
+	if primaryErr != nil {
+		// Replica lag is kept from the last check
+		zap.S().Warn("Replicas: Unable to get primary block number ERROR=", primaryErr.Error())
+	}
+
+	for _, pool := range r.replicas {
+		recordPoolStats(pool.name, pool.sqlDB)
+
+		blockNumber, err := latestBlockNumber(pool.sqlDB)
+		if err != nil {
+			if atomic.SwapInt32(&pool.healthy, 0) == 1 {
+				zap.S().Warn("Replicas: Replica down POOL=", pool.name, " ERROR=", err.Error())
+			}
+		} else {
+			if atomic.SwapInt32(&pool.healthy, 1) == 0 {
+				zap.S().Info("Replicas: Replica up POOL=", pool.name)
+			}
+			if primaryErr == nil {
+				lag := primaryBlockNumber - blockNumber
+				if lag < 0 {
+					lag = 0
+				}
+				atomic.StoreInt64(&pool.lag, lag)
+			}
+		}
+
+		metrics.DBPoolLagGauge.WithLabelValues(pool.name).Set(float64(atomic.LoadInt64(&pool.lag)))
+		if r.available(pool) {
+			metrics.DBPoolHealthyGauge.WithLabelValues(pool.name).Set(1)
+		} else {
+			metrics.DBPoolHealthyGauge.WithLabelValues(pool.name).Set(0)
+		}
+	}
+}
+
+// latestBlockNumber - highest block number in the pool's blocks table, doubles as a ping
+func latestBlockNumber(sqlDB *sql.DB) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.Config.DbReplicaCheckTimeout)
+	defer cancel()
+
+	blockNumber := int64(0)
+	err := sqlDB.QueryRowContext(ctx, "SELECT COALESCE(MAX(number), 0) FROM blocks").Scan(&blockNumber)
+
+	return blockNumber, err
+}
+
+func recordPoolStats(name string, sqlDB *sql.DB) {
+	stats := sqlDB.Stats()
+	metrics.DBPoolConnectionsGauge.WithLabelValues(name, "in_use").Set(float64(stats.InUse))
+	metrics.DBPoolConnectionsGauge.WithLabelValues(name, "idle").Set(float64(stats.Idle))
+}
+
+// isReadStatement - statement built by gorm's query callbacks, or a raw SELECT
+// Raw statements like UPDATE ... RETURNING also go through the row callback
+func isReadStatement(sql string) bool {
+	if sql == "" {
+		return true
+	}
+
+	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(sql)), "SELECT")
+}
+
+// isConnectionError - the replica couldn't be reached, as opposed to the query failing
+func isConnectionError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) || pgconn.Timeout(err) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || pgconn.SafeToRetry(err) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
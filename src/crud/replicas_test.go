@@ -0,0 +1,107 @@
+package crud
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/metrics"
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func newTestReplicaPool(name string, weight int) *replicaPool {
+	return &replicaPool{
+		name:    name,
+		weight:  weight,
+		healthy: 1,
+	}
+}
+
+func TestReplicaRouterNext(t *testing.T) {
+	assert := assert.New(t)
+
+	a := newTestReplicaPool("a", 3)
+	b := newTestReplicaPool("b", 1)
+	router := &replicaRouter{
+		replicas: []*replicaPool{a, b},
+		maxLag:   20,
+	}
+
+	// Weighted, and spread out rather than in bursts
+	picks := []string{}
+	for i := 0; i < 8; i++ {
+		picks = append(picks, router.next().name)
+	}
+	assert.Equal([]string{"a", "a", "b", "a", "a", "a", "b", "a"}, picks)
+
+	// Down
+	a.healthy = 0
+	assert.Equal("b", router.next().name)
+	assert.Equal("b", router.next().name)
+
+	// Lagging
+	b.lag = 21
+	assert.Nil(router.next())
+
+	a.healthy = 1
+	assert.Equal("a", router.next().name)
+}
+
+func TestReplicaCallbacks(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	assert.Equal(nil, err)
+
+	connConfig, _ := pgx.ParseConfig("host=localhost")
+	replica := newTestReplicaPool("replica-test:5432", 1)
+	replica.sqlDB = stdlib.OpenDB(*connConfig)
+
+	router := &replicaRouter{
+		replicas: []*replicaPool{replica},
+		maxLag:   20,
+	}
+	err = registerReplicaCallbacks(db, router)
+	assert.Equal(nil, err)
+
+	// Reads go to the replica
+	db.Where("number = ?", 1).Find(&[]models.Block{})
+	db.Raw("SELECT * FROM blocks").Scan(&[]models.Block{})
+	assert.Equal(float64(2), testutil.ToFloat64(metrics.DBPoolQueriesCounter.WithLabelValues("replica-test:5432")))
+
+	// Unless the primary is asked for
+	primaryQueries := testutil.ToFloat64(metrics.DBPoolQueriesCounter.WithLabelValues(primaryPoolName))
+	db.WithContext(WithPrimary(context.Background())).Where("number = ?", 1).Find(&[]models.Block{})
+	assert.Equal(primaryQueries+1, testutil.ToFloat64(metrics.DBPoolQueriesCounter.WithLabelValues(primaryPoolName)))
+
+	// Writes aren't routed
+	db.Raw("UPDATE webhook_deliveries SET status = 'dead' RETURNING *").Scan(&[]models.WebhookDelivery{})
+	db.Create(&models.Webhook{Url: "http://localhost"})
+	assert.Equal(float64(2), testutil.ToFloat64(metrics.DBPoolQueriesCounter.WithLabelValues("replica-test:5432")))
+	assert.Equal(primaryQueries+1, testutil.ToFloat64(metrics.DBPoolQueriesCounter.WithLabelValues(primaryPoolName)))
+}
+
+func TestIsReadStatement(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(true, isReadStatement(""))
+	assert.Equal(true, isReadStatement(" select * from blocks"))
+	assert.Equal(false, isReadStatement("UPDATE webhook_deliveries SET status = 'dead' RETURNING *"))
+}
+
+func TestIsConnectionError(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(false, isConnectionError(context.DeadlineExceeded))
+	assert.Equal(false, isConnectionError(errors.New("relation does not exist")))
+	assert.Equal(true, isConnectionError(fmt.Errorf("query: %w", driver.ErrBadConn)))
+}
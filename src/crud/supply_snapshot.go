@@ -0,0 +1,129 @@
+package crud
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// SupplySnapshotCrud - type for supply_snapshot table model
+type SupplySnapshotCrud struct {
+	db    *gorm.DB
+	model *models.SupplySnapshot
+}
+
+var supplySnapshotCrud *SupplySnapshotCrud
+var supplySnapshotCrudOnce sync.Once
+
+// GetSupplySnapshotCrud - create and/or return the supply_snapshots table model
+func GetSupplySnapshotCrud() *SupplySnapshotCrud {
+	supplySnapshotCrudOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		supplySnapshotCrud = &SupplySnapshotCrud{
+			db:    dbConn,
+			model: &models.SupplySnapshot{},
+		}
+	})
+
+	return supplySnapshotCrud
+}
+
+// Migrate - migrate supply_snapshots table
+// NOTE: unlike the chain tables, the supply_snapshots table is owned by this service
+func (m *SupplySnapshotCrud) Migrate() error {
+	err := m.db.AutoMigrate(m.model)
+	if err != nil {
+		return err
+	}
+
+	// One snapshot per interval, even with multiple replicas taking it
+	err = m.db.Exec(
+		"CREATE UNIQUE INDEX IF NOT EXISTS supply_snapshots_idx_timestamp ON supply_snapshots (timestamp)",
+	).Error
+
+	return err
+}
+
+// InsertOne - insert one into supply_snapshots table, ignoring a snapshot already taken for the timestamp
+func (m *SupplySnapshotCrud) InsertOne(ctx context.Context, supplySnapshot *models.SupplySnapshot) error {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.SupplySnapshot{})
+
+	db = db.Clauses(clause.OnConflict{DoNothing: true}).Create(supplySnapshot)
+
+	return db.Error
+}
+
+// SelectMany - select many from supply_snapshots table, latest first
+// Timestamps of 0 are unbounded
+func (m *SupplySnapshotCrud) SelectMany(
+	ctx context.Context,
+	limit int,
+	skip int,
+	startTimestamp int64,
+	endTimestamp int64,
+) (*[]models.SupplySnapshot, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.SupplySnapshot{})
+
+	// Latest snapshots first
+	db = db.Order("timestamp desc")
+
+	// Start timestamp
+	if startTimestamp != 0 {
+		db = db.Where("timestamp >= ?", startTimestamp)
+	}
+
+	// End timestamp
+	if endTimestamp != 0 {
+		db = db.Where("timestamp <= ?", endTimestamp)
+	}
+
+	// Limit is required and defaulted to 1
+	db = db.Limit(limit)
+
+	// Skip
+	if skip != 0 {
+		db = db.Offset(skip)
+	}
+
+	supplySnapshots := &[]models.SupplySnapshot{}
+	db = db.Find(supplySnapshots)
+
+	return supplySnapshots, db.Error
+}
+
+// CountBy - count from supply_snapshots table
+func (m *SupplySnapshotCrud) CountBy(
+	ctx context.Context,
+	startTimestamp int64,
+	endTimestamp int64,
+) (int64, error) {
+	db := m.db.WithContext(ctx)
+	db = db.Model(&models.SupplySnapshot{})
+
+	if startTimestamp != 0 {
+		db = db.Where("timestamp >= ?", startTimestamp)
+	}
+
+	if endTimestamp != 0 {
+		db = db.Where("timestamp <= ?", endTimestamp)
+	}
+
+	var count int64
+	db = db.Count(&count)
+	return count, db.Error
+}
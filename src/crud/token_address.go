@@ -1,6 +1,9 @@
 package crud
 
 import (
+	"context"
+	"strconv"
+	"strings"
 	"sync"
 
 	"go.uber.org/zap"
@@ -38,10 +41,11 @@ func GetTokenAddressCrud() *TokenAddressCrud {
 // SelectMany - select from token_transfers table
 // Returns: models, error (if present)
 func (m *TokenAddressCrud) SelectMany(
+	ctx context.Context,
 	limit int,
 	skip int,
 ) (*[]models.TokenAddress, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&[]models.TokenAddress{})
@@ -64,9 +68,10 @@ func (m *TokenAddressCrud) SelectMany(
 
 // SelectTokensByPublicKey - select token contract addresses by address
 func (m *TokenAddressCrud) SelectManyByAddress(
+	ctx context.Context,
 	address string,
 ) (*[]models.TokenAddress, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&models.TokenAddress{})
@@ -83,11 +88,12 @@ func (m *TokenAddressCrud) SelectManyByAddress(
 // SelectMany - select from token_transfers table
 // Returns: models, error (if present)
 func (m *TokenAddressCrud) SelectManyByTokenContractAddress(
+	ctx context.Context,
 	limit int,
 	skip int,
 	tokenContractAddress string,
 ) (*[]models.TokenAddress, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&[]models.TokenAddress{})
@@ -111,11 +117,95 @@ func (m *TokenAddressCrud) SelectManyByTokenContractAddress(
 	return tokenAddresses, db.Error
 }
 
+// TokenHolderStats - distribution of a token's balances over its holders
+type TokenHolderStats struct {
+	HolderCount     int64
+	TotalBalance    float64
+	TopTenShare     float64
+	TopHundredShare float64
+	Gini            float64
+}
+
+// SelectHolderStatsByTokenContractAddress - holder count, total balance, top 10 and top 100 shares and Gini coefficient of a token
+// Holders are ranked by balance, largest first, so with n holders the Gini coefficient is
+// G = (n + 1 - 2 * sum(rank * balance) / sum(balance)) / n
+func (m *TokenAddressCrud) SelectHolderStatsByTokenContractAddress(
+	ctx context.Context,
+	tokenContractAddress string,
+) (*TokenHolderStats, error) {
+	db := m.db.WithContext(ctx)
+
+	db = db.Raw(`SELECT COUNT(*) AS holder_count,
+		COALESCE(SUM(balance), 0) AS total_balance,
+		COALESCE(SUM(balance) FILTER (WHERE rank <= 10) / NULLIF(SUM(balance), 0), 0) AS top_ten_share,
+		COALESCE(SUM(balance) FILTER (WHERE rank <= 100) / NULLIF(SUM(balance), 0), 0) AS top_hundred_share,
+		COALESCE((COUNT(*) + 1 - 2 * SUM(rank * balance) / NULLIF(SUM(balance), 0)) / NULLIF(COUNT(*), 0), 0) AS gini
+	FROM (
+		SELECT balance, ROW_NUMBER() OVER (ORDER BY balance DESC) AS rank
+		FROM token_addresses
+		WHERE token_contract_address = ? AND balance > 0
+	) AS holders`,
+		tokenContractAddress,
+	)
+
+	tokenHolderStats := &TokenHolderStats{}
+	db = db.Scan(tokenHolderStats)
+
+	return tokenHolderStats, db.Error
+}
+
+// TokenHolderBucket - holders of a token by order of magnitude of their balance
+// Bucket 0 is below 1, then 1 for 1 to 10, 2 for 10 to 100 and so on
+type TokenHolderBucket struct {
+	Bucket  int
+	Holders int64
+}
+
+// holderBalanceThresholds - SQL array of the lower bounds of buckets 1 and up, balances past the last are in the last bucket
+var holderBalanceThresholds = func() string {
+	thresholds := []string{}
+	for i := 0; i < 40; i++ {
+		thresholds = append(thresholds, "1e"+strconv.Itoa(i))
+	}
+
+	return "ARRAY[" + strings.Join(thresholds, ", ") + "]::float8[]"
+}()
+
+// SelectHolderHistogramByTokenContractAddress - holders of a token by bucket, smallest first, empty buckets left out
+func (m *TokenAddressCrud) SelectHolderHistogramByTokenContractAddress(
+	ctx context.Context,
+	tokenContractAddress string,
+) (*[]TokenHolderBucket, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.TokenAddress{})
+
+	db = db.Select("WIDTH_BUCKET(balance, " + holderBalanceThresholds + ") AS bucket, COUNT(*) AS holders")
+
+	// Token Contract Address
+	db = db.Where("token_contract_address = ?", tokenContractAddress)
+
+	// Holders only
+	db = db.Where("balance > 0")
+
+	db = db.Group("bucket")
+
+	// Smallest first
+	db = db.Order("bucket asc")
+
+	tokenHolderBuckets := &[]TokenHolderBucket{}
+	db = db.Scan(tokenHolderBuckets)
+
+	return tokenHolderBuckets, db.Error
+}
+
 func (m *TokenAddressCrud) CountBy(
+	ctx context.Context,
 	address string,
 	tokenContractAddress string,
 ) (int64, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 	db = db.Model(&models.TokenAddress{})
 
 	if address != "" {
@@ -0,0 +1,40 @@
+package crud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestSelectHolderStatsByTokenContractAddress(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Equal(nil, err)
+
+	tokenAddressCrud := &TokenAddressCrud{db: db}
+
+	var statement *gorm.Statement
+	db.Callback().Row().After("gorm:row").Register("test:statement", func(db *gorm.DB) {
+		statement = db.Statement
+	})
+
+	// Scanning rows isn't supported in dry run mode, the statement is still built
+	_, _ = tokenAddressCrud.SelectHolderStatsByTokenContractAddress(context.Background(), "cx1")
+	assert.Contains(statement.SQL.String(), `ROW_NUMBER() OVER (ORDER BY balance DESC) AS rank`)
+	assert.Contains(statement.SQL.String(), `WHERE token_contract_address = $1 AND balance > 0`)
+	assert.Equal([]interface{}{"cx1"}, statement.Vars)
+
+	_, _ = tokenAddressCrud.SelectHolderHistogramByTokenContractAddress(context.Background(), "cx1")
+	assert.Equal(
+		`SELECT WIDTH_BUCKET(balance, `+holderBalanceThresholds+`) AS bucket, COUNT(*) AS holders `+
+			`FROM "token_addresses" WHERE token_contract_address = $1 AND balance > 0 `+
+			`GROUP BY "bucket" ORDER BY bucket asc`,
+		statement.SQL.String(),
+	)
+	assert.Equal([]interface{}{"cx1"}, statement.Vars)
+	assert.Contains(holderBalanceThresholds, "ARRAY[1e0, 1e1, 1e2, ")
+}
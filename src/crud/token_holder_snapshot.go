@@ -0,0 +1,95 @@
+package crud
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// TokenHolderSnapshotCrud - type for token_holder_snapshot table model
+type TokenHolderSnapshotCrud struct {
+	db    *gorm.DB
+	model *models.TokenHolderSnapshot
+}
+
+var tokenHolderSnapshotCrud *TokenHolderSnapshotCrud
+var tokenHolderSnapshotCrudOnce sync.Once
+
+// GetTokenHolderSnapshotCrud - create and/or return the token_holder_snapshots table model
+func GetTokenHolderSnapshotCrud() *TokenHolderSnapshotCrud {
+	tokenHolderSnapshotCrudOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		tokenHolderSnapshotCrud = &TokenHolderSnapshotCrud{
+			db:    dbConn,
+			model: &models.TokenHolderSnapshot{},
+		}
+	})
+
+	return tokenHolderSnapshotCrud
+}
+
+// Migrate - migrate token_holder_snapshots table
+// NOTE: unlike the chain tables, the token_holder_snapshots table is owned by this service
+func (m *TokenHolderSnapshotCrud) Migrate() error {
+	err := m.db.AutoMigrate(m.model)
+	if err != nil {
+		return err
+	}
+
+	// One snapshot per token per day, taking it again replaces it
+	err = m.db.Exec(
+		"CREATE UNIQUE INDEX IF NOT EXISTS token_holder_snapshots_idx_token_contract_address_date ON token_holder_snapshots (token_contract_address, date)",
+	).Error
+
+	return err
+}
+
+// UpsertFromTokenAddresses - snapshot the holder count of every token for a day from token_addresses table
+func (m *TokenHolderSnapshotCrud) UpsertFromTokenAddresses(ctx context.Context, date string, updatedTimestamp int64) error {
+	db := m.db.WithContext(ctx)
+
+	db = db.Exec(`INSERT INTO token_holder_snapshots (date, token_contract_address, holder_count, updated_timestamp)
+	SELECT
+		?, token_contract_address, COUNT(*), ?
+	FROM
+		token_addresses
+	WHERE
+		balance > 0
+	GROUP BY token_contract_address
+	ON CONFLICT (token_contract_address, date) DO UPDATE SET
+		holder_count = excluded.holder_count,
+		updated_timestamp = excluded.updated_timestamp`, date, updatedTimestamp)
+
+	return db.Error
+}
+
+// SelectManyByDates - snapshots of a token on the dates that have one
+func (m *TokenHolderSnapshotCrud) SelectManyByDates(
+	ctx context.Context,
+	tokenContractAddress string,
+	dates []string,
+) (*[]models.TokenHolderSnapshot, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.TokenHolderSnapshot{})
+
+	// Token Contract Address
+	db = db.Where("token_contract_address = ?", tokenContractAddress)
+
+	// Dates
+	db = db.Where("date IN ?", dates)
+
+	tokenHolderSnapshots := &[]models.TokenHolderSnapshot{}
+	db = db.Find(tokenHolderSnapshots)
+
+	return tokenHolderSnapshots, db.Error
+}
@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"sync"
 
 	"go.uber.org/zap"
@@ -38,10 +39,11 @@ func GetTokenTransferCrud() *TokenTransferCrud {
 // SelectOne - select from token_transfers table
 // Returns: models, error (if present)
 func (m *TokenTransferCrud) SelectOne(
+	ctx context.Context,
 	transactionHash string,
 	logIndex int32,
 ) (*models.TokenTransfer, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&[]models.TokenTransfer{})
@@ -61,8 +63,10 @@ func (m *TokenTransferCrud) SelectOne(
 // SelectMany - select from token_transfers table
 // Returns: models, error (if present)
 func (m *TokenTransferCrud) SelectMany(
+	ctx context.Context,
 	limit int,
 	skip int,
+	cursor *Cursor,
 	from string,
 	to string,
 	blockNumber int,
@@ -70,16 +74,38 @@ func (m *TokenTransferCrud) SelectMany(
 	endBlockNumber int,
 	transactionHash string,
 	tokenContractAddress string,
+	sort string,
 ) (*[]models.TokenTransfer, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&[]models.TokenTransfer{})
 
 	// Latest transactions first
-	db = db.Order("block_number desc")
-	db = db.Order("transaction_index desc")
-	db = db.Order("log_index desc")
+	if sort == "asc" {
+		db = db.Order("block_number asc")
+		db = db.Order("transaction_index asc")
+		db = db.Order("log_index asc")
+	} else {
+		db = db.Order("block_number desc")
+		db = db.Order("transaction_index desc")
+		db = db.Order("log_index desc")
+	}
+
+	// Cursor
+	if cursor != nil {
+		if sort == "asc" {
+			db = db.Where(
+				"(block_number, transaction_index, log_index) > (?, ?, ?)",
+				cursor.BlockNumber, cursor.TransactionIndex, cursor.LogIndex,
+			)
+		} else {
+			db = db.Where(
+				"(block_number, transaction_index, log_index) < (?, ?, ?)",
+				cursor.BlockNumber, cursor.TransactionIndex, cursor.LogIndex,
+			)
+		}
+	}
 
 	// from
 	if from != "" {
@@ -133,11 +159,12 @@ func (m *TokenTransferCrud) SelectMany(
 // SelectManyByAddress - select from token_transfers table by address
 // Returns: models, error (if present)
 func (m *TokenTransferCrud) SelectManyByAddress(
+	ctx context.Context,
 	limit int,
 	skip int,
 	address string,
 ) (*[]models.TokenTransfer, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&[]models.TokenTransfer{})
@@ -168,11 +195,12 @@ func (m *TokenTransferCrud) SelectManyByAddress(
 // SelectManyByTokenContracAddress - select from token_transfers table by token contract address
 // Returns: models, error (if present)
 func (m *TokenTransferCrud) SelectManyByTokenContractAddress(
+	ctx context.Context,
 	limit int,
 	skip int,
 	tokenContractAddress string,
 ) (*[]models.TokenTransfer, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&[]models.TokenTransfer{})
@@ -196,3 +224,121 @@ func (m *TokenTransferCrud) SelectManyByTokenContractAddress(
 
 	return tokenTransfers, db.Error
 }
+
+// SelectManyActivity - select token transfers from or to an address
+// Latest first by (block_number, transaction_index, log_index), after the cursor if given
+// direction - "in" for transfers to the address, "out" for transfers from it, empty for both
+// Returns: models, error (if present)
+func (m *TokenTransferCrud) SelectManyActivity(
+	ctx context.Context,
+	limit int,
+	cursor *Cursor,
+	address string,
+	direction string,
+	startTimestamp int64,
+	endTimestamp int64,
+) (*[]models.TokenTransfer, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&[]models.TokenTransfer{})
+
+	db = db.Select("token_transfers.*")
+
+	// Address
+	// Rows of the address are read from token_transfer_by_addresses, like SelectManyByAddress, from or to the address is really slow
+	byAddresses := "SELECT transaction_hash, log_index, block_number FROM token_transfer_by_addresses WHERE address = ?"
+	byAddressesVars := []interface{}{address}
+
+	// Cursor
+	// Only rows from the cursor's block back are read
+	if cursor != nil {
+		byAddresses += " AND block_number <= ?"
+		byAddressesVars = append(byAddressesVars, cursor.BlockNumber)
+	}
+	db = db.Joins(
+		"JOIN ("+byAddresses+") AS activity "+
+			"ON activity.transaction_hash = token_transfers.transaction_hash AND activity.log_index = token_transfers.log_index",
+		byAddressesVars...,
+	)
+
+	// Latest transfers first
+	// Ordered by the by address table's block number first so it's read in order
+	db = db.Order("activity.block_number desc, token_transfers.transaction_index desc, token_transfers.log_index desc")
+
+	// Cursor
+	if cursor != nil {
+		db = db.Where(
+			"(token_transfers.block_number, token_transfers.transaction_index, token_transfers.log_index) < (?, ?, ?)",
+			cursor.BlockNumber, cursor.TransactionIndex, cursor.LogIndex,
+		)
+	}
+
+	db = whereActivityDirection(db, "token_transfers", address, direction)
+
+	// Time range
+	if startTimestamp != 0 {
+		db = db.Where("token_transfers.block_timestamp >= ?", startTimestamp)
+	}
+	if endTimestamp != 0 {
+		db = db.Where("token_transfers.block_timestamp <= ?", endTimestamp)
+	}
+
+	// Limit is required
+	db = db.Limit(limit)
+
+	tokenTransfers := &[]models.TokenTransfer{}
+	db = db.Find(tokenTransfers)
+
+	return tokenTransfers, db.Error
+}
+
+// TokenBalanceChange - net amount of a token an address received after a block
+type TokenBalanceChange struct {
+	TokenContractAddress string
+
+	// Exact integer text in the token's smallest unit
+	Change string
+
+	// In the token's units, for tokens whose decimals aren't known
+	ChangeDecimal float64
+}
+
+// SelectBalanceChangesAfter - net IRC2 transfers of an address after a block, by token
+// Folded back from the current balances to get the balances at the block
+func (m *TokenTransferCrud) SelectBalanceChangesAfter(
+	ctx context.Context,
+	address string,
+	blockNumber int64,
+) (*[]TokenBalanceChange, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&[]models.TokenTransfer{})
+
+	// Received counts up, sent counts down, a transfer to itself does neither
+	// Only the transfers after the block are converted from hex
+	db = db.Select(
+		"token_contract_address, "+
+			"SUM(((to_address = ?)::int - (from_address = ?)::int) * "+hexToNumeric("token_transfers.value")+")::text AS change, "+
+			"SUM(((to_address = ?)::int - (from_address = ?)::int) * value_decimal) AS change_decimal",
+		address, address, address, address,
+	)
+
+	// Address and block, read from the by address table
+	db = db.Joins(
+		"JOIN (SELECT transaction_hash, log_index FROM token_transfer_by_addresses WHERE address = ? AND block_number > ?) AS changes "+
+			"ON changes.transaction_hash = token_transfers.transaction_hash AND changes.log_index = token_transfers.log_index",
+		address, blockNumber,
+	)
+
+	// IRC2 tokens only
+	db = db.Where("token_contract_address IN (SELECT address FROM addresses WHERE token_standard = ?)", "irc2")
+
+	db = db.Group("token_contract_address")
+
+	tokenBalanceChanges := &[]TokenBalanceChange{}
+	db = db.Scan(tokenBalanceChanges)
+
+	return tokenBalanceChanges, db.Error
+}
@@ -0,0 +1,67 @@
+package crud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestSelectBalanceChangesAfter(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Equal(nil, err)
+
+	tokenTransferCrud := &TokenTransferCrud{db: db}
+
+	var statement *gorm.Statement
+	db.Callback().Row().After("gorm:row").Register("test:statement", func(db *gorm.DB) {
+		statement = db.Statement
+	})
+
+	// Scanning rows isn't supported in dry run mode, the statement is still built
+	_, _ = tokenTransferCrud.SelectBalanceChangesAfter(context.Background(), "hx1", 100)
+
+	assert.Equal(
+		`SELECT token_contract_address, `+
+			`SUM(((to_address = $1)::int - (from_address = $2)::int) * `+hexToNumeric("token_transfers.value")+`)::text AS change, `+
+			`SUM(((to_address = $3)::int - (from_address = $4)::int) * value_decimal) AS change_decimal `+
+			`FROM "token_transfers" `+
+			`JOIN (SELECT transaction_hash, log_index FROM token_transfer_by_addresses WHERE address = $5 AND block_number > $6) AS changes `+
+			`ON changes.transaction_hash = token_transfers.transaction_hash AND changes.log_index = token_transfers.log_index `+
+			`WHERE token_contract_address IN (SELECT address FROM addresses WHERE token_standard = $7) `+
+			`GROUP BY "token_contract_address"`,
+		statement.SQL.String(),
+	)
+	assert.Equal([]interface{}{"hx1", "hx1", "hx1", "hx1", "hx1", int64(100), "irc2"}, statement.Vars)
+}
+
+func TestTokenTransferSelectManyActivity(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Equal(nil, err)
+
+	tokenTransferCrud := &TokenTransferCrud{db: db}
+
+	var statement *gorm.Statement
+	db.Callback().Query().After("gorm:query").Register("test:statement", func(db *gorm.DB) {
+		statement = db.Statement
+	})
+
+	_, _ = tokenTransferCrud.SelectManyActivity(context.Background(), 25, nil, "hx1", "", 10, 0)
+
+	// Both directions are every row of the address
+	assert.Equal(
+		`SELECT token_transfers.* FROM "token_transfers" JOIN (`+
+			`SELECT transaction_hash, log_index, block_number FROM token_transfer_by_addresses WHERE address = $1`+
+			`) AS activity ON activity.transaction_hash = token_transfers.transaction_hash AND activity.log_index = token_transfers.log_index `+
+			`WHERE token_transfers.block_timestamp >= $2 `+
+			`ORDER BY activity.block_number desc, token_transfers.transaction_index desc, token_transfers.log_index desc LIMIT 25`,
+		statement.SQL.String(),
+	)
+	assert.Equal([]interface{}{"hx1", int64(10)}, statement.Vars)
+}
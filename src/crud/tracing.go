@@ -0,0 +1,76 @@
+package crud
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/tracing"
+)
+
+const tracingSpanKey = "tracing:span"
+
+// registerTracingCallbacks - span for each query, a child of the statement's context
+func registerTracingCallbacks(db *gorm.DB) error {
+	callback := db.Callback()
+
+	for _, err := range []error{
+		callback.Query().Before("gorm:query").Register("tracing:before_query", tracingBefore("query")),
+		callback.Query().After("gorm:query").Register("tracing:after_query", tracingAfter),
+		callback.Row().Before("gorm:row").Register("tracing:before_row", tracingBefore("row")),
+		callback.Row().After("gorm:row").Register("tracing:after_row", tracingAfter),
+		callback.Raw().Before("gorm:raw").Register("tracing:before_raw", tracingBefore("raw")),
+		callback.Raw().After("gorm:raw").Register("tracing:after_raw", tracingAfter),
+		callback.Create().Before("gorm:create").Register("tracing:before_create", tracingBefore("create")),
+		callback.Create().After("gorm:create").Register("tracing:after_create", tracingAfter),
+		callback.Update().Before("gorm:update").Register("tracing:before_update", tracingBefore("update")),
+		callback.Update().After("gorm:update").Register("tracing:after_update", tracingAfter),
+		callback.Delete().Before("gorm:delete").Register("tracing:before_delete", tracingBefore("delete")),
+		callback.Delete().After("gorm:delete").Register("tracing:after_delete", tracingAfter),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func tracingBefore(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, span := tracing.Tracer().Start(
+			db.Statement.Context,
+			"gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemPostgreSQL,
+				semconv.DBOperationKey.String(operation),
+			),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+func tracingAfter(db *gorm.DB) {
+	value, ok := db.InstanceGet(tracingSpanKey)
+	if ok == false {
+		return
+	}
+	span := value.(trace.Span)
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBSQLTableKey.String(db.Statement.Table),
+		semconv.DBStatementKey.String(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && errors.Is(db.Error, gorm.ErrRecordNotFound) == false {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
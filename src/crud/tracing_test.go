@@ -0,0 +1,44 @@
+package crud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/models"
+	"github.com/sudoblockio/icon-go-api/tracing"
+)
+
+func TestTracingCallbacks(t *testing.T) {
+	assert := assert.New(t)
+
+	exporter := tracing.StartInMemory()
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	assert.Equal(nil, err)
+
+	err = registerTracingCallbacks(db)
+	assert.Equal(nil, err)
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "parent")
+	db.WithContext(ctx).Where("number = ?", 1).Find(&[]models.Block{})
+	parent.End()
+
+	spans := exporter.GetSpans()
+	assert.Equal(2, len(spans))
+
+	querySpan := spans[0]
+	assert.Equal("gorm.query", querySpan.Name)
+	assert.Equal(parent.SpanContext().SpanID(), querySpan.Parent.SpanID())
+
+	attributes := map[string]string{}
+	for _, attribute := range querySpan.Attributes {
+		attributes[string(attribute.Key)] = attribute.Value.Emit()
+	}
+	assert.Equal("postgresql", attributes["db.system"])
+	assert.Equal("blocks", attributes["db.sql.table"])
+	assert.Contains(attributes["db.statement"], "number = $1")
+}
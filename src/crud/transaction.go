@@ -2,8 +2,8 @@ package crud
 
 import (
 	"context"
+	"strings"
 	"sync"
-	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -40,8 +40,10 @@ func GetTransactionCrud() *TransactionCrud {
 // SelectMany - select from transactions table
 // Returns: models, error (if present)
 func (m *TransactionCrud) SelectMany(
+	ctx context.Context,
 	limit int,
 	skip int,
+	cursor *Cursor,
 	from string,
 	to string,
 	_type string,
@@ -51,16 +53,32 @@ func (m *TransactionCrud) SelectMany(
 	method string,
 	sort string,
 ) (*[]models.TransactionList, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&[]models.Transaction{})
 
 	// Latest transactions first
 	if sort != "" {
-		db = db.Order("block_number " + sort + ", transaction_index")
+		db = db.Order("block_number " + sort + ", transaction_index, log_index")
 	} else {
-		db = db.Order("transaction_index")
+		db = db.Order("transaction_index, log_index")
+	}
+
+	// Cursor
+	// Rows after the last row of the previous page, matching the sort order above
+	if cursor != nil {
+		if sort == "asc" {
+			db = db.Where(
+				"(block_number > ? OR (block_number = ? AND (transaction_index, log_index) > (?, ?)))",
+				cursor.BlockNumber, cursor.BlockNumber, cursor.TransactionIndex, cursor.LogIndex,
+			)
+		} else {
+			db = db.Where(
+				"(block_number < ? OR (block_number = ? AND (transaction_index, log_index) > (?, ?)))",
+				cursor.BlockNumber, cursor.BlockNumber, cursor.TransactionIndex, cursor.LogIndex,
+			)
+		}
 	}
 
 	// from
@@ -112,9 +130,10 @@ func (m *TransactionCrud) SelectMany(
 	return transactions, db.Error
 }
 
-// CountMany - select from transactions table
-// Returns: models, error (if present)
+// CountMany - count from transactions table
+// Exact for small counts, estimated for large ones
 func (m *TransactionCrud) CountMany(
+	ctx context.Context,
 	from string,
 	to string,
 	_type string,
@@ -122,49 +141,44 @@ func (m *TransactionCrud) CountMany(
 	startBlockNumber int,
 	endBlockNumber int,
 	method string,
-) (*int64, error) {
-	db := m.db
-	db = db.Model(&[]models.Transaction{})
-	if from != "" {
-		db = db.Where("from_address = ?", from)
-	}
-	if to != "" {
-		db = db.Where("to_address = ?", to)
-	}
-	if _type != "" {
-		db = db.Where("type = ?", _type)
-	}
-	if blockNumber != 0 {
-		db = db.Where("block_number = ?", blockNumber)
-	}
-	if startBlockNumber != 0 {
-		db = db.Where("block_number >= ?", startBlockNumber)
-	}
-	if endBlockNumber != 0 {
-		db = db.Where("block_number <= ?", endBlockNumber)
-	}
-	if method != "" {
-		db = db.Where("method = ?", method)
-	}
-
-	// Strict timeout as some of these queries can take a while
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-
-	count := int64(0)
-	db = db.WithContext(ctx).Count(&count)
+) (*Count, error) {
+	return countWithStrategy(ctx, m.db, func(db *gorm.DB) *gorm.DB {
+		db = db.Model(&[]models.Transaction{})
+		if from != "" {
+			db = db.Where("from_address = ?", from)
+		}
+		if to != "" {
+			db = db.Where("to_address = ?", to)
+		}
+		if _type != "" {
+			db = db.Where("type = ?", _type)
+		}
+		if blockNumber != 0 {
+			db = db.Where("block_number = ?", blockNumber)
+		}
+		if startBlockNumber != 0 {
+			db = db.Where("block_number >= ?", startBlockNumber)
+		}
+		if endBlockNumber != 0 {
+			db = db.Where("block_number <= ?", endBlockNumber)
+		}
+		if method != "" {
+			db = db.Where("method = ?", method)
+		}
 
-	return &count, db.Error
+		return db
+	})
 }
 
 // SelectManyByAddress - select from transactions table
 // Returns: models, error (if present)
 func (m *TransactionCrud) SelectManyByAddress(
+	ctx context.Context,
 	limit int,
 	skip int,
 	address string,
 ) (*[]models.TransactionList, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&[]models.Transaction{})
@@ -199,8 +213,8 @@ func (m *TransactionCrud) SelectManyByAddress(
 
 // CountManyIcxByAddress - select from transactions table
 // Returns: int64, error (if present)
-func (m *TransactionCrud) CountManyIcxByAddress(address string) (int64, error) {
-	db := m.db
+func (m *TransactionCrud) CountManyIcxByAddress(ctx context.Context, address string) (int64, error) {
+	db := m.db.WithContext(ctx)
 
 	db = db.Model(&models.Transaction{}).Where("type='transaction'")
 	db = db.Model(&models.Transaction{}).Where("to_address = ? or from_address = ?", address, address)
@@ -214,11 +228,12 @@ func (m *TransactionCrud) CountManyIcxByAddress(address string) (int64, error) {
 // SelectManyIcxByAddress - select from transactions table
 // Returns: models, error (if present)
 func (m *TransactionCrud) SelectManyIcxByAddress(
+	ctx context.Context,
 	limit int,
 	skip int,
 	address string,
 ) (*[]models.TransactionList, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&[]models.Transaction{})
@@ -252,12 +267,13 @@ func (m *TransactionCrud) SelectManyIcxByAddress(
 // SelectManyInternal - select many internal transaction table
 // Returns: models, error (if present)
 func (m *TransactionCrud) SelectManyInternal(
+	ctx context.Context,
 	limit int,
 	skip int,
 	hash string,
 	blockNumber int,
 ) (*[]models.TransactionInternalList, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&[]models.Transaction{})
@@ -295,11 +311,12 @@ func (m *TransactionCrud) SelectManyInternal(
 // SelectManyInternalByAddress - select from internal transactions table
 // Returns: models, error (if present)
 func (m *TransactionCrud) SelectManyInternalByAddress(
+	ctx context.Context,
 	limit int,
 	skip int,
 	address string,
 ) (*[]models.TransactionInternalList, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&[]models.Transaction{})
@@ -332,10 +349,11 @@ func (m *TransactionCrud) SelectManyInternalByAddress(
 
 // SelectOne - select from transactions table
 func (m *TransactionCrud) SelectOne(
+	ctx context.Context,
 	hash string,
 	logIndex int32, // Used for internal transactions
 ) (*models.Transaction, error) {
-	db := m.db
+	db := m.db.WithContext(ctx)
 
 	// Set table
 	db = db.Model(&[]models.Transaction{})
@@ -351,3 +369,217 @@ func (m *TransactionCrud) SelectOne(
 
 	return transaction, db.Error
 }
+
+// SelectManyActivity - select regular and/or internal transactions from or to an address
+// Latest first by (block_number, transaction_index, log_index), after the cursor if given
+// direction - "in" for transactions to the address, "out" for transactions from it, empty for both
+// Returns: models, error (if present)
+func (m *TransactionCrud) SelectManyActivity(
+	ctx context.Context,
+	limit int,
+	cursor *Cursor,
+	address string,
+	types []string,
+	direction string,
+	startTimestamp int64,
+	endTimestamp int64,
+) (*[]models.TransactionList, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&[]models.Transaction{})
+	db = db.Select("transactions.*")
+
+	// Address
+	// Rows of the address are read from the by address tables, like SelectManyByAddress, from or to the address is really slow
+	// Regular transactions have no log index in transaction_by_addresses, they are log index -1
+	byAddresses := []string{}
+	byAddressesVars := []interface{}{}
+	for _, transactionType := range types {
+		switch transactionType {
+		case "transaction":
+			byAddresses = append(byAddresses, "SELECT transaction_hash, -1 AS log_index, block_number FROM transaction_by_addresses WHERE address = ?")
+		case "log":
+			byAddresses = append(byAddresses, "SELECT transaction_hash, log_index, block_number FROM transaction_internal_by_addresses WHERE address = ?")
+		default:
+			continue
+		}
+		byAddressesVars = append(byAddressesVars, address)
+
+		// Cursor
+		// Only rows from the cursor's block back are read
+		if cursor != nil {
+			byAddresses[len(byAddresses)-1] += " AND block_number <= ?"
+			byAddressesVars = append(byAddressesVars, cursor.BlockNumber)
+		}
+	}
+	db = db.Joins(
+		"JOIN ("+strings.Join(byAddresses, " UNION ALL ")+") AS activity "+
+			"ON activity.transaction_hash = transactions.hash AND activity.log_index = transactions.log_index",
+		byAddressesVars...,
+	)
+
+	// Latest transactions first
+	// Ordered by the by address tables' block number first so they are read in order
+	db = db.Order("activity.block_number desc, transactions.transaction_index desc, transactions.log_index desc")
+
+	// Cursor
+	if cursor != nil {
+		db = db.Where(
+			"(transactions.block_number, transactions.transaction_index, transactions.log_index) < (?, ?, ?)",
+			cursor.BlockNumber, cursor.TransactionIndex, cursor.LogIndex,
+		)
+	}
+
+	db = whereActivityDirection(db, "transactions", address, direction)
+
+	// Time range
+	if startTimestamp != 0 {
+		db = db.Where("transactions.block_timestamp >= ?", startTimestamp)
+	}
+	if endTimestamp != 0 {
+		db = db.Where("transactions.block_timestamp <= ?", endTimestamp)
+	}
+
+	// Limit is required
+	db = db.Limit(limit)
+
+	transactions := &[]models.TransactionList{}
+	db = db.Find(transactions)
+
+	return transactions, db.Error
+}
+
+// whereActivityDirection - rows of a table to an address for "in" or from it for "out", every row otherwise
+// The rows are already the address's, read from the by address tables
+func whereActivityDirection(db *gorm.DB, table string, address string, direction string) *gorm.DB {
+	switch direction {
+	case "in":
+		return db.Where(table+".to_address = ?", address)
+	case "out":
+		return db.Where(table+".from_address = ?", address)
+	default:
+		return db
+	}
+}
+
+// icxBalanceChange - SQL expression for the change in loop of the ICX balance of an address from a transaction or internal transaction
+// Senders of regular transactions pay the fee even if the transaction failed, failed transactions move no value
+func icxBalanceChange(address string) (string, []interface{}) {
+	return "CASE WHEN (type = ? OR status = ?) AND value LIKE ? " +
+			"THEN ((to_address = ?)::int - (from_address = ?)::int) * " + smallHexToNumeric("value") + " ELSE 0 END - " +
+			"CASE WHEN type = ? AND from_address = ? AND transaction_fee LIKE ? " +
+			"THEN " + smallHexToNumeric("transaction_fee") + " ELSE 0 END",
+		[]interface{}{"log", "0x1", "0x%", address, address, "transaction", address, "0x%"}
+}
+
+// joinIcxMovements - transactions and internal transactions that change the ICX balance of an address, from the by address tables
+// Only rows with a block number matching the condition, like "> ?", if it isn't empty
+// Regular transactions have no log index in transaction_by_addresses, they are log index -1
+func joinIcxMovements(db *gorm.DB, address string, blockCondition string, blockNumber int64) *gorm.DB {
+	byAddresses := []string{
+		"SELECT transaction_hash, -1 AS log_index FROM transaction_by_addresses WHERE address = ?",
+		"SELECT transaction_hash, log_index FROM transaction_internal_by_addresses WHERE address = ?",
+	}
+	byAddressesVars := []interface{}{}
+	for i := range byAddresses {
+		byAddressesVars = append(byAddressesVars, address)
+
+		if blockCondition != "" {
+			byAddresses[i] += " AND block_number " + blockCondition
+			byAddressesVars = append(byAddressesVars, blockNumber)
+		}
+	}
+	db = db.Joins(
+		"JOIN ("+strings.Join(byAddresses, " UNION ALL ")+") AS movements "+
+			"ON movements.transaction_hash = transactions.hash AND movements.log_index = transactions.log_index",
+		byAddressesVars...,
+	)
+
+	// Moves ICX or pays a fee
+	// Transactions sent by the address are all included for their fees, even without value
+	return db.Where("value_decimal != 0 OR (type = ? AND from_address = ?)", "transaction", address)
+}
+
+// IcxBalanceChange - change in the ICX balance of an address over a block or a day
+type IcxBalanceChange struct {
+	FirstBlockNumber   int64
+	LastBlockNumber    int64
+	LastBlockTimestamp int64
+
+	// Exact integer text in loop
+	Change string
+}
+
+// SelectManyIcxBalanceChanges - select the ICX balance changes of an address per block, or per UTC day if daily
+// Only blocks before beforeBlockNumber if it isn't 0
+// Latest first
+func (m *TransactionCrud) SelectManyIcxBalanceChanges(
+	ctx context.Context,
+	address string,
+	daily bool,
+	beforeBlockNumber int64,
+	limit int,
+) (*[]IcxBalanceChange, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&[]models.Transaction{})
+
+	change, changeArgs := icxBalanceChange(address)
+	db = db.Select(
+		"MIN(transactions.block_number) AS first_block_number, "+
+			"MAX(transactions.block_number) AS last_block_number, "+
+			"MAX(transactions.block_timestamp) AS last_block_timestamp, "+
+			"SUM("+change+")::text AS change",
+		changeArgs...,
+	)
+
+	// Before the page
+	if beforeBlockNumber != 0 {
+		db = joinIcxMovements(db, address, "< ?", beforeBlockNumber)
+	} else {
+		db = joinIcxMovements(db, address, "", 0)
+	}
+
+	// Block timestamps are microseconds
+	if daily {
+		db = db.Group("transactions.block_timestamp / 86400000000")
+	} else {
+		db = db.Group("transactions.block_number")
+	}
+
+	// Latest first
+	db = db.Order("last_block_number desc")
+
+	// Limit
+	db = db.Limit(limit)
+
+	icxBalanceChanges := &[]IcxBalanceChange{}
+	db = db.Scan(icxBalanceChanges)
+
+	return icxBalanceChanges, db.Error
+}
+
+// SumIcxBalanceChangeAfter - change in loop of the ICX balance of an address after a block, as exact integer text
+func (m *TransactionCrud) SumIcxBalanceChangeAfter(
+	ctx context.Context,
+	address string,
+	blockNumber int64,
+) (string, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&[]models.Transaction{})
+
+	change, changeArgs := icxBalanceChange(address)
+	db = db.Select("COALESCE(SUM("+change+"), 0)::text AS change", changeArgs...)
+
+	// After the block
+	db = joinIcxMovements(db, address, "> ?", blockNumber)
+
+	icxBalanceChange := &IcxBalanceChange{}
+	db = db.Scan(icxBalanceChange)
+
+	return icxBalanceChange.Change, db.Error
+}
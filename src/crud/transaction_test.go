@@ -0,0 +1,97 @@
+package crud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestSelectManyIcxBalanceChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Equal(nil, err)
+
+	transactionCrud := &TransactionCrud{db: db}
+
+	var statement *gorm.Statement
+	db.Callback().Row().After("gorm:row").Register("test:statement", func(db *gorm.DB) {
+		statement = db.Statement
+	})
+
+	change := `CASE WHEN (type = $1 OR status = $2) AND value LIKE $3 ` +
+		`THEN ((to_address = $4)::int - (from_address = $5)::int) * ` + smallHexToNumeric("value") + ` ELSE 0 END - ` +
+		`CASE WHEN type = $6 AND from_address = $7 AND transaction_fee LIKE $8 ` +
+		`THEN ` + smallHexToNumeric("transaction_fee") + ` ELSE 0 END`
+	changeVars := []interface{}{"log", "0x1", "0x%", "hx1", "hx1", "transaction", "hx1", "0x%"}
+
+	// Scanning rows isn't supported in dry run mode, the statement is still built
+	_, _ = transactionCrud.SelectManyIcxBalanceChanges(context.Background(), "hx1", true, 100, 25)
+
+	assert.Equal(
+		`SELECT MIN(transactions.block_number) AS first_block_number, MAX(transactions.block_number) AS last_block_number, `+
+			`MAX(transactions.block_timestamp) AS last_block_timestamp, SUM(`+change+`)::text AS change `+
+			`FROM "transactions" JOIN (`+
+			`SELECT transaction_hash, -1 AS log_index FROM transaction_by_addresses WHERE address = $9 AND block_number < $10 UNION ALL `+
+			`SELECT transaction_hash, log_index FROM transaction_internal_by_addresses WHERE address = $11 AND block_number < $12`+
+			`) AS movements ON movements.transaction_hash = transactions.hash AND movements.log_index = transactions.log_index `+
+			`WHERE value_decimal != 0 OR (type = $13 AND from_address = $14) `+
+			`GROUP BY transactions.block_timestamp / 86400000000 ORDER BY last_block_number desc LIMIT 25`,
+		statement.SQL.String(),
+	)
+	joinVars := []interface{}{"hx1", int64(100), "hx1", int64(100), "transaction", "hx1"}
+	assert.Equal(append(changeVars, joinVars...), statement.Vars)
+
+	_, _ = transactionCrud.SumIcxBalanceChangeAfter(context.Background(), "hx1", 100)
+
+	assert.Equal(
+		`SELECT COALESCE(SUM(`+change+`), 0)::text AS change `+
+			`FROM "transactions" JOIN (`+
+			`SELECT transaction_hash, -1 AS log_index FROM transaction_by_addresses WHERE address = $9 AND block_number > $10 UNION ALL `+
+			`SELECT transaction_hash, log_index FROM transaction_internal_by_addresses WHERE address = $11 AND block_number > $12`+
+			`) AS movements ON movements.transaction_hash = transactions.hash AND movements.log_index = transactions.log_index `+
+			`WHERE value_decimal != 0 OR (type = $13 AND from_address = $14)`,
+		statement.SQL.String(),
+	)
+	assert.Equal(append(changeVars, joinVars...), statement.Vars)
+
+	// First page
+	_, _ = transactionCrud.SelectManyIcxBalanceChanges(context.Background(), "hx1", false, 0, 25)
+
+	assert.Contains(statement.SQL.String(), `transaction_by_addresses WHERE address = $9 UNION ALL`)
+	assert.Contains(statement.SQL.String(), `GROUP BY "transactions"."block_number" ORDER BY`)
+}
+
+func TestTransactionSelectManyActivity(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.Equal(nil, err)
+
+	transactionCrud := &TransactionCrud{db: db}
+
+	var statement *gorm.Statement
+	db.Callback().Query().After("gorm:query").Register("test:statement", func(db *gorm.DB) {
+		statement = db.Statement
+	})
+
+	cursor := &Cursor{BlockNumber: 100, TransactionIndex: 2, LogIndex: -1, Sort: "desc"}
+	_, _ = transactionCrud.SelectManyActivity(context.Background(), 25, cursor, "hx1", []string{"transaction", "log"}, "in", 0, 0)
+
+	// Read from the by address tables back from the cursor's block, no from or to scan
+	assert.Equal(
+		`SELECT transactions.* FROM "transactions" JOIN (`+
+			`SELECT transaction_hash, -1 AS log_index, block_number FROM transaction_by_addresses WHERE address = $1 AND block_number <= $2 `+
+			`UNION ALL `+
+			`SELECT transaction_hash, log_index, block_number FROM transaction_internal_by_addresses WHERE address = $3 AND block_number <= $4`+
+			`) AS activity ON activity.transaction_hash = transactions.hash AND activity.log_index = transactions.log_index `+
+			`WHERE (transactions.block_number, transactions.transaction_index, transactions.log_index) < ($5, $6, $7) `+
+			`AND transactions.to_address = $8 `+
+			`ORDER BY activity.block_number desc, transactions.transaction_index desc, transactions.log_index desc LIMIT 25`,
+		statement.SQL.String(),
+	)
+	assert.Equal([]interface{}{"hx1", int64(100), "hx1", int64(100), int64(100), int64(2), int64(-1), "hx1"}, statement.Vars)
+}
@@ -2,8 +2,35 @@ package crud
 
 import (
 	"reflect"
+	"strings"
 )
 
+// likeEscaper - escapes LIKE wildcards so user input only matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+// hexToNumeric - SQL expression for the exact numeric value of a 0x prefixed hex column
+// Postgres has no hex to numeric cast, bit strings overflow past 64 bits, so the digits are summed
+// Only pass column names, the expression isn't escaped
+func hexToNumeric(column string) string {
+	return "(SELECT COALESCE(ROUND(SUM(" +
+		"(STRPOS('0123456789abcdef', SUBSTR(LOWER(" + column + "), 2 + digit.i, 1)) - 1) * " +
+		"POWER(16::numeric, LENGTH(" + column + ") - 2 - digit.i)" +
+		")), 0) FROM GENERATE_SERIES(1, LENGTH(" + column + ") - 2) AS digit(i))"
+}
+
+// smallHexToNumeric - SQL expression for the exact numeric value of a 0x prefixed hex column of up to 30 digits
+// Cast as two 60 bit halves instead of summing every digit, ICX amounts and fees in loop are far shorter
+// Only pass column names, the expression isn't escaped
+func smallHexToNumeric(column string) string {
+	digits := "LPAD(SUBSTR(" + column + ", 3), 30, '0')"
+	return "(('x' || SUBSTR(" + digits + ", 1, 15))::bit(60)::bigint::numeric * 1152921504606846976 + " +
+		"('x' || SUBSTR(" + digits + ", 16, 15))::bit(60)::bigint)"
+}
+
 func extractFilledFieldsFromModel(modelValueOf reflect.Value, modelTypeOf reflect.Type) map[string]interface{} {
 
 	fields := map[string]interface{}{}
@@ -0,0 +1,129 @@
+package crud
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// WebhookCrud - type for webhook table model
+type WebhookCrud struct {
+	db    *gorm.DB
+	model *models.Webhook
+}
+
+var webhookCrud *WebhookCrud
+var webhookCrudOnce sync.Once
+
+// GetWebhookCrud - create and/or return the webhooks table model
+func GetWebhookCrud() *WebhookCrud {
+	webhookCrudOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		webhookCrud = &WebhookCrud{
+			db:    dbConn,
+			model: &models.Webhook{},
+		}
+	})
+
+	return webhookCrud
+}
+
+// Migrate - migrate webhooks table
+// NOTE: unlike the chain tables, the webhooks table is owned by this service
+func (m *WebhookCrud) Migrate() error {
+	err := m.db.AutoMigrate(m.model)
+
+	return err
+}
+
+// InsertOne - insert one into webhooks table
+func (m *WebhookCrud) InsertOne(ctx context.Context, webhook *models.Webhook) error {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.Webhook{})
+
+	db = db.Create(webhook)
+
+	return db.Error
+}
+
+// SelectOne - select one from webhooks table
+func (m *WebhookCrud) SelectOne(ctx context.Context, id int64) (*models.Webhook, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.Webhook{})
+
+	// Id
+	db = db.Where("id = ?", id)
+
+	webhook := &models.Webhook{}
+	db = db.First(webhook)
+
+	return webhook, db.Error
+}
+
+// SelectMany - select many from webhooks table
+func (m *WebhookCrud) SelectMany(
+	ctx context.Context,
+	limit int,
+	skip int,
+	eventType string,
+) (*[]models.Webhook, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.Webhook{})
+
+	// Oldest first
+	db = db.Order("id asc")
+
+	// Event type
+	if eventType != "" {
+		db = db.Where("event_type = ?", eventType)
+	}
+
+	// Limit, 0 for all
+	if limit != 0 {
+		db = db.Limit(limit)
+	}
+
+	// Skip
+	if skip != 0 {
+		db = db.Offset(skip)
+	}
+
+	webhooks := &[]models.Webhook{}
+	db = db.Find(webhooks)
+
+	return webhooks, db.Error
+}
+
+// DeleteOne - delete one from webhooks table along with its deliveries
+func (m *WebhookCrud) DeleteOne(ctx context.Context, id int64) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		db := tx.Where("webhook_id = ?", id).Delete(&models.WebhookDelivery{})
+		if db.Error != nil {
+			return db.Error
+		}
+
+		db = tx.Where("id = ?", id).Delete(&models.Webhook{})
+		if db.Error != nil {
+			return db.Error
+		}
+		if db.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,211 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// Webhook delivery statuses
+// Dead deliveries ran out of attempts and make up the dead-letter list
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusDead      = "dead"
+)
+
+// WebhookDeliveryCrud - type for webhook_delivery table model
+type WebhookDeliveryCrud struct {
+	db    *gorm.DB
+	model *models.WebhookDelivery
+}
+
+var webhookDeliveryCrud *WebhookDeliveryCrud
+var webhookDeliveryCrudOnce sync.Once
+
+// GetWebhookDeliveryCrud - create and/or return the webhook_deliveries table model
+func GetWebhookDeliveryCrud() *WebhookDeliveryCrud {
+	webhookDeliveryCrudOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		webhookDeliveryCrud = &WebhookDeliveryCrud{
+			db:    dbConn,
+			model: &models.WebhookDelivery{},
+		}
+	})
+
+	return webhookDeliveryCrud
+}
+
+// Migrate - migrate webhook_deliveries table
+// NOTE: unlike the chain tables, the webhook_deliveries table is owned by this service
+func (m *WebhookDeliveryCrud) Migrate() error {
+	err := m.db.AutoMigrate(m.model)
+	if err != nil {
+		return err
+	}
+
+	// One delivery per event per webhook, even with multiple replicas receiving the same event
+	err = m.db.Exec(
+		"CREATE UNIQUE INDEX IF NOT EXISTS webhook_deliveries_idx_webhook_id_event_hash ON webhook_deliveries (webhook_id, event_hash)",
+	).Error
+	if err != nil {
+		return err
+	}
+
+	// Due deliveries
+	err = m.db.Exec(
+		"CREATE INDEX IF NOT EXISTS webhook_deliveries_idx_status_next_attempt_timestamp ON webhook_deliveries (status, next_attempt_timestamp)",
+	).Error
+
+	return err
+}
+
+// InsertOne - insert one into webhook_deliveries table, ignoring duplicate events
+func (m *WebhookDeliveryCrud) InsertOne(ctx context.Context, webhookDelivery *models.WebhookDelivery) error {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.WebhookDelivery{})
+
+	db = db.Clauses(clause.OnConflict{DoNothing: true}).Create(webhookDelivery)
+
+	return db.Error
+}
+
+// InsertMany - insert many into webhook_deliveries table, ignoring duplicate events
+func (m *WebhookDeliveryCrud) InsertMany(ctx context.Context, webhookDeliveries *[]models.WebhookDelivery) error {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.WebhookDelivery{})
+
+	db = db.Clauses(clause.OnConflict{DoNothing: true}).Create(webhookDeliveries)
+
+	return db.Error
+}
+
+// ErrWebhookDeliveryLeaseLost - the delivery was claimed again after its lease expired
+var ErrWebhookDeliveryLeaseLost = errors.New("webhook delivery lease lost")
+
+// UpdateOneLeased - update one in webhook_deliveries table if it's still leased until leaseTimestamp
+// A lease is held by the next_attempt_timestamp set when claiming, once it expires another worker can claim the delivery
+func (m *WebhookDeliveryCrud) UpdateOneLeased(
+	ctx context.Context,
+	webhookDelivery *models.WebhookDelivery,
+	leaseTimestamp int64,
+) error {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.WebhookDelivery{})
+
+	// Still leased
+	db = db.Where("id = ? AND next_attempt_timestamp = ?", webhookDelivery.Id, leaseTimestamp)
+
+	db = db.Select("*").Omit("id").Updates(webhookDelivery)
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return ErrWebhookDeliveryLeaseLost
+	}
+
+	return nil
+}
+
+// ClaimDue - select pending deliveries that are due and lease them until leaseTimestamp
+// Rows locked by another replica are skipped, a lease that expires makes the delivery due again
+func (m *WebhookDeliveryCrud) ClaimDue(
+	ctx context.Context,
+	limit int,
+	nowTimestamp int64,
+	leaseTimestamp int64,
+) (*[]models.WebhookDelivery, error) {
+	db := m.db.WithContext(ctx)
+
+	webhookDeliveries := &[]models.WebhookDelivery{}
+	db = db.Raw(`UPDATE webhook_deliveries
+	SET next_attempt_timestamp = ?
+	WHERE id IN (
+		SELECT
+			id
+		FROM
+			webhook_deliveries
+		WHERE
+			status = ? AND next_attempt_timestamp <= ?
+		ORDER BY next_attempt_timestamp
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING *`, leaseTimestamp, WebhookDeliveryStatusPending, nowTimestamp, limit).Scan(webhookDeliveries)
+
+	return webhookDeliveries, db.Error
+}
+
+// SelectMany - select many from webhook_deliveries table
+func (m *WebhookDeliveryCrud) SelectMany(
+	ctx context.Context,
+	limit int,
+	skip int,
+	webhookID int64,
+	status string,
+) (*[]models.WebhookDelivery, error) {
+	db := m.db.WithContext(ctx)
+
+	// Set table
+	db = db.Model(&models.WebhookDelivery{})
+
+	// Latest deliveries first
+	db = db.Order("id desc")
+
+	// Webhook
+	db = db.Where("webhook_id = ?", webhookID)
+
+	// Status
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	// Limit is required and defaulted to 1
+	db = db.Limit(limit)
+
+	// Skip
+	if skip != 0 {
+		db = db.Offset(skip)
+	}
+
+	webhookDeliveries := &[]models.WebhookDelivery{}
+	db = db.Find(webhookDeliveries)
+
+	return webhookDeliveries, db.Error
+}
+
+// CountBy - count from webhook_deliveries table
+func (m *WebhookDeliveryCrud) CountBy(
+	ctx context.Context,
+	webhookID int64,
+	status string,
+) (int64, error) {
+	db := m.db.WithContext(ctx)
+	db = db.Model(&models.WebhookDelivery{})
+
+	db = db.Where("webhook_id = ?", webhookID)
+
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	var count int64
+	db = db.Count(&count)
+	return count, db.Error
+}
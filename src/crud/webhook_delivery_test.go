@@ -0,0 +1,41 @@
+package crud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func TestWebhookDeliveryUpdateOneLeased(t *testing.T) {
+	assert := assert.New(t)
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	assert.Equal(nil, err)
+
+	webhookDeliveryCrud := &WebhookDeliveryCrud{db: db}
+
+	var statement *gorm.Statement
+	db.Callback().Update().After("gorm:update").Register("test:statement", func(db *gorm.DB) {
+		statement = db.Statement
+	})
+
+	// Nothing is updated in dry run mode, so the lease reads as lost
+	err = webhookDeliveryCrud.UpdateOneLeased(context.Background(), &models.WebhookDelivery{
+		Id:                   7,
+		Status:               WebhookDeliveryStatusDelivered,
+		NextAttemptTimestamp: 200,
+	}, 100)
+	assert.Equal(ErrWebhookDeliveryLeaseLost, err)
+
+	assert.Contains(statement.SQL.String(), `UPDATE "webhook_deliveries" SET `)
+	assert.NotContains(statement.SQL.String(), `"id"=`)
+	assert.Contains(statement.SQL.String(), `WHERE id = $`)
+	assert.Contains(statement.SQL.String(), ` AND next_attempt_timestamp = $`)
+	assert.Equal(int64(100), statement.Vars[len(statement.Vars)-1])
+	assert.Equal(int64(7), statement.Vars[len(statement.Vars)-2])
+}
@@ -0,0 +1,112 @@
+package freshness
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/metrics"
+	"github.com/sudoblockio/icon-go-api/service"
+)
+
+// Statuses
+const (
+	StatusOk       = "ok"
+	StatusDegraded = "degraded"
+	StatusUnknown  = "unknown"
+)
+
+// Status - how far the indexed data is behind the node
+type Status struct {
+	Status             string `json:"status"`
+	IndexedBlockNumber int64  `json:"indexed_block_number"`
+	NodeBlockNumber    int64  `json:"node_block_number"`
+	Lag                int64  `json:"lag"`
+	LagThreshold       int64  `json:"lag_threshold"`
+	UpdatedTimestamp   int64  `json:"updated_timestamp"`
+	Error              string `json:"error,omitempty"`
+}
+
+// Latest status, *Status
+var currentStatus atomic.Value
+
+func init() {
+	currentStatus.Store(&Status{Status: StatusUnknown})
+}
+
+// Start - poll the indexed and node block numbers
+func Start() {
+	go func() {
+		for {
+			Refresh()
+			time.Sleep(config.Config.IndexerLagPollInterval)
+		}
+	}()
+
+	zap.S().Info("Started Freshness")
+}
+
+// Refresh - compare the highest indexed block with the node's latest block
+func Refresh() {
+	status := &Status{
+		Status:           StatusOk,
+		LagThreshold:     config.Config.IndexerLagThreshold,
+		UpdatedTimestamp: time.Now().Unix(),
+	}
+
+	block, err := crud.GetBlockCrud().SelectOne(context.Background(), 0)
+	if err != nil {
+		zap.S().Warn("Freshness: Unable to get indexed block number ERROR=", err.Error())
+		status.Status = StatusUnknown
+		status.Error = "could not get indexed block number"
+		currentStatus.Store(status)
+		return
+	}
+	status.IndexedBlockNumber = block.Number
+
+	nodeBlockNumber, err := service.IconNodeServiceGetLastBlockNumber(context.Background())
+	if err != nil {
+		zap.S().Warn("Freshness: Unable to get node block number ERROR=", err.Error())
+		status.Status = StatusUnknown
+		status.Error = "could not get node block number"
+		currentStatus.Store(status)
+		return
+	}
+	status.NodeBlockNumber = nodeBlockNumber
+
+	// The node can be behind the indexer's source
+	status.Lag = nodeBlockNumber - block.Number
+	if status.Lag < 0 {
+		status.Lag = 0
+	}
+	if status.Lag > config.Config.IndexerLagThreshold {
+		status.Status = StatusDegraded
+	}
+
+	metrics.IndexedBlockNumberGauge.Set(float64(status.IndexedBlockNumber))
+	metrics.NodeBlockNumberGauge.Set(float64(status.NodeBlockNumber))
+	metrics.IndexerLagGauge.Set(float64(status.Lag))
+
+	currentStatus.Store(status)
+}
+
+// GetStatus - latest status
+func GetStatus() *Status {
+	return currentStatus.Load().(*Status)
+}
+
+// Check - health check, fails when degraded
+func Check() (interface{}, error) {
+	status := GetStatus()
+	if status.Status == StatusDegraded {
+		return status, errors.New("indexer is " + strconv.FormatInt(status.Lag, 10) + " blocks behind the node")
+	}
+
+	return status, nil
+}
@@ -0,0 +1,25 @@
+package freshness
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	// Unknown until the first refresh
+	assert.Equal(StatusUnknown, GetStatus().Status)
+	_, err := Check()
+	assert.Equal(nil, err)
+
+	currentStatus.Store(&Status{Status: StatusOk, Lag: 2, LagThreshold: 20})
+	_, err = Check()
+	assert.Equal(nil, err)
+
+	currentStatus.Store(&Status{Status: StatusDegraded, Lag: 50, LagThreshold: 20})
+	details, err := Check()
+	assert.NotEqual(nil, err)
+	assert.Equal(int64(50), details.(*Status).Lag)
+}
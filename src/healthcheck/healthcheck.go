@@ -1,11 +1,18 @@
 package healthcheck
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/freshness"
+	"github.com/sudoblockio/icon-go-api/redis"
+	"github.com/sudoblockio/icon-go-api/service"
 	"go.uber.org/zap"
 
 	"github.com/InVisionApp/go-health/v2"
@@ -14,33 +21,171 @@ import (
 )
 
 // Start - start health server
+// Liveness only checks the api responds, readiness checks its dependencies
 func Start() {
-	// create a new health instance
-	h := health.New()
+	interval := time.Duration(config.Config.HealthPollingInterval) * time.Second
 
-	// create a couple of checks
-	blocksCheckerURL, _ := url.Parse("http://localhost:" + config.Config.APIPort + "/version")
-	blocksChecker, _ := checkers.NewHTTP(&checkers.HTTPConfig{
-		URL: blocksCheckerURL,
+	// Liveness
+	liveness := health.New()
+
+	apiCheckerURL, _ := url.Parse("http://localhost:" + config.Config.APIPort + "/version")
+	apiChecker, _ := checkers.NewHTTP(&checkers.HTTPConfig{
+		URL:     apiCheckerURL,
+		Timeout: config.Config.HealthCheckTimeout,
 	})
 
-	// Add the checks to the health instance
-	h.AddChecks([]*health.Config{
+	liveness.AddChecks([]*health.Config{
 		{
 			Name:     "blocks-rest-check",
-			Checker:  blocksChecker,
-			Interval: time.Duration(config.Config.HealthPollingInterval) * time.Second,
+			Checker:  apiChecker,
+			Interval: interval,
+			Fatal:    true,
+		},
+	})
+
+	// Readiness
+	readiness := health.New()
+
+	readiness.AddChecks([]*health.Config{
+		{
+			Name:     "postgres-check",
+			Checker:  &LatencyChecker{Check: checkPostgres},
+			Interval: interval,
+			Fatal:    true,
+		},
+		{
+			Name:     "redis-check",
+			Checker:  &LatencyChecker{Check: checkRedis},
+			Interval: interval,
+			Fatal:    true,
+		},
+		{
+			Name:     "redis-subscribers-check",
+			Checker:  &LatencyChecker{Check: checkSubscribers},
+			Interval: interval,
 			Fatal:    true,
 		},
+		{
+			// Degraded, shared by every replica so only fatal if configured
+			Name:     "icon-node-check",
+			Checker:  &LatencyChecker{Check: checkIconNode},
+			Interval: interval,
+			Fatal:    config.Config.HealthIconNodeFatal,
+		},
+		{
+			// Degraded, shared by every replica so only fatal if configured
+			Name:     "indexer-lag-check",
+			Checker:  &LatencyChecker{Check: freshness.Check},
+			Interval: interval,
+			Fatal:    config.Config.IndexerLagFatal,
+		},
 	})
 
 	//  Start the healthcheck process
-	if err := h.Start(); err != nil {
-		zap.S().Fatalf("Unable to start healthcheck: %v", err)
+	if err := liveness.Start(); err != nil {
+		zap.S().Fatalf("Unable to start liveness healthcheck: %v", err)
+	}
+	if err := readiness.Start(); err != nil {
+		zap.S().Fatalf("Unable to start readiness healthcheck: %v", err)
 	}
 
-	// Define a healthcheck endpoint and use the built-in JSON handler
-	http.HandleFunc(config.Config.HealthPrefix, handlers.NewJSONHandlerFunc(h, nil))
+	// Define healthcheck endpoints and use the built-in JSON handler
+	http.HandleFunc(config.Config.HealthPrefix, handlers.NewJSONHandlerFunc(liveness, nil))
+	http.HandleFunc(config.Config.HealthLivenessPrefix, handlers.NewJSONHandlerFunc(liveness, nil))
+	http.HandleFunc(config.Config.HealthReadinessPrefix, handlers.NewJSONHandlerFunc(readiness, nil))
 	go http.ListenAndServe(":"+config.Config.HealthPort, nil)
 	zap.S().Info("Started Healthcheck:", config.Config.HealthPort)
 }
+
+// LatencyChecker - health check reporting how long it took
+type LatencyChecker struct {
+	Check func() (interface{}, error)
+}
+
+// LatencyDetails - details of a LatencyChecker check
+type LatencyDetails struct {
+	LatencyMilli float64     `json:"latency_ms"`
+	Details      interface{} `json:"details,omitempty"`
+}
+
+// Status - implements health.ICheckable
+func (l *LatencyChecker) Status() (interface{}, error) {
+	start := time.Now()
+	details, err := l.Check()
+
+	return &LatencyDetails{
+		LatencyMilli: float64(time.Since(start).Microseconds()) / 1000,
+		Details:      details,
+	}, err
+}
+
+func checkPostgres() (interface{}, error) {
+	return nil, crud.PingPostgres(config.Config.HealthCheckTimeout)
+}
+
+func checkRedis() (interface{}, error) {
+	return nil, redis.GetRedisClient().Ping(config.Config.HealthCheckTimeout)
+}
+
+// checkSubscribers - subscribers are running and blocks are arriving
+func checkSubscribers() (interface{}, error) {
+	statuses := redis.GetSubscriberStatuses()
+
+	channelNames := []string{
+		config.Config.RedisBlocksChannel,
+		config.Config.RedisTransactionsChannel,
+		config.Config.RedisLogsChannel,
+		config.Config.RedisTokenTransfersChannel,
+	}
+	for _, channelName := range channelNames {
+		status, ok := statuses[channelName]
+		if ok == false || status.Running == false {
+			return statuses, errors.New("subscriber not running CHANNEL=" + channelName)
+		}
+	}
+
+	// Other channels can be quiet, a block is expected every few seconds
+	blocksStatus := statuses[config.Config.RedisBlocksChannel]
+	if blocksStatus.LastMessageTimestamp != 0 &&
+		time.Since(time.Unix(blocksStatus.LastMessageTimestamp, 0)) > config.Config.HealthSubscriberTimeout {
+		return statuses, errors.New("no blocks received within " + config.Config.HealthSubscriberTimeout.String())
+	}
+
+	return statuses, nil
+}
+
+// IconNodeDetails - reachability of an icon node url
+type IconNodeDetails struct {
+	Url          string  `json:"url"`
+	LatencyMilli float64 `json:"latency_ms"`
+	BlockHeight  int64   `json:"block_height"`
+	Error        string  `json:"error,omitempty"`
+}
+
+// checkIconNode - at least one icon node url is reachable
+func checkIconNode() (interface{}, error) {
+	details := []IconNodeDetails{}
+	errs := []string{}
+
+	for _, iconNodeURL := range config.Config.IconNodeServiceURL {
+		start := time.Now()
+		height, err := service.IconNodeServiceGetLastBlockHeight(context.Background(), iconNodeURL, config.Config.HealthCheckTimeout)
+
+		nodeDetails := IconNodeDetails{
+			Url:          iconNodeURL,
+			LatencyMilli: float64(time.Since(start).Microseconds()) / 1000,
+			BlockHeight:  height,
+		}
+		if err != nil {
+			nodeDetails.Error = err.Error()
+			errs = append(errs, iconNodeURL+": "+err.Error())
+		}
+		details = append(details, nodeDetails)
+	}
+
+	if len(errs) == len(config.Config.IconNodeServiceURL) {
+		return details, errors.New("no icon node reachable " + strings.Join(errs, ", "))
+	}
+
+	return details, nil
+}
@@ -4,8 +4,10 @@
 package healthcheck
 
 import (
+	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/sudoblockio/icon-go-api/api"
@@ -28,4 +30,27 @@ func TestHealthCheck(t *testing.T) {
 	resp, err := http.Get("http://localhost:" + config.Config.HealthPort + config.Config.HealthPrefix)
 	assert.Equal(nil, err)
 	assert.Equal(200, resp.StatusCode)
+
+	resp, err = http.Get("http://localhost:" + config.Config.HealthPort + config.Config.HealthLivenessPrefix)
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	// Readiness depends on postgres, redis, and the icon node
+	resp, err = http.Get("http://localhost:" + config.Config.HealthPort + config.Config.HealthReadinessPrefix)
+	assert.Equal(nil, err)
+	assert.Contains([]int{200, 500}, resp.StatusCode)
+}
+
+func TestLatencyChecker(t *testing.T) {
+	assert := assert.New(t)
+
+	checker := &LatencyChecker{Check: func() (interface{}, error) {
+		time.Sleep(10 * time.Millisecond)
+		return "details", errors.New("check failed")
+	}}
+
+	details, err := checker.Status()
+	assert.Equal("check failed", err.Error())
+	assert.Equal("details", details.(*LatencyDetails).Details)
+	assert.GreaterOrEqual(details.(*LatencyDetails).LatencyMilli, float64(10))
 }
@@ -3,14 +3,22 @@ package main
 import (
 	"log"
 
+	"go.uber.org/zap"
+
 	"github.com/sudoblockio/icon-go-api/api"
+	"github.com/sudoblockio/icon-go-api/apikeys"
 	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/freshness"
 	"github.com/sudoblockio/icon-go-api/global"
 	"github.com/sudoblockio/icon-go-api/healthcheck"
 	"github.com/sudoblockio/icon-go-api/logging"
 	"github.com/sudoblockio/icon-go-api/metrics"
 	_ "github.com/sudoblockio/icon-go-api/models" // for swagger docs
+	"github.com/sudoblockio/icon-go-api/prices"
 	"github.com/sudoblockio/icon-go-api/redis"
+	"github.com/sudoblockio/icon-go-api/stats"
+	"github.com/sudoblockio/icon-go-api/tracing"
+	"github.com/sudoblockio/icon-go-api/webhooks"
 )
 
 func main() {
@@ -22,10 +30,42 @@ func main() {
 	// Start Prometheus client
 	metrics.Start()
 
+	// Start Tracing
+	// NOTE: spans are no-ops when disabled
+	if config.Config.TracingEnabled {
+		tracing.Start()
+	}
+
 	// Start Redis Client
 	// NOTE: redis is used for websockets
 	redis.GetRedisClient().StartSubscribers()
 
+	// Start Freshness
+	// NOTE: indexer lag is reported in the status endpoint, headers, and readiness
+	freshness.Start()
+
+	// Start Prices
+	// NOTE: prices are used for the market cap and portfolio values
+	prices.Start()
+
+	// Start Stats
+	// NOTE: supplies and market cap are refreshed in the background, not in requests
+	stats.Start()
+
+	// Start Webhooks
+	// NOTE: webhooks are dispatched from the redis subscribers
+	if webhooks.Active() {
+		webhooks.Start()
+	} else if config.Config.WebhooksEnabled {
+		zap.S().Warn("Webhooks are not started without ADMIN_API_KEY")
+	}
+
+	// Start ApiKeys
+	// NOTE: api keys are only used for rate limiting
+	if config.Config.RateLimitEnabled {
+		apikeys.Start()
+	}
+
 	// Start API server
 	api.Start()
 
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+// NewFiberMiddleware - record request count and latency by route template
+func NewFiberMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+
+		// Status is only set by the error handler after the middleware returns
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			fiberErr := &fiber.Error{}
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		// Route template, not the path, to keep the labels bounded
+		// Unmatched requests have the middleware's route, /
+		route := c.Route().Path
+
+		labels := []string{c.Method(), route, strconv.Itoa(status)}
+		HTTPRequestsCounter.WithLabelValues(labels...).Inc()
+		HTTPRequestDurationHistogram.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
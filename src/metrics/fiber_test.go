@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiberMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	app := fiber.New()
+	app.Use(NewFiberMiddleware())
+	app.Get("/api/v1/blocks/:number", func(c *fiber.Ctx) error {
+		return c.SendString("{}")
+	})
+
+	for _, path := range []string{"/api/v1/blocks/1", "/api/v1/blocks/2", "/not-found"} {
+		_, err := app.Test(httptest.NewRequest("GET", path, nil))
+		assert.Equal(nil, err)
+	}
+
+	// By route template
+	assert.Equal(float64(2), testutil.ToFloat64(HTTPRequestsCounter.WithLabelValues("GET", "/api/v1/blocks/:number", "200")))
+	assert.Equal(float64(1), testutil.ToFloat64(HTTPRequestsCounter.WithLabelValues("GET", "/", "404")))
+	assert.Equal(2, testutil.CollectAndCount(HTTPRequestsCounter))
+}
@@ -12,7 +12,6 @@ import (
 )
 
 // Metrics
-// TODO: Implement metrics
 var (
 	MaxBlockNumberBlocksRawGauge = promauto.NewGauge(prometheus.GaugeOpts{
 		Name:        "max_block_number_blocks_raw",
@@ -29,6 +28,103 @@ var (
 		Help:        "max block number read from the logs_raw topic",
 		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
 	})
+	IndexedBlockNumberGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name:        "indexed_block_number",
+		Help:        "highest block number in the blocks table",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	})
+	NodeBlockNumberGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name:        "node_block_number",
+		Help:        "latest block number of the icon node",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	})
+	IndexerLagGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name:        "indexer_lag_blocks",
+		Help:        "blocks the blocks table is behind the icon node",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	})
+
+	// HTTP
+	HTTPRequestsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "http_requests_total",
+		Help:        "http requests by method, route template, and status",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"method", "route", "status"})
+	HTTPRequestDurationHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:        "http_request_duration_seconds",
+		Help:        "http request latency by method, route template, and status",
+		Buckets:     prometheus.DefBuckets,
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"method", "route", "status"})
+
+	// Database
+	DBQueryDurationHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:        "db_query_duration_seconds",
+		Help:        "database query latency by table and operation",
+		Buckets:     prometheus.DefBuckets,
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"table", "operation"})
+	DBQueryErrorsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "db_query_errors_total",
+		Help:        "database query errors by table and operation, excluding record not found",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"table", "operation"})
+	DBPoolQueriesCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "db_pool_queries_total",
+		Help:        "read queries routed to each connection pool",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"pool"})
+	DBPoolConnectionsGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "db_pool_connections",
+		Help:        "connections of each connection pool by state, in_use or idle",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"pool", "state"})
+	DBPoolHealthyGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "db_pool_healthy",
+		Help:        "1 if the replica pool is receiving reads, 0 if it is down or lagging",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"pool"})
+	DBPoolLagGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "db_pool_lag_blocks",
+		Help:        "blocks the replica pool's blocks table is behind the primary",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"pool"})
+
+	// Redis
+	RedisCommandsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "redis_commands_total",
+		Help:        "redis commands by command",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"command"})
+	RedisErrorsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "redis_errors_total",
+		Help:        "redis command errors by command, excluding missing keys",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"command"})
+	ResponseCacheCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "response_cache_requests_total",
+		Help:        "cacheable requests by result, hit or miss",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"result"})
+
+	// Websockets
+	WebsocketConnectionsGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "websocket_connections",
+		Help:        "open websocket connections by channel",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"channel"})
+	BroadcasterDropsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "broadcaster_drops_total",
+		Help:        "messages dropped for slow consumers by channel and slow consumer policy",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"channel", "policy"})
+
+	// Webhooks
+	WebhookEventsDroppedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "webhook_events_dropped_total",
+		Help:        "events not dispatched to webhooks by event type",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"event_type"})
 )
 
 func Start() {
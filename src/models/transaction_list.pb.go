@@ -34,6 +34,8 @@ type TransactionList struct {
 	ValueDecimal         float64  `protobuf:"fixed64,11,opt,name=value_decimal,json=valueDecimal,proto3" json:"value_decimal"`
 	Data                 string   `protobuf:"bytes,12,opt,name=data,proto3" json:"data"`
 	TransactionType      int32    `protobuf:"varint,13,opt,name=transaction_type,json=transactionType,proto3" json:"transaction_type"`
+	TransactionIndex     int64    `protobuf:"varint,14,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index"`
+	LogIndex             int64    `protobuf:"varint,15,opt,name=log_index,json=logIndex,proto3" json:"log_index"`
 }
 
 func (m *TransactionList) Reset()         { *m = TransactionList{} }
@@ -152,6 +154,20 @@ func (m *TransactionList) GetTransactionType() int32 {
 	return 0
 }
 
+func (m *TransactionList) GetTransactionIndex() int64 {
+	if m != nil {
+		return m.TransactionIndex
+	}
+	return 0
+}
+
+func (m *TransactionList) GetLogIndex() int64 {
+	if m != nil {
+		return m.LogIndex
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*TransactionList)(nil), "models.TransactionList")
 }
@@ -161,24 +177,26 @@ func init() {
 }
 
 var fileDescriptor_396544a4d5fcb28a = []byte{
-	// 300 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0xcf, 0x4e, 0xc3, 0x30,
-	0x0c, 0xc6, 0x15, 0xb6, 0x95, 0xcd, 0xfb, 0x87, 0x22, 0x34, 0xe5, 0x82, 0x54, 0xe0, 0x40, 0xb9,
-	0x8c, 0x03, 0x4f, 0x00, 0x42, 0x9c, 0x10, 0x87, 0x6a, 0x27, 0x2e, 0x55, 0xb6, 0x78, 0x5a, 0x45,
-	0xd3, 0x54, 0x8d, 0x8b, 0xb4, 0x57, 0xe1, 0x69, 0x51, 0x1c, 0x06, 0xbd, 0xd9, 0x3f, 0x7f, 0xfe,
-	0x6c, 0xcb, 0xb0, 0xa2, 0x56, 0xd7, 0x5e, 0xef, 0xa8, 0x74, 0x75, 0x51, 0x95, 0x9e, 0xd6, 0x4d,
-	0xeb, 0xc8, 0xc9, 0xc4, 0x3a, 0x83, 0x95, 0xbf, 0xf9, 0x1e, 0xc0, 0x72, 0xf3, 0x2f, 0x79, 0x2b,
-	0x3d, 0xc9, 0x6b, 0x98, 0xed, 0x5b, 0x67, 0x0b, 0x6d, 0x4c, 0x8b, 0xde, 0x2b, 0x91, 0x8a, 0x6c,
-	0x92, 0x4f, 0x03, 0x7b, 0x8a, 0x48, 0x5e, 0x01, 0x90, 0xfb, 0x13, 0x9c, 0xb1, 0x60, 0x42, 0xee,
-	0x54, 0xbe, 0x84, 0xd1, 0x97, 0xae, 0x3a, 0x54, 0x03, 0xae, 0xc4, 0x44, 0xde, 0xc1, 0x72, 0x5b,
-	0xb9, 0xdd, 0x67, 0x41, 0xa5, 0x45, 0x4f, 0xda, 0x36, 0x6a, 0x98, 0x8a, 0x6c, 0x90, 0x2f, 0x18,
-	0x6f, 0x4e, 0x54, 0x4a, 0x18, 0x1e, 0xb4, 0x3f, 0xa8, 0x11, 0x77, 0x73, 0x1c, 0x96, 0x8a, 0xcd,
-	0x75, 0x67, 0xb7, 0xd8, 0xaa, 0x84, 0x3b, 0xa7, 0xcc, 0xde, 0x19, 0x05, 0xff, 0xfe, 0xb5, 0x7b,
-	0x44, 0x75, 0xce, 0x0e, 0x8b, 0x1e, 0x7e, 0x45, 0x94, 0x2b, 0x48, 0x3c, 0x69, 0xea, 0xbc, 0x1a,
-	0x73, 0xfd, 0x37, 0x0b, 0x73, 0xe9, 0xd8, 0xa0, 0x9a, 0xc4, 0xb9, 0x21, 0x0e, 0x5a, 0x8b, 0x74,
-	0x70, 0x46, 0x41, 0xd4, 0xc6, 0x4c, 0xde, 0xc2, 0x9c, 0xaf, 0x2a, 0x0c, 0xee, 0x4a, 0xab, 0x2b,
-	0x35, 0x4d, 0x45, 0x26, 0xf2, 0x19, 0xc3, 0x97, 0xc8, 0x82, 0xa1, 0xd1, 0xa4, 0xd5, 0x2c, 0x1a,
-	0x86, 0x58, 0xde, 0xc3, 0x45, 0x7f, 0x4b, 0x1e, 0x38, 0x4f, 0x45, 0x36, 0xca, 0xfb, 0xdb, 0x6f,
-	0x8e, 0x0d, 0x3e, 0xc3, 0xc7, 0x78, 0xfd, 0x10, 0x1f, 0xb5, 0x4d, 0xf8, 0x6f, 0x8f, 0x3f, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0x5a, 0x49, 0x3a, 0xbe, 0xd1, 0x01, 0x00, 0x00,
+	// 330 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x92, 0x4f, 0x4b, 0xf3, 0x40,
+	0x10, 0xc6, 0xc9, 0xdb, 0x36, 0x6f, 0x33, 0xfd, 0xa7, 0x8b, 0x94, 0x05, 0x11, 0xa2, 0x1e, 0x8c,
+	0x08, 0xf5, 0xe0, 0x27, 0x50, 0x44, 0x10, 0xc4, 0x43, 0xe8, 0xc9, 0x4b, 0xd8, 0x36, 0xd3, 0x36,
+	0xb8, 0x9b, 0x2d, 0xd9, 0xa9, 0xe8, 0xf7, 0xf5, 0x83, 0xc8, 0xce, 0xb6, 0x9a, 0xdb, 0xcc, 0x6f,
+	0x9e, 0x79, 0x66, 0x86, 0x5d, 0x98, 0x52, 0xa3, 0x6a, 0xa7, 0x96, 0x54, 0xd9, 0xba, 0xd0, 0x95,
+	0xa3, 0xd9, 0xb6, 0xb1, 0x64, 0x45, 0x6c, 0x6c, 0x89, 0xda, 0x5d, 0x7c, 0x77, 0x60, 0x32, 0xff,
+	0x93, 0xbc, 0x54, 0x8e, 0xc4, 0x39, 0x0c, 0x57, 0x8d, 0x35, 0x85, 0x2a, 0xcb, 0x06, 0x9d, 0x93,
+	0x51, 0x1a, 0x65, 0x49, 0x3e, 0xf0, 0xec, 0x3e, 0x20, 0x71, 0x06, 0x40, 0xf6, 0x57, 0xf0, 0x8f,
+	0x05, 0x09, 0xd9, 0x43, 0xf9, 0x04, 0x7a, 0x1f, 0x4a, 0xef, 0x50, 0x76, 0xb8, 0x12, 0x12, 0x71,
+	0x05, 0x93, 0x85, 0xb6, 0xcb, 0xf7, 0x82, 0x2a, 0x83, 0x8e, 0x94, 0xd9, 0xca, 0x6e, 0x1a, 0x65,
+	0x9d, 0x7c, 0xcc, 0x78, 0x7e, 0xa0, 0x42, 0x40, 0x77, 0xa3, 0xdc, 0x46, 0xf6, 0xb8, 0x9b, 0x63,
+	0xbf, 0x54, 0x68, 0xae, 0x77, 0x66, 0x81, 0x8d, 0x8c, 0xb9, 0x73, 0xc0, 0xec, 0x95, 0x91, 0xf7,
+	0x6f, 0x5f, 0xbb, 0x42, 0x94, 0xff, 0xd9, 0x61, 0xdc, 0xc2, 0x4f, 0x88, 0x62, 0x0a, 0xb1, 0x23,
+	0x45, 0x3b, 0x27, 0xfb, 0x5c, 0xdf, 0x67, 0x7e, 0x2e, 0x7d, 0x6d, 0x51, 0x26, 0x61, 0xae, 0x8f,
+	0xbd, 0xd6, 0x20, 0x6d, 0x6c, 0x29, 0x21, 0x68, 0x43, 0x26, 0x2e, 0x61, 0xc4, 0x57, 0x15, 0x25,
+	0x2e, 0x2b, 0xa3, 0xb4, 0x1c, 0xa4, 0x51, 0x16, 0xe5, 0x43, 0x86, 0x8f, 0x81, 0x79, 0xc3, 0x52,
+	0x91, 0x92, 0xc3, 0x60, 0xe8, 0x63, 0x71, 0x0d, 0x47, 0xed, 0x2d, 0x79, 0xe0, 0x28, 0x8d, 0xb2,
+	0x5e, 0xde, 0xde, 0x7e, 0xee, 0x67, 0xdf, 0xc0, 0x71, 0x5b, 0x5a, 0xd5, 0x25, 0x7e, 0xca, 0x31,
+	0x1f, 0xde, 0xf6, 0x78, 0xf6, 0x5c, 0x9c, 0x42, 0xa2, 0xed, 0x7a, 0x2f, 0x9a, 0xb0, 0xa8, 0xaf,
+	0xed, 0x9a, 0x8b, 0x0f, 0xf0, 0xd6, 0x9f, 0xdd, 0x86, 0x27, 0x5f, 0xc4, 0xfc, 0x03, 0xee, 0x7e,
+	0x06, 0x00, 0x35, 0x80, 0xda, 0x88, 0x1b, 0x02, 0x00, 0x00,
 }
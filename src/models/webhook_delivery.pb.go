@@ -0,0 +1,176 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: webhook_delivery.proto
+
+package models
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type WebhookDelivery struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+	WebhookId            int64    `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id"`
+	EventType            string   `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type"`
+	EventHash            string   `protobuf:"bytes,4,opt,name=event_hash,json=eventHash,proto3" json:"event_hash"`
+	Payload              string   `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload"`
+	Status               string   `protobuf:"bytes,6,opt,name=status,proto3" json:"status"`
+	Attempts             int64    `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts"`
+	NextAttemptTimestamp int64    `protobuf:"varint,8,opt,name=next_attempt_timestamp,json=nextAttemptTimestamp,proto3" json:"next_attempt_timestamp"`
+	LastStatusCode       int64    `protobuf:"varint,9,opt,name=last_status_code,json=lastStatusCode,proto3" json:"last_status_code"`
+	LastError            string   `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error"`
+	CreatedTimestamp     int64    `protobuf:"varint,11,opt,name=created_timestamp,json=createdTimestamp,proto3" json:"created_timestamp"`
+	UpdatedTimestamp     int64    `protobuf:"varint,12,opt,name=updated_timestamp,json=updatedTimestamp,proto3" json:"updated_timestamp"`
+}
+
+func (m *WebhookDelivery) Reset()         { *m = WebhookDelivery{} }
+func (m *WebhookDelivery) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery) ProtoMessage()    {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c94f9645aae4d49, []int{0}
+}
+
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery.Unmarshal(m, b)
+}
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
+}
+func (m *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(m, src)
+}
+func (m *WebhookDelivery) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery.Size(m)
+}
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
+
+func (m *WebhookDelivery) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetWebhookId() int64 {
+	if m != nil {
+		return m.WebhookId
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetEventHash() string {
+	if m != nil {
+		return m.EventHash
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetAttempts() int64 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetNextAttemptTimestamp() int64 {
+	if m != nil {
+		return m.NextAttemptTimestamp
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetLastStatusCode() int64 {
+	if m != nil {
+		return m.LastStatusCode
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetCreatedTimestamp() int64 {
+	if m != nil {
+		return m.CreatedTimestamp
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetUpdatedTimestamp() int64 {
+	if m != nil {
+		return m.UpdatedTimestamp
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*WebhookDelivery)(nil), "models.WebhookDelivery")
+}
+
+func init() {
+	proto.RegisterFile("webhook_delivery.proto", fileDescriptor_6c94f9645aae4d49)
+}
+
+var fileDescriptor_6c94f9645aae4d49 = []byte{
+	// 291 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x91, 0xcd, 0x4a, 0x03, 0x31,
+	0x10, 0x80, 0x69, 0xab, 0xdb, 0xee, 0x28, 0xb5, 0x0d, 0x52, 0x82, 0x20, 0x14, 0x4f, 0x85, 0x42,
+	0x3d, 0xe8, 0x0b, 0xf8, 0x07, 0x7a, 0xad, 0x05, 0xc1, 0xcb, 0x92, 0x36, 0x03, 0xbb, 0xb8, 0xdb,
+	0x84, 0x64, 0x5a, 0xdd, 0x07, 0xf2, 0x3d, 0x65, 0x27, 0xe9, 0x0f, 0x1e, 0xe7, 0xfb, 0x3e, 0x32,
+	0x09, 0x81, 0xd1, 0x37, 0x2e, 0x73, 0x63, 0xbe, 0x32, 0x8d, 0x65, 0xb1, 0x45, 0x57, 0xcf, 0xac,
+	0x33, 0x64, 0x44, 0x52, 0x19, 0x8d, 0xa5, 0xbf, 0xf9, 0xed, 0xc0, 0xc5, 0x47, 0x48, 0x9e, 0x63,
+	0x21, 0xfa, 0xd0, 0x2e, 0xb4, 0x6c, 0x8d, 0x5b, 0x93, 0xce, 0xbc, 0x5d, 0x68, 0x71, 0x0d, 0xb0,
+	0x3b, 0xa5, 0xd0, 0xb2, 0xcd, 0x3c, 0x8d, 0xe4, 0x8d, 0x35, 0x6e, 0x71, 0x4d, 0x19, 0xd5, 0x16,
+	0x65, 0x67, 0xdc, 0x9a, 0xa4, 0xf3, 0x94, 0xc9, 0xa2, 0xb6, 0x78, 0xd0, 0xb9, 0xf2, 0xb9, 0x3c,
+	0x39, 0xd2, 0xaf, 0xca, 0xe7, 0x42, 0x42, 0xd7, 0xaa, 0xba, 0x34, 0x4a, 0xcb, 0x53, 0x76, 0xbb,
+	0x51, 0x8c, 0x20, 0xf1, 0xa4, 0x68, 0xe3, 0x65, 0xc2, 0x22, 0x4e, 0xe2, 0x0a, 0x7a, 0x8a, 0x08,
+	0x2b, 0x4b, 0x5e, 0x76, 0xf9, 0x32, 0xfb, 0x59, 0xdc, 0xc3, 0x68, 0x8d, 0x3f, 0x94, 0x45, 0x90,
+	0x51, 0x51, 0xa1, 0x27, 0x55, 0x59, 0xd9, 0xe3, 0xf2, 0xb2, 0xb1, 0x0f, 0x41, 0x2e, 0x76, 0x4e,
+	0x4c, 0x60, 0x50, 0x2a, 0x4f, 0x59, 0x58, 0x90, 0xad, 0x8c, 0x46, 0x99, 0x72, 0xdf, 0x6f, 0xf8,
+	0x3b, 0xe3, 0x27, 0xa3, 0xf9, 0x31, 0x5c, 0xa2, 0x73, 0xc6, 0x49, 0x08, 0x8f, 0x69, 0xc8, 0x4b,
+	0x03, 0xc4, 0x14, 0x86, 0x2b, 0x87, 0x8a, 0x50, 0x1f, 0x6d, 0x3e, 0xe3, 0x93, 0x06, 0x51, 0x1c,
+	0xb6, 0x4e, 0x61, 0xb8, 0xb1, 0xfa, 0x5f, 0x7c, 0x1e, 0xe2, 0x28, 0xf6, 0xf1, 0x23, 0x7c, 0xf6,
+	0x66, 0xb7, 0xe1, 0xcf, 0x96, 0x09, 0x7f, 0xe1, 0xdd, 0xdf, 0x00, 0x02, 0xe2, 0xaf, 0xd6, 0xdc,
+	0x01, 0x00, 0x00,
+}
@@ -0,0 +1,154 @@
+package prices
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// PriceProvider - source of USD prices
+type PriceProvider interface {
+	// Name - for logs
+	Name() string
+
+	// Prices - USD prices by price id, only the ids the provider knows
+	Prices(ctx context.Context) (map[string]float64, error)
+}
+
+// FallbackPriceProvider - provider of fixed prices
+// Its prices are read once and only used when no other provider has a fresh price, never mixed into the median
+type FallbackPriceProvider interface {
+	PriceProvider
+
+	Fallback() bool
+}
+
+// quote - price from a provider and when it was fetched
+type quote struct {
+	price     float64
+	updatedAt time.Time
+}
+
+// Oracle - median of the fresh prices of its providers
+type Oracle struct {
+	providers []PriceProvider
+	maxAge    time.Duration
+
+	// Last prices of each provider, by price id
+	quotes    []map[string]quote
+	fallbacks []bool
+	mux       sync.RWMutex
+
+	// For tests
+	now func() time.Time
+}
+
+// NewOracle - oracle over providers, prices older than maxAge are ignored, 0 for no limit
+func NewOracle(providers []PriceProvider, maxAge time.Duration) *Oracle {
+	quotes := make([]map[string]quote, len(providers))
+	fallbacks := make([]bool, len(providers))
+	for i, provider := range providers {
+		quotes[i] = map[string]quote{}
+
+		if fallbackProvider, ok := provider.(FallbackPriceProvider); ok {
+			fallbacks[i] = fallbackProvider.Fallback()
+		}
+	}
+
+	return &Oracle{
+		providers: providers,
+		maxAge:    maxAge,
+		quotes:    quotes,
+		fallbacks: fallbacks,
+		now:       time.Now,
+	}
+}
+
+// Refresh - fetch prices from every provider
+// A provider that fails keeps its last known good prices
+// Fallback providers are only read until they have prices, they are stamped when loaded
+func (o *Oracle) Refresh(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for i, provider := range o.providers {
+		o.mux.RLock()
+		loaded := o.fallbacks[i] && len(o.quotes[i]) > 0
+		o.mux.RUnlock()
+		if loaded {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, provider PriceProvider) {
+			defer wg.Done()
+
+			prices, err := provider.Prices(ctx)
+			if err != nil {
+				zap.S().Warn("Prices: Unable to get prices PROVIDER=", provider.Name(), " ERROR=", err.Error())
+				return
+			}
+
+			updatedAt := o.now()
+
+			o.mux.Lock()
+			defer o.mux.Unlock()
+			for id, price := range prices {
+				o.quotes[i][strings.ToLower(id)] = quote{price: price, updatedAt: updatedAt}
+			}
+		}(i, provider)
+	}
+
+	wg.Wait()
+}
+
+// Get - median of the providers' fresh prices for an id, false if there are none
+// Fallback prices are only used when no other provider has a fresh price, they don't go stale
+func (o *Oracle) Get(id string) (float64, bool) {
+	id = strings.ToLower(id)
+	now := o.now()
+
+	o.mux.RLock()
+	defer o.mux.RUnlock()
+
+	prices := []float64{}
+	fallbackPrices := []float64{}
+	for i, providerQuotes := range o.quotes {
+		q, ok := providerQuotes[id]
+		if ok == false {
+			continue
+		}
+		if o.fallbacks[i] {
+			fallbackPrices = append(fallbackPrices, q.price)
+			continue
+		}
+		if o.maxAge != 0 && now.Sub(q.updatedAt) > o.maxAge {
+			// Stale
+			continue
+		}
+		prices = append(prices, q.price)
+	}
+
+	if len(prices) == 0 {
+		prices = fallbackPrices
+	}
+	if len(prices) == 0 {
+		return 0, false
+	}
+
+	return median(prices), true
+}
+
+func median(values []float64) float64 {
+	sort.Float64s(values)
+
+	middle := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[middle-1] + values[middle]) / 2
+	}
+
+	return values[middle]
+}
@@ -0,0 +1,108 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProvider struct {
+	prices map[string]float64
+	err    error
+}
+
+func (p *fakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *fakeProvider) Prices(ctx context.Context) (map[string]float64, error) {
+	return p.prices, p.err
+}
+
+func TestOracleMedian(t *testing.T) {
+	assert := assert.New(t)
+
+	oracle := NewOracle([]PriceProvider{
+		&fakeProvider{prices: map[string]float64{"icx": 0.2, "cx1": 1}},
+		&fakeProvider{prices: map[string]float64{"ICX": 0.3}},
+		&fakeProvider{prices: map[string]float64{"icx": 10}},
+	}, 0)
+	oracle.Refresh(context.Background())
+
+	// Outlier ignored
+	price, ok := oracle.Get("icx")
+	assert.Equal(true, ok)
+	assert.Equal(0.3, price)
+
+	// Even count
+	assert.Equal(1.0, median([]float64{2, 0}))
+
+	price, ok = oracle.Get("CX1")
+	assert.Equal(true, ok)
+	assert.Equal(1.0, price)
+
+	_, ok = oracle.Get("cx2")
+	assert.Equal(false, ok)
+}
+
+func TestOracleStaleness(t *testing.T) {
+	assert := assert.New(t)
+
+	provider := &fakeProvider{prices: map[string]float64{"icx": 0.25}}
+	oracle := NewOracle([]PriceProvider{provider}, time.Minute)
+
+	now := time.Now()
+	oracle.now = func() time.Time { return now }
+	oracle.Refresh(context.Background())
+
+	// Last known good price is kept while the provider fails
+	provider.err = errors.New("unavailable")
+	provider.prices = nil
+	now = now.Add(30 * time.Second)
+	oracle.Refresh(context.Background())
+
+	price, ok := oracle.Get("icx")
+	assert.Equal(true, ok)
+	assert.Equal(0.25, price)
+
+	// Until it's stale
+	now = now.Add(time.Minute)
+	_, ok = oracle.Get("icx")
+	assert.Equal(false, ok)
+}
+
+func TestOracleFallback(t *testing.T) {
+	assert := assert.New(t)
+
+	provider := &fakeProvider{prices: map[string]float64{"icx": 0.25}}
+	static := NewStaticProvider(map[string]float64{"icx": 1, "cx1": 2})
+	oracle := NewOracle([]PriceProvider{provider, static}, time.Minute)
+
+	now := time.Now()
+	oracle.now = func() time.Time { return now }
+	oracle.Refresh(context.Background())
+
+	// Not mixed into the median of fresh prices
+	price, ok := oracle.Get("icx")
+	assert.Equal(true, ok)
+	assert.Equal(0.25, price)
+
+	price, ok = oracle.Get("cx1")
+	assert.Equal(true, ok)
+	assert.Equal(2.0, price)
+
+	// Stamped once when loaded, not on each refresh
+	loadedAt := oracle.quotes[1]["icx"].updatedAt
+	now = now.Add(2 * time.Minute)
+	provider.err = errors.New("unavailable")
+	oracle.Refresh(context.Background())
+	assert.Equal(loadedAt, oracle.quotes[1]["icx"].updatedAt)
+
+	// Used once the other prices are stale
+	price, ok = oracle.Get("icx")
+	assert.Equal(true, ok)
+	assert.Equal(1.0, price)
+}
@@ -0,0 +1,99 @@
+package prices
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/config"
+)
+
+// IcxID - id of ICX's price, tokens are priced by contract address
+const IcxID = "icx"
+
+// Provider names
+const (
+	ProviderCoinGecko = "coingecko"
+	ProviderJSONURL   = "json_url"
+	ProviderStatic    = "static"
+	ProviderFile      = "file"
+)
+
+// Oracle in use, *Oracle
+var currentOracle atomic.Value
+
+func init() {
+	currentOracle.Store(NewOracle(nil, 0))
+}
+
+// Start - refresh prices from the configured providers
+func Start() {
+	client := &http.Client{
+		Timeout: config.Config.PricesRequestTimeout,
+	}
+
+	enabled := map[string]bool{}
+	providers := []PriceProvider{}
+	for _, name := range config.Config.PricesProviders {
+		enabled[strings.TrimSpace(name)] = true
+
+		switch strings.TrimSpace(name) {
+		case ProviderCoinGecko:
+			providers = append(providers, NewCoinGeckoProvider(
+				client,
+				config.Config.PricesCoinGeckoURL,
+				config.Config.PricesCoinGeckoAPIKey,
+				config.Config.PricesCoinGeckoIDs,
+			))
+		case ProviderJSONURL:
+			providers = append(providers, NewJSONURLProvider(client, config.Config.PricesJSONURL, config.Config.PricesJSONPaths))
+		case ProviderStatic:
+			providers = append(providers, NewStaticProvider(config.Config.PricesUSD))
+		case ProviderFile:
+			providers = append(providers, NewFileProvider(config.Config.PricesFile))
+		case "":
+		default:
+			zap.S().Fatal("Prices: Unknown provider PROVIDER=", name)
+		}
+	}
+
+	// Settings of a provider that isn't enabled are otherwise ignored silently
+	if len(config.Config.PricesUSD) > 0 && enabled[ProviderStatic] == false {
+		zap.S().Warn("Prices: PRICES_USD is set but the static provider isn't in PRICES_PROVIDERS, the prices are ignored")
+	}
+	if config.Config.PricesJSONURL != "" && enabled[ProviderJSONURL] == false {
+		zap.S().Warn("Prices: PRICES_JSON_URL is set but the json_url provider isn't in PRICES_PROVIDERS, the url is ignored")
+	}
+	if config.Config.PricesFile != "" && enabled[ProviderFile] == false {
+		zap.S().Warn("Prices: PRICES_FILE is set but the file provider isn't in PRICES_PROVIDERS, the file is ignored")
+	}
+
+	oracle := NewOracle(providers, config.Config.PricesMaxAge)
+	SetOracle(oracle)
+
+	go func() {
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), config.Config.PricesRequestTimeout)
+			oracle.Refresh(ctx)
+			cancel()
+
+			time.Sleep(config.Config.PricesRefreshInterval)
+		}
+	}()
+
+	zap.S().Info("Started Prices with ", len(providers), " providers")
+}
+
+// SetOracle - use an oracle built outside of the config
+func SetOracle(oracle *Oracle) {
+	currentOracle.Store(oracle)
+}
+
+// Get - USD price of ICX or a token, false if no provider has a fresh price
+func Get(id string) (float64, bool) {
+	return currentOracle.Load().(*Oracle).Get(id)
+}
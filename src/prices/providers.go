@@ -0,0 +1,230 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const userAgent = "icon-go-api"
+
+// CoinGeckoProvider - prices from CoinGecko's simple price endpoint
+type CoinGeckoProvider struct {
+	client *http.Client
+	url    string
+	apiKey string
+
+	// CoinGecko ids by price id
+	ids map[string]string
+}
+
+// NewCoinGeckoProvider - apiKey is optional, sent as a demo api key
+func NewCoinGeckoProvider(client *http.Client, url string, apiKey string, ids map[string]string) *CoinGeckoProvider {
+	return &CoinGeckoProvider{
+		client: client,
+		url:    strings.TrimSuffix(url, "/"),
+		apiKey: apiKey,
+		ids:    ids,
+	}
+}
+
+func (p *CoinGeckoProvider) Name() string {
+	return ProviderCoinGecko
+}
+
+func (p *CoinGeckoProvider) Prices(ctx context.Context) (map[string]float64, error) {
+	coinGeckoIDs := []string{}
+	for _, coinGeckoID := range p.ids {
+		coinGeckoIDs = append(coinGeckoIDs, coinGeckoID)
+	}
+
+	query := url.Values{}
+	query.Set("ids", strings.Join(coinGeckoIDs, ","))
+	query.Set("vs_currencies", "usd")
+
+	headers := map[string]string{}
+	if p.apiKey != "" {
+		headers["x-cg-demo-api-key"] = p.apiKey
+	}
+
+	// {"icon": {"usd": 0.25}}
+	response := map[string]map[string]float64{}
+	err := getJSON(ctx, p.client, p.url+"/simple/price?"+query.Encode(), headers, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := map[string]float64{}
+	for id, coinGeckoID := range p.ids {
+		price, ok := response[coinGeckoID]["usd"]
+		if ok {
+			prices[id] = price
+		}
+	}
+
+	return prices, nil
+}
+
+// JSONURLProvider - prices from any url with a JSON body
+type JSONURLProvider struct {
+	client *http.Client
+	url    string
+
+	// Dotted paths to each price in the body by price id, empty if the body is a flat object by price id
+	paths map[string]string
+}
+
+func NewJSONURLProvider(client *http.Client, url string, paths map[string]string) *JSONURLProvider {
+	return &JSONURLProvider{
+		client: client,
+		url:    url,
+		paths:  paths,
+	}
+}
+
+func (p *JSONURLProvider) Name() string {
+	return ProviderJSONURL
+}
+
+func (p *JSONURLProvider) Prices(ctx context.Context) (map[string]float64, error) {
+	var body interface{}
+	err := getJSON(ctx, p.client, p.url, nil, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := p.paths
+	if len(paths) == 0 {
+		object, ok := body.(map[string]interface{})
+		if ok == false {
+			return nil, errors.New("body is not an object")
+		}
+		paths = map[string]string{}
+		for id := range object {
+			paths[id] = id
+		}
+	}
+
+	prices := map[string]float64{}
+	for id, path := range paths {
+		price, ok := lookupPrice(body, path)
+		if ok {
+			prices[id] = price
+		}
+	}
+
+	return prices, nil
+}
+
+// lookupPrice - number or numeric string at a dotted path
+func lookupPrice(body interface{}, path string) (float64, bool) {
+	value := body
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if ok == false {
+			return 0, false
+		}
+		value, ok = object[key]
+		if ok == false {
+			return 0, false
+		}
+	}
+
+	switch price := value.(type) {
+	case float64:
+		return price, true
+	case string:
+		parsed, err := strconv.ParseFloat(price, 64)
+		return parsed, err == nil
+	}
+
+	return 0, false
+}
+
+// StaticProvider - fixed prices, only used when no other provider has a fresh price
+type StaticProvider struct {
+	prices map[string]float64
+}
+
+func NewStaticProvider(prices map[string]float64) *StaticProvider {
+	return &StaticProvider{
+		prices: prices,
+	}
+}
+
+func (p *StaticProvider) Name() string {
+	return ProviderStatic
+}
+
+func (p *StaticProvider) Prices(ctx context.Context) (map[string]float64, error) {
+	return p.prices, nil
+}
+
+func (p *StaticProvider) Fallback() bool {
+	return true
+}
+
+// FileProvider - prices from a JSON file of prices by price id, read on each refresh
+type FileProvider struct {
+	path string
+}
+
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{
+		path: path,
+	}
+}
+
+func (p *FileProvider) Name() string {
+	return ProviderFile
+}
+
+func (p *FileProvider) Prices(ctx context.Context) (map[string]float64, error) {
+	file, err := ioutil.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := map[string]float64{}
+	err = json.Unmarshal(file, &prices)
+	if err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// getJSON - GET a url and decode its JSON body
+func getJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != 200 {
+		return errors.New("StatusCode=" + strconv.Itoa(resp.StatusCode))
+	}
+
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,79 @@
+package prices
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoinGeckoProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/simple/price", r.URL.Path)
+		assert.Equal("icon", r.URL.Query().Get("ids"))
+		assert.Equal("usd", r.URL.Query().Get("vs_currencies"))
+		assert.Equal("key", r.Header.Get("x-cg-demo-api-key"))
+		assert.Equal(userAgent, r.Header.Get("User-Agent"))
+
+		w.Write([]byte(`{"icon": {"usd": 0.25}}`))
+	}))
+	defer server.Close()
+
+	provider := NewCoinGeckoProvider(server.Client(), server.URL+"/", "key", map[string]string{"icx": "icon"})
+	prices, err := provider.Prices(context.Background())
+	assert.Equal(nil, err)
+	assert.Equal(map[string]float64{"icx": 0.25}, prices)
+}
+
+func TestJSONURLProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(`{"data": {"icx": {"price": "0.25"}, "cx1": {"price": 2}}, "icx": 0.3}`))
+	}))
+	defer server.Close()
+
+	provider := NewJSONURLProvider(server.Client(), server.URL, map[string]string{
+		"icx": "data.icx.price",
+		"cx1": "data.cx1.price",
+		"cx2": "data.cx2.price",
+	})
+	prices, err := provider.Prices(context.Background())
+	assert.Equal(nil, err)
+	assert.Equal(map[string]float64{"icx": 0.25, "cx1": 2}, prices)
+
+	// Flat object without paths
+	provider = NewJSONURLProvider(server.Client(), server.URL, nil)
+	prices, err = provider.Prices(context.Background())
+	assert.Equal(nil, err)
+	assert.Equal(map[string]float64{"icx": 0.3}, prices)
+
+	status = http.StatusTooManyRequests
+	_, err = provider.Prices(context.Background())
+	assert.NotEqual(nil, err)
+}
+
+func TestFileProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "prices.json")
+	provider := NewFileProvider(path)
+
+	_, err := provider.Prices(context.Background())
+	assert.NotEqual(nil, err)
+
+	err = os.WriteFile(path, []byte(`{"icx": 0.25}`), 0644)
+	assert.Equal(nil, err)
+
+	prices, err := provider.Prices(context.Background())
+	assert.Equal(nil, err)
+	assert.Equal(map[string]float64{"icx": 0.25}, prices)
+}
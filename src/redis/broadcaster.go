@@ -2,79 +2,183 @@ package redis
 
 import (
 	"sync"
-	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/metrics"
 )
 
 // BroadcasterID - type for broadcaster channel IDs
 type BroadcasterID uint64
 
-var lastBroadcasterID BroadcasterID = 0
+// SlowConsumerPolicy - what to do when an output channel's buffer is full
+type SlowConsumerPolicy string
+
+const (
+	// SlowConsumerPolicyDropOldest - discard the oldest buffered message to make room for the new one
+	SlowConsumerPolicyDropOldest SlowConsumerPolicy = "drop_oldest"
+
+	// SlowConsumerPolicyDisconnect - remove and close the output channel
+	SlowConsumerPolicyDisconnect SlowConsumerPolicy = "disconnect"
+)
 
 // Broadcaster - Broadcaster channels
 type Broadcaster struct {
 	InputChannel chan []byte
 
+	// Redis channel, for metrics
+	channelName string
+
 	// Output
-	OutputChannels map[BroadcasterID]chan []byte
+	// Buffered channels owned by the subscribers, closed by the broadcaster on removal
+	outputChannels     map[BroadcasterID]chan []byte
+	outputPolicies     map[BroadcasterID]SlowConsumerPolicy
+	outputChannelsMux  sync.Mutex
+	lastBroadcasterID  BroadcasterID
+	slowConsumerPolicy SlowConsumerPolicy
 }
 
 var broadcasters = map[string]*Broadcaster{}
-var broadcastersOnce = map[string]*sync.Once{}
+var broadcastersMux sync.Mutex
 
+// GetBroadcaster - create and/or return the broadcaster for a redis channel
 func GetBroadcaster(channelName string) *Broadcaster {
-	if _, ok := broadcastersOnce[channelName]; ok == false {
-		broadcastersOnce[channelName] = &sync.Once{}
+	broadcastersMux.Lock()
+	defer broadcastersMux.Unlock()
+
+	broadcaster, ok := broadcasters[channelName]
+	if ok == false {
+		broadcaster = NewBroadcaster(SlowConsumerPolicy(config.Config.WebsocketSlowConsumerPolicy))
+		broadcaster.channelName = channelName
+		broadcaster.Start()
+
+		broadcasters[channelName] = broadcaster
 	}
-	broadcastersOnce[channelName].Do(
-		func() {
-			broadcasters[channelName] = &Broadcaster{
-				InputChannel:   make(chan []byte),
-				OutputChannels: make(map[BroadcasterID]chan []byte),
-			}
 
-			broadcasters[channelName].Start()
-		},
-	)
+	return broadcaster
+}
+
+// NewBroadcaster - create a broadcaster, call Start to begin broadcasting
+func NewBroadcaster(slowConsumerPolicy SlowConsumerPolicy) *Broadcaster {
+	if slowConsumerPolicy != SlowConsumerPolicyDisconnect {
+		slowConsumerPolicy = SlowConsumerPolicyDropOldest
+	}
 
-	return broadcasters[channelName]
+	return &Broadcaster{
+		InputChannel:       make(chan []byte),
+		outputChannels:     make(map[BroadcasterID]chan []byte),
+		outputPolicies:     make(map[BroadcasterID]SlowConsumerPolicy),
+		slowConsumerPolicy: slowConsumerPolicy,
+	}
 }
 
-// AddBroadcastChannel - add channel to  broadcaster
+// AddBroadcastChannel - add channel to broadcaster
+// The channel's buffer size is the number of messages the subscriber can fall behind by
 func (b *Broadcaster) AddBroadcastChannel(channel chan []byte) BroadcasterID {
+	return b.AddBroadcastChannelWithPolicy(channel, b.slowConsumerPolicy)
+}
+
+// AddBroadcastChannelWithPolicy - add channel to broadcaster with its own slow consumer policy
+// Subscribers that have to know about every message they miss use the disconnect policy
+func (b *Broadcaster) AddBroadcastChannelWithPolicy(channel chan []byte, slowConsumerPolicy SlowConsumerPolicy) BroadcasterID {
+	if slowConsumerPolicy != SlowConsumerPolicyDisconnect {
+		slowConsumerPolicy = SlowConsumerPolicyDropOldest
+	}
+
+	b.outputChannelsMux.Lock()
+	defer b.outputChannelsMux.Unlock()
 
-	id := lastBroadcasterID
-	lastBroadcasterID++
+	id := b.lastBroadcasterID
+	b.lastBroadcasterID++
 
-	b.OutputChannels[id] = channel
+	b.outputChannels[id] = channel
+	b.outputPolicies[id] = slowConsumerPolicy
 
 	return id
 }
 
-// RemoveBroadcastChannelnel - remove channel from broadcaster
+// SetSlowConsumerPolicy - change the slow consumer policy of a channel already added
+func (b *Broadcaster) SetSlowConsumerPolicy(id BroadcasterID, slowConsumerPolicy SlowConsumerPolicy) {
+	if slowConsumerPolicy != SlowConsumerPolicyDisconnect {
+		slowConsumerPolicy = SlowConsumerPolicyDropOldest
+	}
+
+	b.outputChannelsMux.Lock()
+	defer b.outputChannelsMux.Unlock()
+
+	if _, ok := b.outputChannels[id]; ok {
+		b.outputPolicies[id] = slowConsumerPolicy
+	}
+}
+
+// RemoveBroadcastChannel - remove channel from broadcaster and close it
+// Safe to call more than once
 func (b *Broadcaster) RemoveBroadcastChannel(id BroadcasterID) {
+	b.outputChannelsMux.Lock()
+	defer b.outputChannelsMux.Unlock()
+
+	b.removeBroadcastChannel(id)
+}
 
-	_, ok := b.OutputChannels[id]
+// CountBroadcastChannels - number of subscribed channels
+func (b *Broadcaster) CountBroadcastChannels() int {
+	b.outputChannelsMux.Lock()
+	defer b.outputChannelsMux.Unlock()
+
+	return len(b.outputChannels)
+}
+
+// removeBroadcastChannel - must hold outputChannelsMux
+func (b *Broadcaster) removeBroadcastChannel(id BroadcasterID) {
+	channel, ok := b.outputChannels[id]
 	if ok {
-		delete(b.OutputChannels, id)
+		delete(b.outputChannels, id)
+		delete(b.outputPolicies, id)
+		close(channel)
 	}
 }
 
 // Start - Start broadcaster go routine
 func (b *Broadcaster) Start() {
 	go func() {
-		for {
-			msg := <-b.InputChannel
-
-			for id, channel := range b.OutputChannels {
-				select {
-				// TODO: Determine how to make this non-blocking as if a client is dropped, it could hold up all the
-				//	other channels for the time out (1s).
-				//	Should wrap this in a goroutine so that each client's timeout is assessed in parallel
-				case channel <- msg: // If you get a msg, broadcast it
-				case <-time.After(time.Second * 1): // If a client does not consume within 1s, drop the connection
-					b.RemoveBroadcastChannel(id)
-				}
-			}
+		for msg := range b.InputChannel {
+			b.broadcast(msg)
 		}
 	}()
 }
+
+// broadcast - send a message to every output channel without blocking
+func (b *Broadcaster) broadcast(msg []byte) {
+	b.outputChannelsMux.Lock()
+	defer b.outputChannelsMux.Unlock()
+
+	for id, channel := range b.outputChannels {
+		select {
+		case channel <- msg: // If there is room in the buffer, broadcast it
+			continue
+		default:
+		}
+
+		// Slow consumer, buffer is full
+		slowConsumerPolicy := b.outputPolicies[id]
+		metrics.BroadcasterDropsCounter.WithLabelValues(b.channelName, string(slowConsumerPolicy)).Inc()
+		switch slowConsumerPolicy {
+		case SlowConsumerPolicyDisconnect:
+			zap.S().Debug("Broadcaster: disconnecting slow consumer id=", id)
+			b.removeBroadcastChannel(id)
+		case SlowConsumerPolicyDropOldest:
+			// The broadcaster is the only sender so after one receive there is room,
+			// unless the channel is unbuffered and nobody is waiting on it
+			select {
+			case <-channel:
+			default:
+			}
+
+			select {
+			case channel <- msg:
+			default:
+			}
+		}
+	}
+}
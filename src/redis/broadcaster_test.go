@@ -0,0 +1,207 @@
+package redis
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcasterFastConsumers(t *testing.T) {
+	assert := assert.New(t)
+
+	broadcaster := NewBroadcaster(SlowConsumerPolicyDropOldest)
+	broadcaster.Start()
+
+	numClients := 2000
+	numMsgs := 50
+
+	var ready sync.WaitGroup
+	var done sync.WaitGroup
+	received := make([]int, numClients)
+
+	for i := 0; i < numClients; i++ {
+		ready.Add(1)
+		done.Add(1)
+		go func(i int) {
+			defer done.Done()
+
+			channel := make(chan []byte, numMsgs)
+			id := broadcaster.AddBroadcastChannel(channel)
+			defer broadcaster.RemoveBroadcastChannel(id)
+			ready.Done()
+
+			for range channel {
+				received[i]++
+				if received[i] == numMsgs {
+					return
+				}
+			}
+		}(i)
+	}
+	ready.Wait()
+
+	for i := 0; i < numMsgs; i++ {
+		broadcaster.InputChannel <- []byte(strconv.Itoa(i))
+	}
+	done.Wait()
+
+	for i := 0; i < numClients; i++ {
+		assert.Equal(numMsgs, received[i])
+	}
+	assert.Equal(0, broadcaster.CountBroadcastChannels())
+}
+
+func TestBroadcasterSlowConsumerDropOldest(t *testing.T) {
+	assert := assert.New(t)
+
+	broadcaster := NewBroadcaster(SlowConsumerPolicyDropOldest)
+	broadcaster.Start()
+
+	// Never read until all messages are sent
+	channel := make(chan []byte, 3)
+	broadcaster.AddBroadcastChannel(channel)
+
+	for i := 0; i < 10; i++ {
+		broadcaster.InputChannel <- []byte(strconv.Itoa(i))
+	}
+
+	// Wait for the last message to be broadcast
+	assert.Eventually(func() bool {
+		return len(channel) == 3 && broadcaster.CountBroadcastChannels() == 1
+	}, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+
+	assert.Equal([]byte("7"), <-channel)
+	assert.Equal([]byte("8"), <-channel)
+	assert.Equal([]byte("9"), <-channel)
+}
+
+func TestBroadcasterSlowConsumerDisconnect(t *testing.T) {
+	assert := assert.New(t)
+
+	broadcaster := NewBroadcaster(SlowConsumerPolicyDisconnect)
+	broadcaster.Start()
+
+	slowChannel := make(chan []byte, 1)
+	broadcaster.AddBroadcastChannel(slowChannel)
+
+	fastChannel := make(chan []byte, 10)
+	broadcaster.AddBroadcastChannel(fastChannel)
+
+	broadcaster.InputChannel <- []byte("0")
+	broadcaster.InputChannel <- []byte("1")
+
+	assert.Eventually(func() bool {
+		return broadcaster.CountBroadcastChannels() == 1
+	}, time.Second, time.Millisecond)
+
+	// Slow channel gets what fit in the buffer and is then closed
+	assert.Equal([]byte("0"), <-slowChannel)
+	_, ok := <-slowChannel
+	assert.Equal(false, ok)
+
+	assert.Equal([]byte("0"), <-fastChannel)
+	assert.Equal([]byte("1"), <-fastChannel)
+}
+
+func TestBroadcasterSlowConsumerOwnPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	broadcaster := NewBroadcaster(SlowConsumerPolicyDropOldest)
+	broadcaster.Start()
+
+	disconnectChannel := make(chan []byte, 1)
+	broadcaster.AddBroadcastChannelWithPolicy(disconnectChannel, SlowConsumerPolicyDisconnect)
+
+	dropOldestChannel := make(chan []byte, 1)
+	broadcaster.AddBroadcastChannel(dropOldestChannel)
+
+	broadcaster.InputChannel <- []byte("0")
+	broadcaster.InputChannel <- []byte("1")
+
+	assert.Eventually(func() bool {
+		return broadcaster.CountBroadcastChannels() == 1
+	}, time.Second, time.Millisecond)
+
+	// Disconnected with what fit in the buffer
+	assert.Equal([]byte("0"), <-disconnectChannel)
+	_, ok := <-disconnectChannel
+	assert.Equal(false, ok)
+
+	// Broadcaster policy keeps the latest
+	assert.Equal([]byte("1"), <-dropOldestChannel)
+}
+
+func TestBroadcasterSetSlowConsumerPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	broadcaster := NewBroadcaster(SlowConsumerPolicyDropOldest)
+	broadcaster.Start()
+
+	// Disconnected while resuming, then dropping the oldest like everyone else
+	channel := make(chan []byte, 1)
+	id := broadcaster.AddBroadcastChannelWithPolicy(channel, SlowConsumerPolicyDisconnect)
+	broadcaster.SetSlowConsumerPolicy(id, SlowConsumerPolicyDropOldest)
+
+	// Both messages are broadcast once the other channel has them
+	otherChannel := make(chan []byte, 10)
+	broadcaster.AddBroadcastChannel(otherChannel)
+
+	broadcaster.InputChannel <- []byte("0")
+	broadcaster.InputChannel <- []byte("1")
+
+	assert.Eventually(func() bool {
+		return len(otherChannel) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal([]byte("1"), <-channel)
+	assert.Equal(2, broadcaster.CountBroadcastChannels())
+
+	// Removed channels are left alone
+	broadcaster.RemoveBroadcastChannel(id)
+	broadcaster.SetSlowConsumerPolicy(id, SlowConsumerPolicyDisconnect)
+	assert.Equal(1, broadcaster.CountBroadcastChannels())
+}
+
+func TestBroadcasterConcurrentSubscribe(t *testing.T) {
+	assert := assert.New(t)
+
+	broadcaster := NewBroadcaster(SlowConsumerPolicyDisconnect)
+	broadcaster.Start()
+
+	stop := make(chan bool)
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case broadcaster.InputChannel <- []byte("msg"):
+			}
+		}
+	}()
+
+	// Clients connecting and disconnecting while messages are being broadcast
+	var wg sync.WaitGroup
+	for i := 0; i < 5000; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			channel := make(chan []byte, 1+i%5)
+			id := broadcaster.AddBroadcastChannel(channel)
+
+			for j := 0; j < i%3; j++ {
+				<-channel
+			}
+
+			broadcaster.RemoveBroadcastChannel(id)
+			broadcaster.RemoveBroadcastChannel(id)
+		}(i)
+	}
+	wg.Wait()
+	close(stop)
+
+	assert.Equal(0, broadcaster.CountBroadcastChannels())
+}
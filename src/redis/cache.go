@@ -0,0 +1,27 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// GetCache - get a cached value, nil if not cached
+func (c *Client) GetCache(key string) ([]byte, error) {
+
+	value, err := c.client.Get(context.Background(), key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+
+	return value, err
+}
+
+// SetCache - cache a value until the ttl expires
+func (c *Client) SetCache(key string, value []byte, ttl time.Duration) error {
+
+	err := c.client.Set(context.Background(), key, value, ttl).Err()
+
+	return err
+}
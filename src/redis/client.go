@@ -48,6 +48,10 @@ func GetRedisClient() *Client {
 				return errors.New("RedisClient: Unable to create to redis client")
 			}
 
+			// Command metrics and spans
+			redisClient.client.AddHook(metricsHook{})
+			redisClient.client.AddHook(tracingHook{})
+
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
 
@@ -69,3 +73,11 @@ func GetRedisClient() *Client {
 
 	return redisClient
 }
+
+// Ping - check the redis connection responds within the timeout
+func (c *Client) Ping(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return c.client.Ping(ctx).Err()
+}
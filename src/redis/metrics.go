@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/sudoblockio/icon-go-api/metrics"
+)
+
+// metricsHook - count commands and errors by command
+type metricsHook struct{}
+
+func (metricsHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return ctx, nil
+}
+
+func (metricsHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	recordCommand(cmd)
+	return nil
+}
+
+func (metricsHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return ctx, nil
+}
+
+func (metricsHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	for _, cmd := range cmds {
+		recordCommand(cmd)
+	}
+	return nil
+}
+
+func recordCommand(cmd redis.Cmder) {
+	metrics.RedisCommandsCounter.WithLabelValues(cmd.Name()).Inc()
+
+	// Missing keys aren't errors
+	err := cmd.Err()
+	if err != nil && err != redis.Nil {
+		metrics.RedisErrorsCounter.WithLabelValues(cmd.Name()).Inc()
+	}
+}
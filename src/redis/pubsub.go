@@ -2,11 +2,23 @@ package redis
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/sudoblockio/icon-go-api/config"
 )
 
+// SubscriberStatus - liveness of a subscriber goroutine
+type SubscriberStatus struct {
+	Running bool `json:"running"`
+
+	// Unix timestamp of the last message, 0 if none yet
+	LastMessageTimestamp int64 `json:"last_message_timestamp"`
+}
+
+var subscriberStatuses = map[string]*SubscriberStatus{}
+var subscriberStatusesMux sync.RWMutex
+
 func (c *Client) StartSubscribers() {
 
 	go c.startSubscriber(config.Config.RedisBlocksChannel)
@@ -16,6 +28,29 @@ func (c *Client) StartSubscribers() {
 
 }
 
+// GetSubscriberStatuses - liveness of the subscribers by channel
+func GetSubscriberStatuses() map[string]SubscriberStatus {
+	subscriberStatusesMux.RLock()
+	defer subscriberStatusesMux.RUnlock()
+
+	statuses := map[string]SubscriberStatus{}
+	for channelName, status := range subscriberStatuses {
+		statuses[channelName] = *status
+	}
+
+	return statuses
+}
+
+func setSubscriberStatus(channelName string, running bool, lastMessageTimestamp int64) {
+	subscriberStatusesMux.Lock()
+	defer subscriberStatusesMux.Unlock()
+
+	subscriberStatuses[channelName] = &SubscriberStatus{
+		Running:              running,
+		LastMessageTimestamp: lastMessageTimestamp,
+	}
+}
+
 func (c *Client) startSubscriber(channelName string) {
 
 	// Init pubsub
@@ -29,8 +64,21 @@ func (c *Client) startSubscriber(channelName string) {
 	subscriberChannel := pubsub.Channel()
 	inputChannel := GetBroadcaster(channelName).InputChannel
 
+	lastMessageTimestamp := int64(0)
+	setSubscriberStatus(channelName, true, lastMessageTimestamp)
+	defer func() {
+		setSubscriberStatus(channelName, false, lastMessageTimestamp)
+	}()
+
 	for {
-		redisMsg := <-subscriberChannel
+		redisMsg, ok := <-subscriberChannel
+		if ok == false {
+			// Pubsub closed
+			return
+		}
+
+		lastMessageTimestamp = time.Now().Unix()
+		setSubscriberStatus(channelName, true, lastMessageTimestamp)
 
 		inputChannel <- []byte(redisMsg.Payload)
 	}
@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Token bucket, refilled at rate tokens per second up to burst tokens
+// KEYS[1] - bucket key
+// ARGV - rate, burst, now in milliseconds
+// Returns 1 if a token was taken, and the tokens left as a string since lua numbers are truncated to integers
+var takeTokenScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "timestamp")
+local tokens = tonumber(bucket[1])
+local timestamp = tonumber(bucket[2])
+if tokens == nil or timestamp == nil then
+	tokens = burst
+	timestamp = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - timestamp) / 1000 * rate)
+
+local taken = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	taken = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tokens, "timestamp", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+
+return {taken, tostring(tokens)}
+`)
+
+// TakeToken - take a token from a bucket
+// Returns if a token was taken and the tokens left in the bucket
+func (c *Client) TakeToken(bucketKey string, rate float64, burst int64) (bool, float64, error) {
+
+	result, err := takeTokenScript.Run(
+		context.Background(),
+		c.client,
+		[]string{bucketKey},
+		rate,
+		burst,
+		time.Now().UnixMilli(),
+	).Slice()
+	if err != nil {
+		return false, 0, err
+	}
+
+	taken, _ := result[0].(int64)
+	tokensStr, _ := result[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+
+	return taken == 1, tokens, err
+}
+
+// IncQuota - count a request against a quota that resets after the period
+func (c *Client) IncQuota(quotaKey string, period time.Duration) (int64, error) {
+
+	count, err := c.client.Incr(context.Background(), quotaKey).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if count == 1 {
+		err = c.client.Expire(context.Background(), quotaKey, period).Err()
+	}
+
+	return count, err
+}
@@ -1,11 +1,29 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sudoblockio/icon-go-api/config"
 )
 
-func IconNodeServiceGetTotalSupply() (float64, error) {
+// Chain SCORE, the governance SCORE with the network's staking state
+const chainScoreAddress = "cx0000000000000000000000000000000000000000"
+
+// NetworkInfo - ICX staked, bonded and delegated in the network
+type NetworkInfo struct {
+	TotalStaked    float64
+	TotalBonded    float64
+	TotalDelegated float64
+}
+
+func IconNodeServiceGetTotalSupply(ctx context.Context) (float64, error) {
 
 	// Request icon contract
 	payload := fmt.Sprintf(`{
@@ -14,7 +32,7 @@ func IconNodeServiceGetTotalSupply() (float64, error) {
     "id": 1
 	}`)
 
-	body, err := JsonRpcRequestWithRetry(payload)
+	body, err := JsonRpcRequestWithRetry(ctx, payload)
 	if err != nil {
 		return 0, err
 	}
@@ -28,7 +46,7 @@ func IconNodeServiceGetTotalSupply() (float64, error) {
 	return StringHexToFloat64(result), nil
 }
 
-func IconNodeServiceGetBalance(publicKey string) (float64, error) {
+func IconNodeServiceGetBalance(ctx context.Context, publicKey string) (float64, error) {
 	if publicKey == "hx0000000000000000000000000000000000000000" {
 		return 0.0, nil
 	} else if publicKey == "hx0000000000000000000000000000000000000001" {
@@ -44,7 +62,7 @@ func IconNodeServiceGetBalance(publicKey string) (float64, error) {
     }
 	}`, publicKey)
 
-	body, err := JsonRpcRequestWithRetry(payload)
+	body, err := JsonRpcRequestWithRetry(ctx, payload)
 	if err != nil {
 		return 0.0, err
 	}
@@ -57,3 +75,185 @@ func IconNodeServiceGetBalance(publicKey string) (float64, error) {
 
 	return StringHexToFloat64(balance), nil
 }
+
+// IconNodeServiceGetLastBlockNumber - last block number of the node
+func IconNodeServiceGetLastBlockNumber(ctx context.Context) (int64, error) {
+
+	body, err := JsonRpcRequestWithRetry(ctx, lastBlockPayload)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastBlockHeight(body)
+}
+
+// IconNodeServiceGetLastBlockHeight - last block height of a node, without retries
+func IconNodeServiceGetLastBlockHeight(ctx context.Context, url string, timeout time.Duration) (int64, error) {
+
+	client := &http.Client{
+		Timeout: timeout,
+	}
+	body, err := JsonRpcRequestWithClient(ctx, client, lastBlockPayload, url)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastBlockHeight(body)
+}
+
+const lastBlockPayload = `{
+    "jsonrpc": "2.0",
+    "method": "icx_getLastBlock",
+    "id": 1
+	}`
+
+func lastBlockHeight(body map[string]interface{}) (int64, error) {
+
+	// Extract height
+	result, ok := body["result"].(map[string]interface{})
+	if ok == false {
+		return 0, errors.New("Invalid response")
+	}
+	height, ok := result["height"].(float64)
+	if ok == false {
+		return 0, errors.New("Invalid response")
+	}
+
+	return int64(height), nil
+}
+
+// IconNodeServiceGetTokenBalanceAt - IRC2 token balance of an address at a block height, in the token's smallest unit
+// Only nodes that keep the state of the height can answer, others return an error
+func IconNodeServiceGetTokenBalanceAt(
+	ctx context.Context,
+	tokenContractAddress string,
+	address string,
+	blockNumber int64,
+) (*big.Int, error) {
+
+	balanceHex, err := iconNodeCallAt(
+		ctx,
+		tokenContractAddress,
+		"balanceOf",
+		fmt.Sprintf(`{"_owner": "%s"}`, address),
+		blockNumber,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, ok := new(big.Int).SetString(strings.TrimPrefix(balanceHex, "0x"), 16)
+	if ok == false {
+		return nil, errors.New("Invalid balance: " + balanceHex)
+	}
+
+	return balance, nil
+}
+
+// IconNodeServiceGetTokenDecimals - decimals of an IRC2 token
+func IconNodeServiceGetTokenDecimals(ctx context.Context, tokenContractAddress string) (int, error) {
+	return iconNodeGetTokenDecimalsAt(ctx, tokenContractAddress, 0)
+}
+
+func iconNodeGetTokenDecimalsAt(ctx context.Context, tokenContractAddress string, blockNumber int64) (int, error) {
+	decimalsHex, err := iconNodeCallAt(ctx, tokenContractAddress, "decimals", "{}", blockNumber)
+	if err != nil {
+		return 0, err
+	}
+	decimals, err := strconv.ParseInt(strings.TrimPrefix(decimalsHex, "0x"), 16, 64)
+	if err != nil {
+		return 0, errors.New("Invalid decimals: " + decimalsHex)
+	}
+
+	return int(decimals), nil
+}
+
+// IconNodeServiceGetNetworkInfo - ICX staked, bonded and delegated in the network, from the chain SCORE
+func IconNodeServiceGetNetworkInfo(ctx context.Context) (*NetworkInfo, error) {
+	result, err := iconNodeCallResultAt(ctx, chainScoreAddress, "getNetworkInfo", "{}", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	networkInfo, ok := result.(map[string]interface{})
+	if ok == false {
+		return nil, errors.New("Invalid network info")
+	}
+
+	amounts := map[string]float64{}
+	for _, key := range []string{"totalStake", "totalBonded", "totalDelegated"} {
+		amountHex, ok := networkInfo[key].(string)
+		if ok == false || strings.HasPrefix(amountHex, "0x") == false {
+			return nil, errors.New("Invalid network info: missing " + key)
+		}
+		amounts[key] = StringHexToFloat64(amountHex)
+	}
+
+	return &NetworkInfo{
+		TotalStaked:    amounts["totalStake"],
+		TotalBonded:    amounts["totalBonded"],
+		TotalDelegated: amounts["totalDelegated"],
+	}, nil
+}
+
+// iconNodeCallAt - read-only SCORE call at a block height, 0 for the latest block
+func iconNodeCallAt(ctx context.Context, scoreAddress string, method string, params string, blockNumber int64) (string, error) {
+	result, err := iconNodeCallResultAt(ctx, scoreAddress, method, params, blockNumber)
+	if err != nil {
+		return "", err
+	}
+
+	resultString, ok := result.(string)
+	if ok == false {
+		return "", errors.New("Invalid response: " + fmt.Sprint(result))
+	}
+
+	return resultString, nil
+}
+
+// iconNodeCallResultAt - read-only SCORE call at a block height, for methods that return objects
+// Tried on each node until one answers
+func iconNodeCallResultAt(ctx context.Context, scoreAddress string, method string, params string, blockNumber int64) (interface{}, error) {
+
+	height := ""
+	if blockNumber != 0 {
+		height = fmt.Sprintf(`,
+        "height": "0x%x"`, blockNumber)
+	}
+
+	payload := fmt.Sprintf(`{
+    "jsonrpc": "2.0",
+    "method": "icx_call",
+    "id": 1,
+    "params": {
+        "to": "%s",
+        "dataType": "call",
+        "data": {
+            "method": "%s",
+            "params": %s
+        }%s
+    }
+	}`, scoreAddress, method, params, height)
+
+	client := &http.Client{}
+
+	err := errors.New("No icon node url")
+	for _, url := range config.Config.IconNodeServiceURL {
+		var body map[string]interface{}
+		body, err = JsonRpcRequestWithClient(ctx, client, payload, url)
+		if err != nil {
+			continue
+		}
+
+		// Extract result
+		result, ok := body["result"]
+		if ok == false || result == nil {
+			err = errors.New("Invalid response: " + fmt.Sprint(body["error"]))
+			continue
+		}
+
+		return result, nil
+	}
+
+	return nil, err
+}
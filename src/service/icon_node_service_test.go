@@ -1,22 +1,84 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
 	"github.com/stretchr/testify/require"
+
 	"github.com/sudoblockio/icon-go-api/config"
-	"testing"
 )
 
 func TestIconNodeServiceGetTotalSupply(t *testing.T) {
 	config.ReadEnvironment()
-	body, err := IconNodeServiceGetTotalSupply()
+	body, err := IconNodeServiceGetTotalSupply(context.Background())
 	require.Nil(t, err)
 	require.NotEmpty(t, body)
 }
 
 func TestIconNodeServiceGetBalance(t *testing.T) {
 	config.ReadEnvironment()
-	body, err := IconNodeServiceGetBalance("hx1000000000000000000000000000000000000000")
+	body, err := IconNodeServiceGetBalance(context.Background(), "hx1000000000000000000000000000000000000000")
 
 	require.Nil(t, err)
 	require.NotEmpty(t, body)
 }
+
+func TestIconNodeServiceGetTokenBalanceAt(t *testing.T) {
+	config.ReadEnvironment()
+
+	requests := []map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		requests = append(requests, request)
+
+		params := request["params"].(map[string]interface{})
+		data := params["data"].(map[string]interface{})
+		require.Equal(t, "balanceOf", data["method"])
+		w.Write([]byte(`{"jsonrpc": "2.0", "result": "0x1e8480", "id": 1}`))
+	}))
+	defer server.Close()
+	config.Config.IconNodeServiceURL = []string{server.URL}
+
+	balance, err := IconNodeServiceGetTokenBalanceAt(context.Background(), "cx1", "hx1", 100)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(2000000), balance)
+
+	// Called at the block
+	require.Equal(t, 1, len(requests))
+	for _, request := range requests {
+		require.Equal(t, "0x64", request["params"].(map[string]interface{})["height"])
+	}
+}
+
+func TestIconNodeServiceGetNetworkInfo(t *testing.T) {
+	config.ReadEnvironment()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+
+		params := request["params"].(map[string]interface{})
+		require.Equal(t, chainScoreAddress, params["to"])
+		require.Equal(t, "getNetworkInfo", params["data"].(map[string]interface{})["method"])
+
+		w.Write([]byte(`{"jsonrpc": "2.0", "result": {
+			"totalStake": "0xde0b6b3a7640000",
+			"totalBonded": "0x6f05b59d3b20000",
+			"totalDelegated": "0x1bc16d674ec80000"
+		}, "id": 1}`))
+	}))
+	defer server.Close()
+	config.Config.IconNodeServiceURL = []string{server.URL}
+
+	networkInfo, err := IconNodeServiceGetNetworkInfo(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, float64(1), networkInfo.TotalStaked)
+	require.Equal(t, 0.5, networkInfo.TotalBonded)
+	require.Equal(t, float64(2), networkInfo.TotalDelegated)
+}
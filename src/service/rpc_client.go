@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,26 +13,57 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/tracing"
 )
 
-func JsonRpcRequest(payload string, url string) (map[string]interface{}, error) {
-
-	// Request icon contract
-	method := "POST"
-	resp := map[string]interface{}{}
+func JsonRpcRequest(ctx context.Context, payload string, url string) (map[string]interface{}, error) {
 
 	// Create http client
 	client := &http.Client{
 		//Timeout: 2 * time.Second,
 	}
-	//client := &http.Client{
-	//	Timeout: 2 * time.Second,
-	//}
-	req, err := http.NewRequest(method, url, strings.NewReader(payload))
+
+	return JsonRpcRequestWithClient(ctx, client, payload, url)
+}
+
+// JsonRpcRequestWithClient - request traced as a child of ctx, the trace context is sent to the node
+func JsonRpcRequestWithClient(ctx context.Context, client *http.Client, payload string, url string) (_ map[string]interface{}, err error) {
+
+	// Trace
+	ctx, span := tracing.Tracer().Start(
+		ctx,
+		"jsonrpc",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.RPCSystemKey.String("jsonrpc"),
+			semconv.HTTPURLKey.String(url),
+		),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	// Request icon contract
+	method := "POST"
+	resp := map[string]interface{}{}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(payload))
 	if err != nil {
 		return resp, err
 	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	// Execute request
 	req.Header.Add("Content-Type", "application/json")
@@ -39,6 +71,7 @@ func JsonRpcRequest(payload string, url string) (map[string]interface{}, error)
 	if err != nil {
 		return resp, err
 	}
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(res.StatusCode))
 
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
@@ -70,10 +103,11 @@ func JsonRpcRequest(payload string, url string) (map[string]interface{}, error)
 	return resp, nil
 }
 
-func JsonRpcRequestWithBackup(payload string) (map[string]interface{}, error) {
-	var err error
+func JsonRpcRequestWithBackup(ctx context.Context, payload string) (map[string]interface{}, error) {
+	err := errors.New("No icon node url")
 	for _, icon_node_url := range config.Config.IconNodeServiceURL {
-		resp, err := JsonRpcRequest(payload, icon_node_url)
+		var resp map[string]interface{}
+		resp, err = JsonRpcRequest(ctx, payload, icon_node_url)
 		if err == nil {
 			return resp, err
 		}
@@ -81,26 +115,52 @@ func JsonRpcRequestWithBackup(payload string) (map[string]interface{}, error) {
 	return nil, err
 }
 
-func JsonRpcRequestWithRetry(payload string) (map[string]interface{}, error) {
-	resp, err := retry(payload, JsonRpcRequestWithBackup)
+func JsonRpcRequestWithRetry(ctx context.Context, payload string) (map[string]interface{}, error) {
+	resp, err := retry(ctx, payload, JsonRpcRequestWithBackup)
 	return resp, err
 }
 
-func retry(payload string, JsonRpcRequestWithBackup func(payload string) (map[string]interface{}, error)) (_ map[string]interface{}, err error) {
+// retry - request until it succeeds or the attempts run out, traced as one span around the attempts
+// Stops waiting between attempts once ctx is done
+func retry(
+	ctx context.Context,
+	payload string,
+	JsonRpcRequestWithBackup func(ctx context.Context, payload string) (map[string]interface{}, error),
+) (_ map[string]interface{}, err error) {
+
+	// Trace
+	ctx, span := tracing.Tracer().Start(ctx, "jsonrpc.retry")
+	attempt := 0
+	defer func() {
+		span.SetAttributes(attribute.Int("rpc.attempts", attempt))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	attempts := config.Config.IconNodeRpcRetryAttempts
 	duration := config.Config.IconNodeRpcRetrySleepSeconds
-	for i := 0; ; i++ {
-		resp, err := JsonRpcRequestWithBackup(payload)
+	var lastErr error
+	for {
+		attempt++
+		resp, err := JsonRpcRequestWithBackup(ctx, payload)
 		if err == nil {
 			return resp, err
 		}
+		lastErr = err
 
-		if i >= (attempts - 1) {
+		if attempt >= attempts {
 			break
 		}
 
-		time.Sleep(duration)
 		zap.S().Warn("retrying after error:", err)
+		select {
+		case <-ctx.Done():
+			return map[string]interface{}{}, fmt.Errorf("after %d attempts, canceled: %s, last error: %s", attempt, ctx.Err(), lastErr)
+		case <-time.After(duration):
+		}
 	}
-	return map[string]interface{}{}, fmt.Errorf("after %d attempts, last error: %s", attempts, err)
+	return map[string]interface{}{}, fmt.Errorf("after %d attempts, last error: %s", attempt, lastErr)
 }
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/tracing"
+)
+
+func TestJsonRpcRequestWithClient(t *testing.T) {
+	assert := assert.New(t)
+
+	exporter := tracing.StartInMemory()
+
+	traceparent := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Write([]byte(`{"jsonrpc": "2.0", "result": "0x1", "id": 1}`))
+	}))
+	defer server.Close()
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "parent")
+	resp, err := JsonRpcRequestWithClient(ctx, server.Client(), `{"jsonrpc": "2.0", "method": "icx_getLastBlock", "id": 1}`, server.URL)
+	parent.End()
+	assert.Equal(nil, err)
+	assert.Equal("0x1", resp["result"])
+
+	spans := exporter.GetSpans()
+	assert.Equal(2, len(spans))
+
+	rpcSpan := spans[0]
+	assert.Equal("jsonrpc", rpcSpan.Name)
+	assert.Equal(parent.SpanContext().SpanID(), rpcSpan.Parent.SpanID())
+
+	// The node receives the rpc span as its parent
+	assert.Equal("00-"+rpcSpan.SpanContext.TraceID().String()+"-"+rpcSpan.SpanContext.SpanID().String()+"-01", traceparent)
+}
+
+func TestRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	exporter := tracing.StartInMemory()
+
+	config.Config.IconNodeRpcRetryAttempts = 3
+	config.Config.IconNodeRpcRetrySleepSeconds = time.Millisecond
+
+	// Succeeds on the second attempt
+	calls := 0
+	resp, err := retry(context.Background(), "{}", func(ctx context.Context, payload string) (map[string]interface{}, error) {
+		calls++
+		if calls < 2 {
+			return nil, errors.New("unavailable")
+		}
+		return map[string]interface{}{"result": "0x1"}, nil
+	})
+	assert.Equal(nil, err)
+	assert.Equal("0x1", resp["result"])
+	assert.Equal(2, calls)
+
+	spans := exporter.GetSpans()
+	assert.Equal(1, len(spans))
+	assert.Equal("jsonrpc.retry", spans[0].Name)
+	assert.Contains(spans[0].Attributes, attribute.Int("rpc.attempts", 2))
+
+	// Fails every attempt
+	calls = 0
+	_, err = retry(context.Background(), "{}", func(ctx context.Context, payload string) (map[string]interface{}, error) {
+		calls++
+		return nil, errors.New("unavailable")
+	})
+	assert.NotEqual(nil, err)
+	assert.Equal(3, calls)
+}
+
+func TestRetryCanceled(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.IconNodeRpcRetryAttempts = 3
+	config.Config.IconNodeRpcRetrySleepSeconds = time.Hour
+
+	// Canceled while waiting to retry
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := retry(ctx, "{}", func(ctx context.Context, payload string) (map[string]interface{}, error) {
+		calls++
+		cancel()
+		return nil, errors.New("unavailable")
+	})
+	assert.NotEqual(nil, err)
+	assert.Equal(1, calls)
+}
@@ -3,9 +3,15 @@ package service
 import (
 	"go.uber.org/zap"
 	"math/big"
+	"strings"
 )
 
 func StringHexToFloat64(hex string) float64 {
+	return StringHexToFloat64WithDecimals(hex, 18)
+}
+
+// StringHexToFloat64WithDecimals - hex loop amount to a decimal amount of a token with the given decimals
+func StringHexToFloat64WithDecimals(hex string, decimals int) float64 {
 	valueDecimal := float64(0)
 
 	var negative bool
@@ -21,12 +27,12 @@ func StringHexToFloat64(hex string) float64 {
 	}
 
 	baseBigFloatString := "1"
-	for i := 0; i < 18; i++ {
+	for i := 0; i < decimals; i++ {
 		baseBigFloatString += "0"
 	}
 	baseBigFloat, success := new(big.Float).SetString(baseBigFloatString) // 10^(base)
 	if success == false {
-		zap.S().Warn("Set String Error: base=", 18)
+		zap.S().Warn("Set String Error: base=", decimals)
 		return 0
 	}
 
@@ -42,6 +48,31 @@ func StringHexToFloat64(hex string) float64 {
 	return valueDecimal
 }
 
+// FormatUnits - exact decimal amount of a token with the given decimals from its smallest unit
+func FormatUnits(value *big.Int, decimals int) string {
+	digits := new(big.Int).Abs(value).String()
+
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+	}
+
+	if decimals <= 0 {
+		return sign + digits
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	whole := digits[:len(digits)-decimals]
+	fraction := strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if fraction == "" {
+		return sign + whole
+	}
+
+	return sign + whole + "." + fraction
+}
+
 //func StringHexToInt64(i string) int64 {
 //	o := new(big.Int)
 //
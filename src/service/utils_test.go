@@ -2,6 +2,7 @@ package service
 
 import (
 	"github.com/stretchr/testify/assert"
+	"math/big"
 	"testing"
 )
 
@@ -10,3 +11,15 @@ func TestStringHexToInt64(t *testing.T) {
 	output := StringHexToFloat64(inputString)
 	assert.Equal(t, float64(949499958.6892647), output, "Test success")
 }
+
+func TestFormatUnits(t *testing.T) {
+	value, _ := new(big.Int).SetString("311686fe637dc7b0622d7e6", 16)
+	assert.Equal(t, "949499958.689264700391348198", FormatUnits(value, 18))
+
+	assert.Equal(t, "1.5", FormatUnits(big.NewInt(1500000), 6))
+	assert.Equal(t, "2", FormatUnits(big.NewInt(2000000), 6))
+	assert.Equal(t, "0.000001", FormatUnits(big.NewInt(1), 6))
+	assert.Equal(t, "-0.05", FormatUnits(big.NewInt(-50000), 6))
+	assert.Equal(t, "0", FormatUnits(big.NewInt(0), 18))
+	assert.Equal(t, "42", FormatUnits(big.NewInt(42), 0))
+}
@@ -0,0 +1,86 @@
+package stats
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// DateFormat - format of the days of daily stats, UTC
+const DateFormat = "2006-01-02"
+
+// RollupDaily - aggregate each metric per day, from the latest day rolled up through today
+func RollupDaily() {
+	ctx := context.Background()
+
+	latestDate, err := crud.GetDailyStatCrud().SelectLatestDate(ctx)
+	if err != nil {
+		zap.S().Warn("Stats: Unable to get latest daily stat date ERROR=", err.Error())
+		return
+	}
+
+	days := rollupDays(latestDate, time.Now(), config.Config.StatsDailyBackfillDays)
+	for _, day := range days {
+		dailyStats, err := rollupDay(ctx, day)
+		if err != nil {
+			zap.S().Warn("Stats: Unable to roll up daily stats DATE=", day.Format(DateFormat), " ERROR=", err.Error())
+			return
+		}
+
+		err = crud.GetDailyStatCrud().UpsertMany(ctx, dailyStats)
+		if err != nil {
+			zap.S().Warn("Stats: Unable to insert daily stats DATE=", day.Format(DateFormat), " ERROR=", err.Error())
+			return
+		}
+	}
+
+	zap.S().Info("Stats: Rolled up daily stats DAYS=", len(days))
+}
+
+// rollupDay - every metric for the UTC day starting at day
+func rollupDay(ctx context.Context, day time.Time) ([]models.DailyStat, error) {
+	date := day.Format(DateFormat)
+	startTimestamp := day.UnixMicro()
+	endTimestamp := day.AddDate(0, 0, 1).UnixMicro()
+
+	dailyStats := []models.DailyStat{}
+	for _, metric := range crud.DailyStatMetrics {
+		value, err := crud.GetDailyStatCrud().SelectMetric(ctx, metric, startTimestamp, endTimestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		dailyStats = append(dailyStats, models.DailyStat{
+			Date:             date,
+			Metric:           metric,
+			Value:            value,
+			UpdatedTimestamp: time.Now().Unix(),
+		})
+	}
+
+	return dailyStats, nil
+}
+
+// rollupDays - starts of the UTC days to roll up, oldest first
+// The latest day rolled up is included again since it may have been rolled up before it was over
+// Without a latest day, today and the backfill days before it
+func rollupDays(latestDate string, now time.Time, backfillDays int) []time.Time {
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	start := today.AddDate(0, 0, -backfillDays)
+	if latestDay, err := time.Parse(DateFormat, latestDate); err == nil && latestDay.After(start) {
+		start = latestDay
+	}
+
+	days := []time.Time{}
+	for day := start; day.After(today) == false; day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	return days
+}
@@ -0,0 +1,40 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollupDays(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2022, 6, 30, 15, 0, 0, 0, time.UTC)
+
+	// Nothing rolled up, today only
+	days := rollupDays("", now, 0)
+	assert.Equal(1, len(days))
+	assert.Equal("2022-06-30", days[0].Format(DateFormat))
+
+	// Nothing rolled up, backfill
+	days = rollupDays("", now, 2)
+	assert.Equal(3, len(days))
+	assert.Equal("2022-06-28", days[0].Format(DateFormat))
+	assert.Equal("2022-06-30", days[2].Format(DateFormat))
+
+	// From the latest day rolled up
+	days = rollupDays("2022-06-29", now, 365)
+	assert.Equal(2, len(days))
+	assert.Equal("2022-06-29", days[0].Format(DateFormat))
+	assert.Equal(time.Date(2022, 6, 29, 0, 0, 0, 0, time.UTC).UnixMicro(), days[0].UnixMicro())
+
+	// Latest day before the backfill
+	days = rollupDays("2021-01-01", now, 1)
+	assert.Equal(2, len(days))
+	assert.Equal("2022-06-29", days[0].Format(DateFormat))
+
+	// Today again
+	days = rollupDays("2022-06-30", now, 365)
+	assert.Equal(1, len(days))
+}
@@ -0,0 +1,149 @@
+package stats
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/models"
+	"github.com/sudoblockio/icon-go-api/prices"
+)
+
+// Stats - supplies and market cap, refreshed in the background
+// Values are from the last successful refresh, errors are from the last refresh if it failed
+type Stats struct {
+	CirculatingSupply float64 `json:"circulating-supply"`
+	TotalSupply       float64 `json:"total-supply"`
+	MarketCap         float64 `json:"market-cap"`
+
+	// Unix timestamps of the last successful refreshes, 0 before the first
+	SupplyUpdatedTimestamp    int64 `json:"supply-updated-timestamp"`
+	MarketCapUpdatedTimestamp int64 `json:"market-cap-updated-timestamp"`
+
+	SupplyError    string `json:"supply-error,omitempty"`
+	MarketCapError string `json:"market-cap-error,omitempty"`
+
+	// Breakdown of the supply from the last successful refresh, nil before the first
+	Supply *models.SupplySnapshot `json:"-"`
+}
+
+// Latest stats, *Stats
+var currentStats atomic.Value
+
+// Serializes refreshes, readers only load currentStats
+var refreshMux sync.Mutex
+
+func init() {
+	currentStats.Store(&Stats{})
+}
+
+// Start - migrate the stats tables and refresh the supplies and market cap on their intervals
+// With the stats writer enabled, instances elect one to write the stats tables
+func Start() {
+	// Migrated by every instance so the tables can be read without a writer
+	err := migrate()
+	if err != nil {
+		zap.S().Fatal("Stats: Unable to migrate stats tables ERROR=", err.Error())
+	}
+
+	go supervise("supply", config.Config.StatsCirculatingSupplyUpdateTime, RefreshSupply)
+	go supervise("market cap", config.Config.StatsMarketCapUpdateTime, RefreshMarketCap)
+
+	if config.Config.StatsWriterEnabled {
+		go startWriter()
+	}
+
+	zap.S().Info("Started Stats")
+}
+
+// supervise - run a refresh on an interval, a panic is logged and the next refresh still runs
+func supervise(name string, interval time.Duration, refresh func()) {
+	for {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					zap.S().Error("Stats: Recovered from panic refreshing ", name, " PANIC=", r)
+				}
+			}()
+
+			refresh()
+		}()
+
+		time.Sleep(interval)
+	}
+}
+
+// RefreshSupply - supply breakdown from the node, kept in the supply history
+func RefreshSupply() {
+	supply, err := getSupply(context.Background())
+	if err != nil {
+		zap.S().Warn("Stats: Unable to get supply ERROR=", err.Error())
+		setSupply(nil, err)
+		return
+	}
+
+	setSupply(supply, nil)
+	recordSupplySnapshot(supply)
+
+	// Market cap follows the supply
+	RefreshMarketCap()
+}
+
+// RefreshMarketCap - circulating supply at the ICX price
+func RefreshMarketCap() {
+	price, ok := prices.Get(prices.IcxID)
+	setMarketCap(price, ok)
+}
+
+// setSupply - swap in the new supplies, or keep the last ones and report the error
+func setSupply(supply *models.SupplySnapshot, err error) {
+	update(func(stats *Stats) {
+		if err != nil {
+			stats.SupplyError = err.Error()
+			return
+		}
+
+		stats.TotalSupply = supply.TotalSupply
+		stats.CirculatingSupply = supply.CirculatingSupply
+		stats.Supply = supply
+		stats.SupplyUpdatedTimestamp = supply.Timestamp
+		stats.SupplyError = ""
+	})
+}
+
+// setMarketCap - swap in the new market cap, or keep the last one and report why it can't be computed
+func setMarketCap(price float64, ok bool) {
+	update(func(stats *Stats) {
+		if ok == false {
+			stats.MarketCapError = "no fresh ICX price"
+			return
+		}
+		if stats.SupplyUpdatedTimestamp == 0 {
+			stats.MarketCapError = "no circulating supply"
+			return
+		}
+
+		stats.MarketCap = stats.CirculatingSupply * price
+		stats.MarketCapUpdatedTimestamp = time.Now().Unix()
+		stats.MarketCapError = ""
+	})
+}
+
+// update - change a copy of the latest stats and swap it in
+func update(change func(stats *Stats)) {
+	refreshMux.Lock()
+	defer refreshMux.Unlock()
+
+	stats := *GetStats()
+	change(&stats)
+	currentStats.Store(&stats)
+}
+
+// GetStats - latest stats, never modified once returned
+func GetStats() *Stats {
+	return currentStats.Load().(*Stats)
+}
@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/service"
+)
+
+func TestSetSupplyAndMarketCap(t *testing.T) {
+	assert := assert.New(t)
+	defer currentStats.Store(&Stats{})
+
+	// Nothing before the first refresh
+	setMarketCap(0.25, true)
+	stats := GetStats()
+	assert.Equal(int64(0), stats.MarketCapUpdatedTimestamp)
+	assert.Equal("no circulating supply", stats.MarketCapError)
+
+	setSupply(newSupplySnapshot(1656547200, 1000, 100, 0, 0, &service.NetworkInfo{}), nil)
+	setMarketCap(0.25, true)
+	stats = GetStats()
+	assert.Equal(1000.0, stats.TotalSupply)
+	assert.Equal(900.0, stats.CirculatingSupply)
+	assert.Equal(225.0, stats.MarketCap)
+	assert.Equal(int64(1656547200), stats.SupplyUpdatedTimestamp)
+	assert.NotEqual(int64(0), stats.MarketCapUpdatedTimestamp)
+	assert.Equal("", stats.MarketCapError)
+
+	// Failures keep the last values
+	setSupply(nil, errors.New("could not get total supply"))
+	setMarketCap(0, false)
+	latest := GetStats()
+	assert.Equal(900.0, latest.CirculatingSupply)
+	assert.Equal(225.0, latest.MarketCap)
+	assert.Equal("could not get total supply", latest.SupplyError)
+	assert.Equal("no fresh ICX price", latest.MarketCapError)
+
+	// Earlier snapshots aren't modified
+	assert.Equal("", stats.SupplyError)
+}
+
+func TestSupervise(t *testing.T) {
+	assert := assert.New(t)
+
+	calls := make(chan int, 2)
+	go supervise("test", 0, func() {
+		calls <- 1
+		panic("refresh failed")
+	})
+
+	// Still refreshing after a panic
+	assert.Equal(1, <-calls)
+	assert.Equal(1, <-calls)
+}
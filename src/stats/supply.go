@@ -0,0 +1,123 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+	"github.com/sudoblockio/icon-go-api/service"
+)
+
+// getSupply - total supply, the configured wallets' balances and the network's staking from the node
+func getSupply(ctx context.Context) (*models.SupplySnapshot, error) {
+	totalSupply, err := service.IconNodeServiceGetTotalSupply(ctx)
+	if err != nil {
+		zap.S().Warn("Stats: Unable to get total supply ERROR=", err.Error())
+		return nil, errors.New("could not get total supply")
+	}
+
+	burned, err := sumBalances(ctx, config.Config.SupplyBurnAddresses)
+	if err != nil {
+		return nil, errors.New("could not get burn wallet balances")
+	}
+
+	locked, err := sumBalances(ctx, config.Config.SupplyLockedAddresses)
+	if err != nil {
+		return nil, errors.New("could not get locked wallet balances")
+	}
+
+	treasury := float64(0)
+	if config.Config.SupplyTreasuryAddress != "" {
+		treasury, err = service.IconNodeServiceGetBalance(ctx, config.Config.SupplyTreasuryAddress)
+		if err != nil {
+			zap.S().Warn("Stats: Unable to get treasury wallet balance ERROR=", err.Error())
+			return nil, errors.New("could not get treasury wallet balance")
+		}
+	}
+
+	networkInfo, err := service.IconNodeServiceGetNetworkInfo(ctx)
+	if err != nil {
+		zap.S().Warn("Stats: Unable to get network info ERROR=", err.Error())
+		return nil, errors.New("could not get network info")
+	}
+
+	return newSupplySnapshot(time.Now().Unix(), totalSupply, burned, locked, treasury, networkInfo), nil
+}
+
+// newSupplySnapshot - circulating supply is the total supply without the burned and locked ICX
+func newSupplySnapshot(
+	timestamp int64,
+	totalSupply float64,
+	burned float64,
+	locked float64,
+	treasury float64,
+	networkInfo *service.NetworkInfo,
+) *models.SupplySnapshot {
+	supply := &models.SupplySnapshot{
+		Timestamp:         timestamp,
+		TotalSupply:       totalSupply,
+		CirculatingSupply: totalSupply - burned - locked,
+		TotalStaked:       networkInfo.TotalStaked,
+		TotalBonded:       networkInfo.TotalBonded,
+		TotalDelegated:    networkInfo.TotalDelegated,
+		Burned:            burned,
+		Locked:            locked,
+		Treasury:          treasury,
+	}
+
+	if totalSupply != 0 {
+		supply.StakingRatio = networkInfo.TotalStaked / totalSupply
+	}
+
+	return supply
+}
+
+// sumBalances - ICX balance of the addresses together
+func sumBalances(ctx context.Context, addresses []string) (float64, error) {
+	sum := float64(0)
+
+	for _, address := range addresses {
+		if address == "" {
+			continue
+		}
+
+		balance, err := service.IconNodeServiceGetBalance(ctx, address)
+		if err != nil {
+			zap.S().Warn("Stats: Unable to get balance ADDRESS=", address, " ERROR=", err.Error())
+			return 0, err
+		}
+		sum += balance
+	}
+
+	return sum, nil
+}
+
+// recordSupplySnapshot - keep the first supply of each history interval, only on the stats writer
+func recordSupplySnapshot(supply *models.SupplySnapshot) {
+	if isWriter() == false {
+		return
+	}
+
+	snapshot := *supply
+	snapshot.Timestamp = historyTimestamp(supply.Timestamp, config.Config.SupplyHistoryInterval)
+
+	err := crud.GetSupplySnapshotCrud().InsertOne(context.Background(), &snapshot)
+	if err != nil {
+		zap.S().Warn("Stats: Unable to insert supply snapshot ERROR=", err.Error())
+	}
+}
+
+// historyTimestamp - start of the interval the timestamp is in
+func historyTimestamp(timestamp int64, interval time.Duration) int64 {
+	seconds := int64(interval / time.Second)
+	if seconds <= 0 {
+		return timestamp
+	}
+
+	return timestamp - timestamp%seconds
+}
@@ -0,0 +1,38 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/service"
+)
+
+func TestNewSupplySnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	supply := newSupplySnapshot(1656547200, 1000, 100, 50, 25, &service.NetworkInfo{
+		TotalStaked:    400,
+		TotalBonded:    100,
+		TotalDelegated: 300,
+	})
+	assert.Equal(850.0, supply.CirculatingSupply)
+	assert.Equal(100.0, supply.Burned)
+	assert.Equal(50.0, supply.Locked)
+	assert.Equal(25.0, supply.Treasury)
+	assert.Equal(0.4, supply.StakingRatio)
+
+	// No supply
+	supply = newSupplySnapshot(1656547200, 0, 0, 0, 0, &service.NetworkInfo{})
+	assert.Equal(0.0, supply.StakingRatio)
+}
+
+func TestHistoryTimestamp(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(int64(1656547200), historyTimestamp(1656549000, time.Hour))
+	assert.Equal(int64(1656547200), historyTimestamp(1656547200, time.Hour))
+	assert.Equal(int64(1656547200), historyTimestamp(1656633599, 24*time.Hour))
+	assert.Equal(int64(1656549000), historyTimestamp(1656549000, 0))
+}
@@ -0,0 +1,24 @@
+package stats
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/crud"
+)
+
+// SnapshotTokenHolders - holder count of every token for today, taken again until the day is over
+func SnapshotTokenHolders() {
+	now := time.Now()
+
+	err := crud.GetTokenHolderSnapshotCrud().UpsertFromTokenAddresses(
+		context.Background(),
+		now.UTC().Format(DateFormat),
+		now.Unix(),
+	)
+	if err != nil {
+		zap.S().Warn("Stats: Unable to snapshot token holders ERROR=", err.Error())
+	}
+}
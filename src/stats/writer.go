@@ -0,0 +1,107 @@
+package stats
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+)
+
+// Key of the advisory lock electing the instance that writes the stats tables
+const writerLockKey int64 = 0x69636f6e73746174
+
+// 1 while this instance holds the writer lock
+var writer int32
+
+// isWriter - true if this instance writes the stats tables
+func isWriter() bool {
+	return atomic.LoadInt32(&writer) == 1
+}
+
+// setWriter - start or stop writing the stats tables
+func setWriter(isWriter bool) {
+	value := int32(0)
+	if isWriter {
+		value = 1
+	}
+	atomic.StoreInt32(&writer, value)
+}
+
+// writerOnly - run a job only while this instance is the writer
+func writerOnly(job func()) func() {
+	return func() {
+		if isWriter() == false {
+			return
+		}
+		job()
+	}
+}
+
+// startWriter - hold the writer lock, and roll up daily stats and snapshot token holders while elected
+// Other instances with the writer enabled take over if the writer's connection to postgres drops
+func startWriter() {
+	var lock *crud.AdvisoryLock
+	started := false
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), config.Config.StatsWriterLockInterval)
+		lock = checkWriterLock(ctx, lock)
+		cancel()
+
+		setWriter(lock != nil)
+
+		// Started once first elected, so the first runs aren't skipped
+		if lock != nil && started == false {
+			started = true
+
+			go supervise("daily stats", config.Config.StatsDailyRollupInterval, writerOnly(RollupDaily))
+			go supervise("token holders", config.Config.StatsDailyRollupInterval, writerOnly(SnapshotTokenHolders))
+		}
+
+		time.Sleep(config.Config.StatsWriterLockInterval)
+	}
+}
+
+// checkWriterLock - the lock if it's still held, otherwise try to take it, nil if another instance holds it
+func checkWriterLock(ctx context.Context, lock *crud.AdvisoryLock) *crud.AdvisoryLock {
+	if lock != nil {
+		err := lock.Check(ctx)
+		if err == nil {
+			return lock
+		}
+
+		zap.S().Warn("Stats: Lost stats writer lock ERROR=", err.Error())
+		_ = lock.Release(ctx)
+	}
+
+	lock, err := crud.TryAdvisoryLock(ctx, writerLockKey)
+	if err != nil {
+		zap.S().Warn("Stats: Unable to take stats writer lock ERROR=", err.Error())
+		return nil
+	}
+	if lock != nil {
+		zap.S().Info("Stats: Elected stats writer")
+	}
+
+	return lock
+}
+
+// migrate - create or update the stats tables
+// NOTE: unlike the chain tables, the stats tables are owned by this service
+func migrate() error {
+	err := crud.GetSupplySnapshotCrud().Migrate()
+	if err != nil {
+		return err
+	}
+
+	err = crud.GetDailyStatCrud().Migrate()
+	if err != nil {
+		return err
+	}
+
+	return crud.GetTokenHolderSnapshotCrud().Migrate()
+}
@@ -0,0 +1,30 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriterOnly(t *testing.T) {
+	assert := assert.New(t)
+	defer setWriter(false)
+
+	runs := 0
+	job := writerOnly(func() {
+		runs++
+	})
+
+	// Not elected
+	job()
+	assert.Equal(0, runs)
+
+	setWriter(true)
+	job()
+	assert.Equal(1, runs)
+
+	// Lost the lock
+	setWriter(false)
+	job()
+	assert.Equal(1, runs)
+}
@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"errors"
+	fiber "github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fiberHeaderCarrier - propagation.TextMapCarrier over fiber request headers
+type fiberHeaderCarrier struct {
+	c *fiber.Ctx
+}
+
+func (f fiberHeaderCarrier) Get(key string) string {
+	return f.c.Get(key)
+}
+
+func (f fiberHeaderCarrier) Set(key string, value string) {
+	f.c.Request().Header.Set(key, value)
+}
+
+func (f fiberHeaderCarrier) Keys() []string {
+	keys := []string{}
+	f.c.Request().Header.VisitAll(func(key []byte, value []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
+
+var _ propagation.TextMapCarrier = fiberHeaderCarrier{}
+
+// NewFiberMiddleware - span for each request, continuing the caller's trace
+// Handlers get the span's context from c.UserContext()
+func NewFiberMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), fiberHeaderCarrier{c})
+
+		ctx, span := Tracer().Start(
+			ctx,
+			c.Method()+" "+c.Path(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethodKey.String(c.Method()),
+				semconv.HTTPTargetKey.String(string(c.Request().RequestURI())),
+				semconv.HTTPSchemeKey.String(c.Protocol()),
+				semconv.NetPeerIPKey.String(c.IP()),
+			),
+		)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			fiberErr := &fiber.Error{}
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+			span.RecordError(err)
+		}
+
+		// Route template, known once the route has matched
+		route := c.Route().Path
+		span.SetName(c.Method() + " " + route)
+		span.SetAttributes(
+			semconv.HTTPRouteKey.String(route),
+			semconv.HTTPStatusCodeKey.Int(status),
+		)
+		if status >= 500 {
+			span.SetStatus(codes.Error, "")
+		}
+
+		return err
+	}
+}
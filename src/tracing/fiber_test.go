@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestFiberMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	exporter := StartInMemory()
+
+	app := fiber.New()
+	app.Use(NewFiberMiddleware())
+	app.Get("/api/v1/blocks/:number", func(c *fiber.Ctx) error {
+		// Handlers continue the request's trace
+		_, span := Tracer().Start(c.UserContext(), "handler")
+		span.End()
+
+		return c.SendString("{}")
+	})
+
+	req := httptest.NewRequest("GET", "/api/v1/blocks/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, err := app.Test(req)
+	assert.Equal(nil, err)
+
+	spans := exporter.GetSpans()
+	assert.Equal(2, len(spans))
+
+	// Handler span ends first
+	handlerSpan := spans[0]
+	requestSpan := spans[1]
+
+	assert.Equal("GET /api/v1/blocks/:number", requestSpan.Name)
+	assert.Equal(trace.SpanKindServer, requestSpan.SpanKind)
+	assert.Equal("4bf92f3577b34da6a3ce929d0e0e4736", requestSpan.SpanContext.TraceID().String())
+	assert.Equal("00f067aa0ba902b7", requestSpan.Parent.SpanID().String())
+
+	assert.Equal(requestSpan.SpanContext.TraceID(), handlerSpan.SpanContext.TraceID())
+	assert.Equal(requestSpan.SpanContext.SpanID(), handlerSpan.Parent.SpanID())
+}
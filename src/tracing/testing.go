@@ -0,0 +1,17 @@
+package tracing
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// StartInMemory - record every span in memory instead of exporting, for tests
+func StartInMemory() *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+
+	setTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+	))
+
+	return exporter
+}
@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/config"
+)
+
+const tracerName = "github.com/sudoblockio/icon-go-api"
+
+// Start - export spans over OTLP and propagate W3C trace context
+func Start() {
+	exporterOptions := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(config.Config.TracingOTLPEndpoint),
+	}
+	if config.Config.TracingOTLPInsecure {
+		exporterOptions = append(exporterOptions, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), exporterOptions...)
+	if err != nil {
+		zap.S().Fatal("Tracing: Unable to create OTLP exporter ERROR=", err.Error())
+	}
+
+	setTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.Config.TracingSampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(config.Config.Name),
+			semconv.DeploymentEnvironmentKey.String(config.Config.NetworkName),
+		)),
+	))
+
+	zap.S().Info("Started Tracing:", config.Config.TracingOTLPEndpoint)
+}
+
+func setTracerProvider(tracerProvider trace.TracerProvider) {
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+// Tracer - tracer for the service's spans
+// No-op until Start is called
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
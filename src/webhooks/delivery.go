@@ -0,0 +1,196 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// Headers sent with every delivery
+// The signature is the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the webhook secret
+const (
+	HeaderWebhookID         = "X-Webhook-Id"
+	HeaderWebhookDeliveryID = "X-Webhook-Delivery-Id"
+	HeaderWebhookEventType  = "X-Webhook-Event-Type"
+	HeaderWebhookTimestamp  = "X-Webhook-Timestamp"
+	HeaderWebhookSignature  = "X-Webhook-Signature"
+)
+
+// deliveryBody - body POSTed to the webhook url
+type deliveryBody struct {
+	DeliveryID int64           `json:"delivery_id"`
+	WebhookID  int64           `json:"webhook_id"`
+	EventType  string          `json:"event_type"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Sign - signature header value for a delivery body
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// RetryDelay - exponential backoff after a number of failed attempts
+func RetryDelay(attempts int64) time.Duration {
+	delay := config.Config.WebhookBackoffInitial
+	for i := int64(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= config.Config.WebhookBackoffMax {
+			return config.Config.WebhookBackoffMax
+		}
+	}
+
+	return delay
+}
+
+// startDeliveryPoller - claim due deliveries and queue them for the workers
+func startDeliveryPoller(deliveryQueue chan models.WebhookDelivery) {
+	// Long enough for a worker to pick up and send the delivery before another replica can claim it
+	lease := config.Config.WebhookPollInterval + 2*config.Config.WebhookRequestTimeout
+
+	for {
+		time.Sleep(config.Config.WebhookPollInterval)
+
+		now := time.Now()
+		webhookDeliveries, err := crud.GetWebhookDeliveryCrud().ClaimDue(
+			context.Background(),
+			config.Config.WebhookWorkers,
+			now.Unix(),
+			now.Add(lease).Unix(),
+		)
+		if err != nil {
+			zap.S().Warn("Webhooks: Unable to claim deliveries ERROR=", err.Error())
+			continue
+		}
+
+		for _, webhookDelivery := range *webhookDeliveries {
+			deliveryQueue <- webhookDelivery
+		}
+	}
+}
+
+// startDeliveryWorker - send queued deliveries and record the outcome
+func startDeliveryWorker(deliveryQueue chan models.WebhookDelivery) {
+	client := newDeliveryClient()
+
+	for webhookDelivery := range deliveryQueue {
+		// Set by the claim, the update only applies while the lease is held
+		leaseTimestamp := webhookDelivery.NextAttemptTimestamp
+
+		webhook, ok := getWebhookByID(webhookDelivery.WebhookId)
+		if ok == false {
+			// Registered on another replica since the last refresh
+			webhookFromDB, err := crud.GetWebhookCrud().SelectOne(crud.WithPrimary(context.Background()), webhookDelivery.WebhookId)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// Deleted, nothing to deliver to
+				webhookDelivery.Status = crud.WebhookDeliveryStatusDead
+				webhookDelivery.LastError = "webhook not found"
+				_ = crud.GetWebhookDeliveryCrud().UpdateOneLeased(context.Background(), &webhookDelivery, leaseTimestamp)
+				continue
+			}
+			if err != nil {
+				// Lease expires and the delivery is claimed again
+				zap.S().Warn("Webhooks: Unable to find webhook WEBHOOK_ID=", webhookDelivery.WebhookId, " ERROR=", err.Error())
+				continue
+			}
+			webhook = *webhookFromDB
+		}
+
+		statusCode, err := Deliver(client, &webhook, &webhookDelivery)
+		recordAttempt(&webhookDelivery, statusCode, err)
+
+		err = crud.GetWebhookDeliveryCrud().UpdateOneLeased(context.Background(), &webhookDelivery, leaseTimestamp)
+		if errors.Is(err, crud.ErrWebhookDeliveryLeaseLost) {
+			// Claimed again by another worker, which records its own attempt
+			zap.S().Warn("Webhooks: Delivery lease expired before the attempt was recorded DELIVERY_ID=", webhookDelivery.Id)
+		} else if err != nil {
+			zap.S().Warn("Webhooks: Unable to update delivery DELIVERY_ID=", webhookDelivery.Id, " ERROR=", err.Error())
+		}
+	}
+}
+
+// Deliver - POST a signed delivery to the webhook url
+// Returns the response status code, an error if the delivery did not succeed
+func Deliver(client *http.Client, webhook *models.Webhook, webhookDelivery *models.WebhookDelivery) (int64, error) {
+	body, err := json.Marshal(&deliveryBody{
+		DeliveryID: webhookDelivery.Id,
+		WebhookID:  webhook.Id,
+		EventType:  webhookDelivery.EventType,
+		Data:       json.RawMessage(webhookDelivery.Payload),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest("POST", webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookID, strconv.FormatInt(webhook.Id, 10))
+	req.Header.Set(HeaderWebhookDeliveryID, strconv.FormatInt(webhookDelivery.Id, 10))
+	req.Header.Set(HeaderWebhookEventType, webhookDelivery.EventType)
+	req.Header.Set(HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderWebhookSignature, Sign(webhook.Secret, timestamp, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Drain so the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return int64(resp.StatusCode), errors.New("StatusCode=" + strconv.Itoa(resp.StatusCode))
+	}
+
+	return int64(resp.StatusCode), nil
+}
+
+// recordAttempt - update a delivery with the outcome of an attempt
+func recordAttempt(webhookDelivery *models.WebhookDelivery, statusCode int64, err error) {
+	now := time.Now()
+
+	webhookDelivery.Attempts++
+	webhookDelivery.LastStatusCode = statusCode
+	webhookDelivery.UpdatedTimestamp = now.Unix()
+
+	if err == nil {
+		webhookDelivery.Status = crud.WebhookDeliveryStatusDelivered
+		webhookDelivery.LastError = ""
+		return
+	}
+
+	webhookDelivery.LastError = err.Error()
+	if webhookDelivery.Attempts >= config.Config.WebhookMaxAttempts {
+		// Dead-letter
+		webhookDelivery.Status = crud.WebhookDeliveryStatusDead
+		return
+	}
+
+	webhookDelivery.NextAttemptTimestamp = now.Add(RetryDelay(webhookDelivery.Attempts)).Unix()
+}
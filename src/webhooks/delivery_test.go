@@ -0,0 +1,129 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func TestSign(t *testing.T) {
+	assert := assert.New(t)
+
+	// printf '1600000000.{"a":1}' | openssl dgst -sha256 -hmac secret
+	signature := Sign("secret", 1600000000, []byte(`{"a":1}`))
+	assert.Equal("sha256=4e107d82910257d43758070322323c95b92af39939824d6610e2c9809a43b8d5", signature)
+
+	assert.NotEqual(signature, Sign("other", 1600000000, []byte(`{"a":1}`)))
+	assert.NotEqual(signature, Sign("secret", 1600000001, []byte(`{"a":1}`)))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.WebhookBackoffInitial = 5 * time.Second
+	config.Config.WebhookBackoffMax = time.Minute
+
+	assert.Equal(5*time.Second, RetryDelay(1))
+	assert.Equal(10*time.Second, RetryDelay(2))
+	assert.Equal(40*time.Second, RetryDelay(4))
+	assert.Equal(time.Minute, RetryDelay(5))
+	assert.Equal(time.Minute, RetryDelay(100))
+}
+
+func TestDeliver(t *testing.T) {
+	assert := assert.New(t)
+
+	webhook := &models.Webhook{Id: 1, EventType: EventTypeTransactions, Secret: "secret"}
+	webhookDelivery := &models.WebhookDelivery{Id: 2, WebhookId: 1, EventType: EventTypeTransactions, Payload: `{"hash":"0x1"}`}
+
+	var received *http.Request
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	webhook.Url = server.URL
+
+	statusCode, err := Deliver(server.Client(), webhook, webhookDelivery)
+	assert.Equal(nil, err)
+	assert.Equal(int64(200), statusCode)
+
+	// Headers
+	assert.Equal("1", received.Header.Get(HeaderWebhookID))
+	assert.Equal("2", received.Header.Get(HeaderWebhookDeliveryID))
+	assert.Equal(EventTypeTransactions, received.Header.Get(HeaderWebhookEventType))
+
+	// Signature
+	timestamp, err := strconv.ParseInt(received.Header.Get(HeaderWebhookTimestamp), 10, 64)
+	assert.Equal(nil, err)
+	assert.Equal(Sign("secret", timestamp, receivedBody), received.Header.Get(HeaderWebhookSignature))
+
+	// Body
+	body := &deliveryBody{}
+	err = json.Unmarshal(receivedBody, body)
+	assert.Equal(nil, err)
+	assert.Equal(int64(2), body.DeliveryID)
+	assert.Equal(int64(1), body.WebhookID)
+	assert.JSONEq(`{"hash":"0x1"}`, string(body.Data))
+}
+
+func TestDeliverNon2xx(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhook := &models.Webhook{Id: 1, Url: server.URL, EventType: EventTypeBlocks, Secret: "secret"}
+	webhookDelivery := &models.WebhookDelivery{Id: 2, WebhookId: 1, EventType: EventTypeBlocks, Payload: `{}`}
+
+	statusCode, err := Deliver(server.Client(), webhook, webhookDelivery)
+	assert.NotEqual(nil, err)
+	assert.Equal(int64(500), statusCode)
+}
+
+func TestRecordAttempt(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.WebhookMaxAttempts = 3
+	config.Config.WebhookBackoffInitial = 5 * time.Second
+	config.Config.WebhookBackoffMax = time.Minute
+
+	webhookDelivery := &models.WebhookDelivery{Status: crud.WebhookDeliveryStatusPending}
+
+	// Retry with backoff
+	before := time.Now().Unix()
+	recordAttempt(webhookDelivery, 500, errors.New("StatusCode=500"))
+	assert.Equal(crud.WebhookDeliveryStatusPending, webhookDelivery.Status)
+	assert.Equal(int64(1), webhookDelivery.Attempts)
+	assert.Equal(int64(500), webhookDelivery.LastStatusCode)
+	assert.Equal("StatusCode=500", webhookDelivery.LastError)
+	assert.GreaterOrEqual(webhookDelivery.NextAttemptTimestamp, before+5)
+
+	// Dead-letter after max attempts
+	recordAttempt(webhookDelivery, 0, errors.New("connection refused"))
+	recordAttempt(webhookDelivery, 0, errors.New("connection refused"))
+	assert.Equal(crud.WebhookDeliveryStatusDead, webhookDelivery.Status)
+	assert.Equal(int64(3), webhookDelivery.Attempts)
+
+	// Success
+	webhookDelivery = &models.WebhookDelivery{Status: crud.WebhookDeliveryStatusPending, Attempts: 1, LastError: "timeout"}
+	recordAttempt(webhookDelivery, 204, nil)
+	assert.Equal(crud.WebhookDeliveryStatusDelivered, webhookDelivery.Status)
+	assert.Equal(int64(2), webhookDelivery.Attempts)
+	assert.Equal("", webhookDelivery.LastError)
+}
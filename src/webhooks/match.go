@@ -0,0 +1,101 @@
+package webhooks
+
+import (
+	"encoding/json"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+// eventMessage - fields of event messages that webhooks can filter on
+type eventMessage struct {
+	FromAddress          string `json:"from_address"`
+	ToAddress            string `json:"to_address"`
+	Address              string `json:"address"`
+	Method               string `json:"method"`
+	TokenContractAddress string `json:"token_contract_address"`
+}
+
+// ValidationError - invalid field of a webhook
+type ValidationError struct {
+	field   string
+	message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.message
+}
+
+// Field - name of the invalid field in the request body
+func (e *ValidationError) Field() string {
+	return e.field
+}
+
+// ValidateWebhook - check the event type and that its filters apply to it
+func ValidateWebhook(webhook *models.Webhook) error {
+	switch webhook.EventType {
+	case EventTypeBlocks:
+		if webhook.Address != "" || webhook.Contract != "" || webhook.Method != "" {
+			return &ValidationError{"event_type", "blocks webhooks can't have filters"}
+		}
+	case EventTypeTransactions, EventTypeLogs:
+	case EventTypeTokenTransfers:
+		if webhook.Method != "" {
+			return &ValidationError{"method", "token_transfers webhooks can't filter on method"}
+		}
+	default:
+		return &ValidationError{"event_type", "event_type must be one of blocks, transactions, logs, token_transfers"}
+	}
+
+	return nil
+}
+
+// Match - check if an event message passes the webhook's filters
+//
+// address - transaction or token transfer from or to address, log emitting contract
+// contract - transaction to address, log emitting contract, token transfer token contract
+// method - transaction or log method
+func Match(webhook *models.Webhook, eventType string, msg []byte) bool {
+	if webhook.EventType != eventType {
+		return false
+	}
+
+	if webhook.Address == "" && webhook.Contract == "" && webhook.Method == "" {
+		return true
+	}
+
+	message := &eventMessage{}
+	err := json.Unmarshal(msg, message)
+	if err != nil {
+		return false
+	}
+
+	switch eventType {
+	case EventTypeTransactions:
+		if webhook.Address != "" && webhook.Address != message.FromAddress && webhook.Address != message.ToAddress {
+			return false
+		}
+		if webhook.Contract != "" && webhook.Contract != message.ToAddress {
+			return false
+		}
+	case EventTypeLogs:
+		if webhook.Address != "" && webhook.Address != message.Address {
+			return false
+		}
+		if webhook.Contract != "" && webhook.Contract != message.Address {
+			return false
+		}
+	case EventTypeTokenTransfers:
+		if webhook.Address != "" && webhook.Address != message.FromAddress && webhook.Address != message.ToAddress {
+			return false
+		}
+		if webhook.Contract != "" && webhook.Contract != message.TokenContractAddress {
+			return false
+		}
+	}
+
+	if webhook.Method != "" && webhook.Method != message.Method {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,66 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func TestValidateWebhook(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		description string
+		webhook     *models.Webhook
+		valid       bool
+	}{
+		{"blocks", &models.Webhook{EventType: EventTypeBlocks}, true},
+		{"blocks with filter", &models.Webhook{EventType: EventTypeBlocks, Address: "hx1"}, false},
+		{"transactions with filters", &models.Webhook{EventType: EventTypeTransactions, Address: "hx1", Contract: "cx1", Method: "transfer"}, true},
+		{"logs with method", &models.Webhook{EventType: EventTypeLogs, Method: "Transfer"}, true},
+		{"token transfers with contract", &models.Webhook{EventType: EventTypeTokenTransfers, Contract: "cx1"}, true},
+		{"token transfers with method", &models.Webhook{EventType: EventTypeTokenTransfers, Method: "transfer"}, false},
+		{"unknown event type", &models.Webhook{EventType: "addresses"}, false},
+	}
+
+	for _, test := range tests {
+		err := ValidateWebhook(test.webhook)
+		assert.Equal(test.valid, err == nil, test.description)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	transaction := []byte(`{"hash": "0x1", "from_address": "hx1", "to_address": "cx1", "method": "transfer"}`)
+	log := []byte(`{"transaction_hash": "0x1", "address": "cx1", "method": "Transfer"}`)
+	tokenTransfer := []byte(`{"from_address": "hx1", "to_address": "hx2", "token_contract_address": "cx2"}`)
+
+	tests := []struct {
+		description string
+		webhook     *models.Webhook
+		eventType   string
+		msg         []byte
+		expected    bool
+	}{
+		{"no filters", &models.Webhook{EventType: EventTypeTransactions}, EventTypeTransactions, transaction, true},
+		{"other event type", &models.Webhook{EventType: EventTypeLogs}, EventTypeTransactions, transaction, false},
+		{"transaction from", &models.Webhook{EventType: EventTypeTransactions, Address: "hx1"}, EventTypeTransactions, transaction, true},
+		{"transaction to", &models.Webhook{EventType: EventTypeTransactions, Address: "cx1"}, EventTypeTransactions, transaction, true},
+		{"transaction address mismatch", &models.Webhook{EventType: EventTypeTransactions, Address: "hx2"}, EventTypeTransactions, transaction, false},
+		{"transaction contract", &models.Webhook{EventType: EventTypeTransactions, Contract: "cx1", Method: "transfer"}, EventTypeTransactions, transaction, true},
+		{"transaction method mismatch", &models.Webhook{EventType: EventTypeTransactions, Method: "vote"}, EventTypeTransactions, transaction, false},
+		{"log contract", &models.Webhook{EventType: EventTypeLogs, Contract: "cx1"}, EventTypeLogs, log, true},
+		{"log contract mismatch", &models.Webhook{EventType: EventTypeLogs, Contract: "cx2"}, EventTypeLogs, log, false},
+		{"token transfer address", &models.Webhook{EventType: EventTypeTokenTransfers, Address: "hx2"}, EventTypeTokenTransfers, tokenTransfer, true},
+		{"token transfer contract", &models.Webhook{EventType: EventTypeTokenTransfers, Contract: "cx2"}, EventTypeTokenTransfers, tokenTransfer, true},
+		{"token transfer contract mismatch", &models.Webhook{EventType: EventTypeTokenTransfers, Contract: "cx1"}, EventTypeTokenTransfers, tokenTransfer, false},
+		{"invalid message", &models.Webhook{EventType: EventTypeTransactions, Address: "hx1"}, EventTypeTransactions, []byte(`not json`), false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.expected, Match(test.webhook, test.eventType, test.msg), test.description)
+	}
+}
@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"sync"
+)
+
+// dispatchQueue - bounded queue of event messages waiting to be dispatched
+type dispatchQueue struct {
+	msgs    [][]byte
+	size    int
+	mux     sync.Mutex
+	pending chan struct{}
+}
+
+func newDispatchQueue(size int) *dispatchQueue {
+	return &dispatchQueue{
+		size:    size,
+		pending: make(chan struct{}, 1),
+	}
+}
+
+// push - add a message without blocking, false if the queue is full
+func (q *dispatchQueue) push(msg []byte) bool {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if len(q.msgs) >= q.size {
+		return false
+	}
+	q.msgs = append(q.msgs, msg)
+
+	select {
+	case q.pending <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+// pop - remove up to limit messages, oldest first, waiting until there is at least one
+func (q *dispatchQueue) pop(limit int) [][]byte {
+	for {
+		q.mux.Lock()
+		if len(q.msgs) > 0 {
+			if limit > len(q.msgs) {
+				limit = len(q.msgs)
+			}
+			msgs := q.msgs[:limit:limit]
+			q.msgs = q.msgs[limit:]
+			q.mux.Unlock()
+
+			return msgs
+		}
+		q.mux.Unlock()
+
+		<-q.pending
+	}
+}
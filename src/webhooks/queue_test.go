@@ -0,0 +1,49 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatchQueue(t *testing.T) {
+	assert := assert.New(t)
+
+	queue := newDispatchQueue(3)
+	assert.Equal(true, queue.push([]byte("1")))
+	assert.Equal(true, queue.push([]byte("2")))
+	assert.Equal(true, queue.push([]byte("3")))
+
+	// Full
+	assert.Equal(false, queue.push([]byte("4")))
+
+	assert.Equal([][]byte{[]byte("1"), []byte("2")}, queue.pop(2))
+	assert.Equal(true, queue.push([]byte("5")))
+	assert.Equal([][]byte{[]byte("3"), []byte("5")}, queue.pop(10))
+}
+
+func TestDispatchQueuePopWaits(t *testing.T) {
+	assert := assert.New(t)
+
+	queue := newDispatchQueue(10)
+
+	popped := make(chan [][]byte)
+	go func() {
+		popped <- queue.pop(10)
+	}()
+
+	select {
+	case <-popped:
+		t.Fatal("pop returned from an empty queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	queue.push([]byte("1"))
+	select {
+	case msgs := <-popped:
+		assert.Equal([][]byte{[]byte("1")}, msgs)
+	case <-time.After(time.Second):
+		t.Fatal("pop did not return after push")
+	}
+}
@@ -0,0 +1,83 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/sudoblockio/icon-go-api/config"
+)
+
+// ErrAddressNotAllowed - webhook url resolves to an address inside the network
+var ErrAddressNotAllowed = errors.New("webhook url must not resolve to a loopback, private, link-local or unspecified address")
+
+// allowedIP - false for addresses that would let a webhook reach services inside the network
+func allowedIP(ip net.IP) bool {
+	return ip.IsLoopback() == false &&
+		ip.IsPrivate() == false &&
+		ip.IsLinkLocalUnicast() == false &&
+		ip.IsLinkLocalMulticast() == false &&
+		ip.IsInterfaceLocalMulticast() == false &&
+		ip.IsUnspecified() == false
+}
+
+// ValidateURL - check the url is an absolute http or https url resolving only to allowed addresses
+// Deliveries check the address again when connecting as the host can resolve differently later
+func ValidateURL(ctx context.Context, rawURL string) error {
+	webhookURL, err := url.Parse(rawURL)
+	if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Hostname() == "" {
+		return &ValidationError{"url", "url must be an absolute http or https url"}
+	}
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", webhookURL.Hostname())
+	if err != nil {
+		return &ValidationError{"url", "url host could not be resolved"}
+	}
+	for _, ip := range ips {
+		if allowedIP(ip) == false {
+			return &ValidationError{"url", ErrAddressNotAllowed.Error()}
+		}
+	}
+
+	return nil
+}
+
+// dialControl - reject connections to addresses that aren't allowed, after the host is resolved
+func dialControl(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || allowedIP(ip) == false {
+		return ErrAddressNotAllowed
+	}
+
+	return nil
+}
+
+// newDeliveryClient - http client for deliveries
+// Connections are checked against the allowed addresses, no proxy is used and redirects aren't followed
+func newDeliveryClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: config.Config.WebhookRequestTimeout,
+		Control: dialControl,
+	}
+
+	return &http.Client{
+		Timeout: config.Config.WebhookRequestTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConnsPerHost: 2,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/models"
+)
+
+func TestValidateURL(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+
+	assert.Equal(nil, ValidateURL(ctx, "https://1.1.1.1/hook"))
+	assert.Equal(nil, ValidateURL(ctx, "http://[2606:4700:4700::1111]:8080/hook"))
+
+	for _, rawURL := range []string{
+		"ftp://1.1.1.1/hook",
+		"/hook",
+		"http://127.0.0.1/hook",
+		"http://localhost:8080/hook",
+		"http://10.0.0.1/hook",
+		"http://192.168.1.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://0.0.0.0/hook",
+		"http://[::1]/hook",
+		"http://[fe80::1]/hook",
+		"http://[fd00::1]/hook",
+	} {
+		err := ValidateURL(ctx, rawURL)
+		assert.NotEqual(nil, err, rawURL)
+
+		validationErr, ok := err.(*ValidationError)
+		assert.Equal(true, ok, rawURL)
+		if ok {
+			assert.Equal("url", validationErr.Field())
+		}
+	}
+}
+
+func TestDeliveryClientRejectsInternalAddresses(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.WebhookRequestTimeout = time.Second
+
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	// The url was allowed when registered and now resolves to loopback
+	webhook := &models.Webhook{Id: 1, Url: server.URL, EventType: EventTypeBlocks, Secret: "secret"}
+	webhookDelivery := &models.WebhookDelivery{Id: 2, WebhookId: 1, EventType: EventTypeBlocks, Payload: `{}`}
+
+	_, err := Deliver(newDeliveryClient(), webhook, webhookDelivery)
+	assert.Equal(true, errors.Is(err, ErrAddressNotAllowed))
+	assert.Equal(false, requested)
+}
+
+func TestDeliveryClientNoRedirects(t *testing.T) {
+	assert := assert.New(t)
+
+	client := newDeliveryClient()
+	assert.Equal(http.ErrUseLastResponse, client.CheckRedirect(nil, nil))
+}
@@ -0,0 +1,209 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sudoblockio/icon-go-api/config"
+	"github.com/sudoblockio/icon-go-api/crud"
+	"github.com/sudoblockio/icon-go-api/metrics"
+	"github.com/sudoblockio/icon-go-api/models"
+	"github.com/sudoblockio/icon-go-api/redis"
+)
+
+// Event types
+const (
+	EventTypeBlocks         = "blocks"
+	EventTypeTransactions   = "transactions"
+	EventTypeLogs           = "logs"
+	EventTypeTokenTransfers = "token_transfers"
+)
+
+// EventTypeChannels - redis channel for each event type
+func EventTypeChannels() map[string]string {
+	return map[string]string{
+		EventTypeBlocks:         config.Config.RedisBlocksChannel,
+		EventTypeTransactions:   config.Config.RedisTransactionsChannel,
+		EventTypeLogs:           config.Config.RedisLogsChannel,
+		EventTypeTokenTransfers: config.Config.RedisTokenTransfersChannel,
+	}
+}
+
+// Registered webhooks, refreshed from the database
+var webhooksByEventType = map[string][]models.Webhook{}
+var webhooksByID = map[int64]models.Webhook{}
+var webhooksMux sync.RWMutex
+
+// Active - true if webhooks are enabled and can be managed, both the endpoints and the dispatchers use this
+// Webhooks are managed with the admin api key, without one nobody could manage the webhooks being delivered
+func Active() bool {
+	return config.Config.WebhooksEnabled && config.Config.AdminAPIKey != ""
+}
+
+// Start - migrate tables and start the dispatchers and delivery workers
+func Start() {
+	err := crud.GetWebhookCrud().Migrate()
+	if err != nil {
+		zap.S().Fatal("Webhooks: Unable to migrate webhooks table ERROR=", err.Error())
+	}
+	err = crud.GetWebhookDeliveryCrud().Migrate()
+	if err != nil {
+		zap.S().Fatal("Webhooks: Unable to migrate webhook_deliveries table ERROR=", err.Error())
+	}
+
+	Refresh()
+	go func() {
+		for {
+			time.Sleep(config.Config.WebhookRefreshInterval)
+			Refresh()
+		}
+	}()
+
+	for eventType, channelName := range EventTypeChannels() {
+		go startDispatcher(eventType, channelName)
+	}
+
+	deliveryQueue := make(chan models.WebhookDelivery)
+	for i := 0; i < config.Config.WebhookWorkers; i++ {
+		go startDeliveryWorker(deliveryQueue)
+	}
+	go startDeliveryPoller(deliveryQueue)
+
+	zap.S().Info("Started Webhooks")
+}
+
+// Refresh - reload registered webhooks from the database
+func Refresh() {
+	// Webhooks registered just before may not be on the database replicas yet
+	webhooks, err := crud.GetWebhookCrud().SelectMany(crud.WithPrimary(context.Background()), 0, 0, "")
+	if err != nil {
+		zap.S().Warn("Webhooks: Unable to refresh webhooks ERROR=", err.Error())
+		return
+	}
+
+	newWebhooksByEventType := map[string][]models.Webhook{}
+	newWebhooksByID := map[int64]models.Webhook{}
+	for _, webhook := range *webhooks {
+		newWebhooksByEventType[webhook.EventType] = append(newWebhooksByEventType[webhook.EventType], webhook)
+		newWebhooksByID[webhook.Id] = webhook
+	}
+
+	webhooksMux.Lock()
+	defer webhooksMux.Unlock()
+
+	webhooksByEventType = newWebhooksByEventType
+	webhooksByID = newWebhooksByID
+}
+
+func getWebhooksByEventType(eventType string) []models.Webhook {
+	webhooksMux.RLock()
+	defer webhooksMux.RUnlock()
+
+	return webhooksByEventType[eventType]
+}
+
+func getWebhookByID(id int64) (models.Webhook, bool) {
+	webhooksMux.RLock()
+	defer webhooksMux.RUnlock()
+
+	webhook, ok := webhooksByID[id]
+	return webhook, ok
+}
+
+// startDispatcher - create a pending delivery for each webhook matching a message on the channel
+// Messages are queued so the subscriber keeps up with the broadcaster while deliveries are inserted in batches
+func startDispatcher(eventType string, channelName string) {
+	queue := newDispatchQueue(config.Config.WebhookQueueSize)
+	go startDispatchWriter(eventType, queue)
+
+	for {
+		// Disconnected rather than silently dropping messages when the buffer is full
+		msgChan := make(chan []byte, config.Config.WebsocketClientBufferSize)
+		broadcasterID := redis.GetBroadcaster(channelName).AddBroadcastChannelWithPolicy(msgChan, redis.SlowConsumerPolicyDisconnect)
+
+		for msg := range msgChan {
+			if queue.push(msg) == false {
+				dropEvent(eventType, msg, "queue full")
+			}
+		}
+
+		// Removed by the broadcaster as a slow consumer, subscribe again
+		// The message that didn't fit is lost, along with any published before subscribing again
+		redis.GetBroadcaster(channelName).RemoveBroadcastChannel(broadcasterID)
+		metrics.WebhookEventsDroppedCounter.WithLabelValues(eventType).Inc()
+		zap.S().Warn("Webhooks: Dispatcher fell behind, events were dropped EVENT_TYPE=", eventType)
+	}
+}
+
+// startDispatchWriter - insert the deliveries of queued messages in batches
+// A batch is retried until it is inserted, the queue holds new messages meanwhile
+func startDispatchWriter(eventType string, queue *dispatchQueue) {
+	for {
+		msgs := queue.pop(config.Config.WebhookBatchSize)
+
+		webhookDeliveries := []models.WebhookDelivery{}
+		for _, msg := range msgs {
+			webhookDeliveries = append(webhookDeliveries, newDeliveries(eventType, msg)...)
+		}
+		if len(webhookDeliveries) == 0 {
+			continue
+		}
+
+		for {
+			err := crud.GetWebhookDeliveryCrud().InsertMany(context.Background(), &webhookDeliveries)
+			if err == nil {
+				break
+			}
+
+			zap.S().Warn("Webhooks: Unable to insert deliveries EVENT_TYPE=", eventType, " ERROR=", err.Error())
+			time.Sleep(config.Config.WebhookPollInterval)
+		}
+	}
+}
+
+// newDeliveries - a pending delivery for each webhook matching the message
+func newDeliveries(eventType string, msg []byte) []models.WebhookDelivery {
+	webhooks := getWebhooksByEventType(eventType)
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	eventHash := hashEvent(msg)
+	nowTimestamp := time.Now().Unix()
+
+	webhookDeliveries := []models.WebhookDelivery{}
+	for _, webhook := range webhooks {
+		if Match(&webhook, eventType, msg) == false {
+			continue
+		}
+
+		webhookDeliveries = append(webhookDeliveries, models.WebhookDelivery{
+			WebhookId:            webhook.Id,
+			EventType:            eventType,
+			EventHash:            eventHash,
+			Payload:              string(msg),
+			Status:               crud.WebhookDeliveryStatusPending,
+			NextAttemptTimestamp: nowTimestamp,
+			CreatedTimestamp:     nowTimestamp,
+			UpdatedTimestamp:     nowTimestamp,
+		})
+	}
+
+	return webhookDeliveries
+}
+
+// dropEvent - count and log an event that won't be dispatched
+func dropEvent(eventType string, msg []byte, reason string) {
+	metrics.WebhookEventsDroppedCounter.WithLabelValues(eventType).Inc()
+	zap.S().Warn("Webhooks: Dropped event EVENT_TYPE=", eventType, " EVENT_HASH=", hashEvent(msg), " REASON=", reason)
+}
+
+func hashEvent(msg []byte) string {
+	eventHashBytes := sha256.Sum256(msg)
+	return hex.EncodeToString(eventHashBytes[:])
+}